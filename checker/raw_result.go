@@ -15,6 +15,8 @@
 package checker
 
 import (
+	"path"
+	"strings"
 	"time"
 
 	"github.com/ossf/scorecard/v4/clients"
@@ -36,13 +38,17 @@ type RawResults struct {
 	CodeReviewResults           CodeReviewData
 	PinningDependenciesResults  PinningDependenciesData
 	WebhookResults              WebhooksData
+	RepositorySettingsResults   RepositorySettingsData
+	CommittedSecretsResults     CommittedSecretsData
 	ContributorsResults         ContributorsData
+	BusFactorResults            BusFactorData
 	MaintainedResults           MaintainedData
 	SignedReleasesResults       SignedReleasesData
 	FuzzingResults              FuzzingData
 	LicenseResults              LicenseData
 	TokenPermissionsResults     TokenPermissionsData
 	CITestResults               CITestData
+	SASTResults                 SASTData
 	Metadata                    MetadataData
 }
 
@@ -51,9 +57,12 @@ type MetadataData struct {
 }
 
 type RevisionCIInfo struct {
-	HeadSHA           string
-	CheckRuns         []clients.CheckRun
-	Statuses          []clients.Status
+	HeadSHA   string
+	CheckRuns []clients.CheckRun
+	Statuses  []clients.Status
+	// Categories are the sorted categories of the successful CI runs,
+	// e.g., "build", "lint" or "tests".
+	Categories        []string
 	PullRequestNumber int
 }
 
@@ -61,6 +70,63 @@ type CITestData struct {
 	CIInfo []RevisionCIInfo
 }
 
+// SASTData contains the raw results
+// for the SAST check.
+type SASTData struct {
+	// Workflows lists the configurations that run a SAST tool.
+	// It is nil if the check did not run.
+	Workflows []SASTWorkflow
+	// Commits lists the recently merged pull requests.
+	Commits []SASTCommit
+	// Languages lists the languages of the repository that known SAST tools analyze.
+	// It is nil if the languages of the repository cannot be determined.
+	Languages []clients.LanguageName
+}
+
+// SASTWorkflowType is the type of a SAST configuration.
+type SASTWorkflowType string
+
+const (
+	// SASTWorkflowCI is a CI configuration that runs a SAST tool,
+	// e.g., a GitHub workflow or a GitLab CI job.
+	SASTWorkflowCI SASTWorkflowType = "ci"
+	// SASTWorkflowSonarConfig is a Sonar configuration in a pom.xml file.
+	SASTWorkflowSonarConfig SASTWorkflowType = "sonarConfig"
+)
+
+// SASTTrigger is an event that runs a SAST tool.
+type SASTTrigger string
+
+const (
+	// SASTTriggerPullRequest is for tools run on pull requests.
+	SASTTriggerPullRequest SASTTrigger = "pullRequest"
+	// SASTTriggerPush is for tools run on pushes to branches.
+	SASTTriggerPush SASTTrigger = "push"
+	// SASTTriggerSchedule is for tools run on a schedule.
+	SASTTriggerSchedule SASTTrigger = "schedule"
+)
+
+// SASTWorkflow is a configuration that runs a SAST tool.
+type SASTWorkflow struct {
+	Type SASTWorkflowType
+	Tool string
+	File File
+	// Triggers lists the events that run the tool.
+	Triggers []SASTTrigger
+	// Languages lists the languages the tool analyzes, nil if it analyzes all languages.
+	Languages []clients.LanguageName
+}
+
+// SASTCommit is a merged pull request and the SAST tool that checked it.
+type SASTCommit struct {
+	HeadSHA           string
+	PullRequestNumber int
+	// Tool is the name of the tool that checked the pull request, empty if none did.
+	Tool string
+	// URL is the URL of the check run of the tool.
+	URL string
+}
+
 // FuzzingData represents different fuzzing done.
 type FuzzingData struct {
 	Fuzzers []Tool
@@ -76,23 +142,42 @@ type PackagingData struct {
 // Package represents a package.
 // nolint
 type Package struct {
-	// TODO: not supported yet. This needs to be unique across
+	// Name is the name of the package, when the workflow names it.
+	// TODO: This needs to be unique across
 	// ecosystems: purl, OSV, CPE, etc.
 	Name *string
 	Job  *WorkflowJob
 	File *File
 	// Note: Msg is populated only for debug messages.
-	Msg  *string
+	Msg *string
+	// Registry is the registry the package is published to, e.g., PyPI.
+	Registry string
+	// Auth is how the workflow authenticates to the registry.
+	Auth PackageAuth
 	Runs []Run
 }
 
+// PackageAuth is how a workflow authenticates to a package registry.
+type PackageAuth string
+
+const (
+	// PackageAuthUnknown is for workflows whose authentication cannot be determined.
+	PackageAuthUnknown PackageAuth = ""
+	// PackageAuthTrustedPublishing is for workflows that exchange an OIDC token
+	// with the registry, e.g., PyPI trusted publishing.
+	PackageAuthTrustedPublishing PackageAuth = "trustedPublishing"
+	// PackageAuthSecret is for workflows that use long-lived secrets.
+	PackageAuthSecret PackageAuth = "secret"
+)
+
 // DependencyUseType represents a type of dependency use.
 type DependencyUseType string
 
 const (
 	// DependencyUseTypeGHAction is an action.
 	DependencyUseTypeGHAction DependencyUseType = "GitHubAction"
-	// DependencyUseTypeDockerfileContainerImage a container image used via FROM.
+	// DependencyUseTypeDockerfileContainerImage a container image used via FROM,
+	// or by a workflow job, a workflow service or a Docker container action.
 	DependencyUseTypeDockerfileContainerImage DependencyUseType = "containerImage"
 	// DependencyUseTypeDownloadThenRun is a download followed by a run.
 	DependencyUseTypeDownloadThenRun DependencyUseType = "downloadThenRun"
@@ -106,11 +191,53 @@ const (
 	DependencyUseTypePipCommand DependencyUseType = "pipCommand"
 	// DependencyUseTypeNugetCommand is a nuget command.
 	DependencyUseTypeNugetCommand DependencyUseType = "nugetCommand"
+	// DependencyUseTypePowerShellCommand is a PowerShell command installing a module, e.g., Install-Module.
+	DependencyUseTypePowerShellCommand DependencyUseType = "powershellCommand"
+	// DependencyUseTypeWingetCommand is a winget command.
+	DependencyUseTypeWingetCommand DependencyUseType = "wingetCommand"
+	// DependencyUseTypeManifest is a dependency declared in a package manifest,
+	// e.g., package.json, that is not pinned by a lockfile or a hash.
+	DependencyUseTypeManifest DependencyUseType = "manifestDependency"
 )
 
 // PinningDependenciesData represents pinned dependency data.
 type PinningDependenciesData struct {
 	Dependencies []Dependency
+	// Manifests lists the package manifests of the repository.
+	Manifests []DependencyManifest
+	// PinVerifications lists the dependencies pinned by hash that failed verification.
+	// It is empty unless verification is enabled.
+	PinVerifications []PinVerification
+}
+
+// PinVerificationIssue is the reason a dependency pinned by hash failed verification.
+type PinVerificationIssue string
+
+const (
+	// PinVerificationIssueUnreachableCommit is a commit that is not reachable from
+	// the default branch or a tag of the dependency's repository, e.g., a commit of a fork.
+	PinVerificationIssueUnreachableCommit PinVerificationIssue = "unreachableCommit"
+	// PinVerificationIssueVersionMismatch is a version comment, e.g., `# v3`,
+	// that does not match the pinned commit.
+	PinVerificationIssueVersionMismatch PinVerificationIssue = "versionMismatch"
+)
+
+// PinVerification represents a dependency pinned by hash that failed verification.
+type PinVerification struct {
+	Dependency Dependency
+	Issue      PinVerificationIssue
+	// Version is the version in the comment following the hash, if any.
+	Version string
+}
+
+// DependencyManifest is a file that declares application dependencies.
+type DependencyManifest struct {
+	File File
+	// Ecosystem is the package ecosystem, e.g., npm.
+	Ecosystem string
+	// Lockfile is the committed lockfile that pins the dependencies
+	// of the manifest, nil if there is none.
+	Lockfile *File
 }
 
 // Dependency represents a dependency.
@@ -192,6 +319,26 @@ type ContributorsData struct {
 	Users []clients.User
 }
 
+// BusFactorData contains the raw results
+// for the Bus-Factor check.
+type BusFactorData struct {
+	// LookbackDate is the start of the analyzed activity window.
+	LookbackDate time.Time
+	// Maintainers who committed or merged changes since LookbackDate.
+	Maintainers []Maintainer
+	// NumCommits is the number of commits analyzed since LookbackDate.
+	NumCommits int
+}
+
+// Maintainer represents a user who committed or merged changes.
+type Maintainer struct {
+	// User contains the organizations and companies
+	// retrieved for the Contributors check.
+	User       clients.User
+	NumCommits int
+	NumMerges  int
+}
+
 // VulnerabilitiesData contains the raw results
 // for the Vulnerabilities check.
 type VulnerabilitiesData struct {
@@ -205,6 +352,15 @@ const (
 	SecurityPolicyInformationTypeEmail SecurityPolicyInformationType = "emailAddress"
 	SecurityPolicyInformationTypeLink  SecurityPolicyInformationType = "httpLink"
 	SecurityPolicyInformationTypeText  SecurityPolicyInformationType = "vulnDisclosureText"
+	// fields of RFC 9116 security.txt files.
+	SecurityPolicyInformationTypeContact    SecurityPolicyInformationType = "securityTxtContact"
+	SecurityPolicyInformationTypeExpires    SecurityPolicyInformationType = "securityTxtExpires"
+	SecurityPolicyInformationTypeEncryption SecurityPolicyInformationType = "securityTxtEncryption"
+	SecurityPolicyInformationTypePolicy     SecurityPolicyInformationType = "securityTxtPolicy"
+	// sections of security policy documents.
+	SecurityPolicyInformationTypeSupportedVersions SecurityPolicyInformationType = "supportedVersions"
+	SecurityPolicyInformationTypeResponseTime      SecurityPolicyInformationType = "responseTime"
+	SecurityPolicyInformationTypePrivateReporting  SecurityPolicyInformationType = "privateReportingChannel"
 )
 
 type SecurityPolicyValueType struct {
@@ -225,10 +381,21 @@ type SecurityPolicyFile struct {
 	File File
 }
 
+// IsSecurityTxt returns true if the file is an RFC 9116 security.txt file
+// rather than a security policy document, e.g., SECURITY.md.
+func (f *SecurityPolicyFile) IsSecurityTxt() bool {
+	return strings.EqualFold(path.Base(f.File.Path), "security.txt")
+}
+
 // SecurityPolicyData contains the raw results
 // for the Security-Policy check.
 type SecurityPolicyData struct {
 	PolicyFiles []SecurityPolicyFile
+	// Advisories are the security advisories published for the repository.
+	// They are nil when the repository client cannot list them.
+	Advisories []clients.SecurityAdvisory
+	// PrivateVulnerabilityReporting is unset when the setting could not be read.
+	PrivateVulnerabilityReporting *bool
 }
 
 // BinaryArtifactData contains the raw results
@@ -257,11 +424,104 @@ type WebhooksData struct {
 	Webhooks []clients.Webhook
 }
 
+// RepositorySettingsData contains the raw results
+// for the Repository-Settings check.
+type RepositorySettingsData struct {
+	// Settings are not set when they could not be read.
+	Settings clients.SecuritySettings
+}
+
+// CommittedSecretsData contains the raw results
+// for the Committed-Secrets check.
+type CommittedSecretsData struct {
+	// Secrets are the potential credentials found in the repository files.
+	Secrets []Secret
+}
+
+// Secret is a potential credential committed to the repository.
+// Its value is never stored, only its location.
+type Secret struct {
+	// Rule is the ID of the detection rule that matched, e.g., `aws-access-key-id`.
+	Rule string
+	// Reason is the justification given in the allowlist, if any.
+	Reason      string
+	File        File
+	Allowlisted bool
+}
+
 // BranchProtectionsData contains the raw results
 // for the Branch-Protection check.
 type BranchProtectionsData struct {
 	Branches        []clients.BranchRef
 	CodeownersFiles []string
+	// Codeowners contains the parsed CODEOWNERS files
+	// found in the locations supported by GitHub and GitLab.
+	Codeowners []CodeownersFile
+}
+
+// CodeownersFile represents a parsed CODEOWNERS file.
+type CodeownersFile struct {
+	File  File
+	Rules []CodeownersRule
+	// SensitivePaths lists the sensitive files found in the repository
+	// and the owners this file assigns to them.
+	SensitivePaths []CodeownersCoverage
+}
+
+// CodeownersRule represents a line assigning owners to a path pattern.
+type CodeownersRule struct {
+	Pattern string
+	// Section is the GitLab section the rule belongs to, if any.
+	Section string
+	Owners  []Codeowner
+	Line    uint
+}
+
+// CodeownerType represents the type of a code owner.
+type CodeownerType string
+
+const (
+	// CodeownerTypeUser is a user, e.g., `@octocat`.
+	CodeownerTypeUser CodeownerType = "user"
+	// CodeownerTypeTeam is a GitHub team or a GitLab group, e.g., `@org/team`.
+	CodeownerTypeTeam CodeownerType = "team"
+	// CodeownerTypeEmail is an email address.
+	CodeownerTypeEmail CodeownerType = "email"
+	// CodeownerTypeRole is a GitLab role, e.g., `@@maintainer`.
+	CodeownerTypeRole CodeownerType = "role"
+	// CodeownerTypeInvalid is an owner with an invalid syntax.
+	CodeownerTypeInvalid CodeownerType = "invalid"
+)
+
+// Codeowner represents an owner in a CODEOWNERS file.
+type Codeowner struct {
+	// Resolved indicates whether the hosting platform resolved the owner
+	// to an existing user or team. It is nil if this is unknown.
+	Resolved *bool
+	Name     string
+	Type     CodeownerType
+}
+
+// SensitivePathType represents a type of sensitive path.
+type SensitivePathType string
+
+const (
+	// SensitivePathTypeWorkflow is a CI workflow definition.
+	SensitivePathTypeWorkflow SensitivePathType = "workflow"
+	// SensitivePathTypeBuild is a build or dependency manifest file.
+	SensitivePathTypeBuild SensitivePathType = "build"
+	// SensitivePathTypeRelease is a release script or configuration.
+	SensitivePathTypeRelease SensitivePathType = "release"
+	// SensitivePathTypeCodeowners is the CODEOWNERS file itself.
+	SensitivePathTypeCodeowners SensitivePathType = "codeowners"
+)
+
+// CodeownersCoverage represents the owners of a sensitive path.
+type CodeownersCoverage struct {
+	Path string
+	Type SensitivePathType
+	// Owners is empty if no rule assigns owners to Path.
+	Owners []Codeowner
 }
 
 // Tool represents a tool.
@@ -315,6 +575,21 @@ const (
 	DangerousWorkflowScriptInjection DangerousWorkflowType = "scriptInjection"
 	// DangerousWorkflowUntrustedCheckout represents an untrusted checkout.
 	DangerousWorkflowUntrustedCheckout DangerousWorkflowType = "untrustedCheckout"
+	// DangerousWorkflowArtifactPoisoning represents the use of artifacts
+	// downloaded from the run that triggered a workflow_run workflow.
+	DangerousWorkflowArtifactPoisoning DangerousWorkflowType = "artifactPoisoning"
+	// DangerousWorkflowCachePoisoning represents a cache keyed on untrusted
+	// input in a privileged workflow.
+	DangerousWorkflowCachePoisoning DangerousWorkflowType = "cachePoisoning"
+	// DangerousWorkflowEnvInjection represents a write of untrusted
+	// input to GITHUB_ENV or GITHUB_OUTPUT.
+	DangerousWorkflowEnvInjection DangerousWorkflowType = "envInjection"
+	// DangerousWorkflowSelfHostedRunner represents a job reachable
+	// from forks that runs on a self-hosted runner.
+	DangerousWorkflowSelfHostedRunner DangerousWorkflowType = "selfHostedRunner"
+	// DangerousWorkflowSecretExposure represents secrets exposed
+	// to the code of merge requests from forks.
+	DangerousWorkflowSecretExposure DangerousWorkflowType = "secretExposure"
 )
 
 // DangerousWorkflowData contains raw results
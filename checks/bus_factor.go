@@ -0,0 +1,57 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/evaluation"
+	"github.com/ossf/scorecard/v4/checks/raw"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/probes"
+)
+
+// CheckBusFactor is the registered name for BusFactor.
+const CheckBusFactor = "Bus-Factor"
+
+//nolint:gochecknoinits
+func init() {
+	if err := registerCheck(CheckBusFactor, BusFactor, nil); err != nil {
+		// this should never happen
+		panic(err)
+	}
+}
+
+// BusFactor runs Bus-Factor check.
+func BusFactor(c *checker.CheckRequest) checker.CheckResult {
+	rawData, err := raw.BusFactor(c.RepoClient)
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		return checker.CreateRuntimeErrorResult(CheckBusFactor, e)
+	}
+
+	// Set the raw results.
+	pRawResults := getRawResults(c)
+	pRawResults.BusFactorResults = rawData
+
+	// Evaluate the probes.
+	findings, err := evaluateProbes(c, pRawResults, probes.BusFactor)
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		return checker.CreateRuntimeErrorResult(CheckBusFactor, e)
+	}
+
+	// Return the score evaluation.
+	return evaluation.BusFactor(CheckBusFactor, findings)
+}
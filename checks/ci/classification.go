@@ -0,0 +1,209 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ci classifies check runs and commit statuses by CI provider
+// and by the kind of verification they perform.
+package ci
+
+import (
+	// Used to embed classification.yml.
+	_ "embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// Category is the kind of verification a CI run performs.
+type Category string
+
+const (
+	// CategoryTests is for runs that execute tests.
+	CategoryTests Category = "tests"
+	// CategoryLint is for linters and formatters.
+	CategoryLint Category = "lint"
+	// CategoryBuild is for runs that build the project.
+	CategoryBuild Category = "build"
+	// CategorySecurityScan is for security scanners.
+	CategorySecurityScan Category = "securityScan"
+	// CategoryAutomation is for bots that do not verify the code, e.g., label bots.
+	CategoryAutomation Category = "automation"
+)
+
+// Run is a check run or status classified by provider and category.
+type Run struct {
+	// Provider is empty if the run is not from a known CI provider.
+	Provider string
+	Name     string
+	URL      string
+	// Category is empty if the run is not a CI run.
+	Category Category
+}
+
+//go:embed classification.yml
+var classificationYAML []byte
+
+type rule struct {
+	Name     string   `yaml:"name"`
+	Patterns []string `yaml:"patterns"`
+	regexps  []*regexp.Regexp
+}
+
+type classification struct {
+	Providers  []rule `yaml:"providers"`
+	Categories []rule `yaml:"categories"`
+}
+
+var (
+	loadOnce  sync.Once
+	rules     *classification
+	errLoaded error
+)
+
+func load() (*classification, error) {
+	loadOnce.Do(func() {
+		rules, errLoaded = parseClassification(classificationYAML)
+	})
+	return rules, errLoaded
+}
+
+func parseClassification(content []byte) (*classification, error) {
+	var c classification
+	if err := yaml.Unmarshal(content, &c); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("yaml.Unmarshal: %v", err))
+	}
+	for _, rs := range [][]rule{c.Providers, c.Categories} {
+		for i := range rs {
+			for _, p := range rs[i].Patterns {
+				re, err := regexp.Compile("(?i)" + p)
+				if err != nil {
+					return nil, sce.WithMessage(sce.ErrScorecardInternal,
+						fmt.Sprintf("invalid pattern for %s: %v", rs[i].Name, err))
+				}
+				rs[i].regexps = append(rs[i].regexps, re)
+			}
+		}
+	}
+	return &c, nil
+}
+
+// match returns the name of the first rule matching any of the values.
+func match(rs []rule, values ...string) string {
+	for i := range rs {
+		for _, re := range rs[i].regexps {
+			for _, v := range values {
+				if v != "" && re.MatchString(v) {
+					return rs[i].Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func classify(name, url string, providerValues ...string) (Run, error) {
+	c, err := load()
+	if err != nil {
+		return Run{}, err
+	}
+	run := Run{
+		Provider: match(c.Providers, providerValues...),
+		Name:     name,
+		URL:      url,
+		Category: Category(match(c.Categories, strings.TrimSpace(name))),
+	}
+	if run.Category == "" && run.Provider != "" {
+		run.Category = CategoryTests
+	}
+	return run, nil
+}
+
+// ClassifyCheckRun classifies a check run.
+func ClassifyCheckRun(cr *clients.CheckRun) (Run, error) {
+	name := cr.Name
+	if name == "" {
+		name = cr.App.Slug
+	}
+	return classify(name, cr.URL, cr.App.Slug, cr.URL)
+}
+
+// ClassifyStatus classifies a commit status.
+func ClassifyStatus(s *clients.Status) (Run, error) {
+	return classify(s.Context, s.URL, s.Context, s.TargetURL)
+}
+
+// RunsTests returns true if the run executes tests.
+// Builds are counted as tests, as CI build jobs usually run the tests too,
+// e.g., `ci/circleci: build`.
+func (r *Run) RunsTests() bool {
+	return r.Category == CategoryTests || r.Category == CategoryBuild
+}
+
+// IsCI returns true if the run verifies the code.
+func (r *Run) IsCI() bool {
+	return r.Category != "" && r.Category != CategoryAutomation
+}
+
+// IsSuccessfulCheckRun returns true if the check run completed successfully.
+func IsSuccessfulCheckRun(cr *clients.CheckRun) bool {
+	return cr.Status == "completed" && cr.Conclusion == "success"
+}
+
+// IsSuccessfulStatus returns true if the status is successful.
+func IsSuccessfulStatus(s *clients.Status) bool {
+	return s.State == "success"
+}
+
+// Categories returns the sorted categories of the successful CI runs of a revision.
+func Categories(statuses []clients.Status, checkRuns []clients.CheckRun) ([]string, error) {
+	var runs []Run
+	for i := range statuses {
+		if !IsSuccessfulStatus(&statuses[i]) {
+			continue
+		}
+		run, err := ClassifyStatus(&statuses[i])
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	for i := range checkRuns {
+		if !IsSuccessfulCheckRun(&checkRuns[i]) {
+			continue
+		}
+		run, err := ClassifyCheckRun(&checkRuns[i])
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	seen := map[Category]bool{}
+	var categories []string
+	for i := range runs {
+		if !runs[i].IsCI() || seen[runs[i].Category] {
+			continue
+		}
+		seen[runs[i].Category] = true
+		categories = append(categories, string(runs[i].Category))
+	}
+	sort.Strings(categories)
+	return categories, nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ci
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+func TestClassifyCheckRun(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		checkRun     clients.CheckRun
+		wantProvider string
+		wantCategory Category
+	}{
+		{
+			name:         "github actions without name",
+			checkRun:     clients.CheckRun{App: clients.CheckRunApp{Slug: "github-actions"}},
+			wantProvider: "GitHub Actions",
+			wantCategory: CategoryTests,
+		},
+		{
+			name:         "github actions unit tests",
+			checkRun:     clients.CheckRun{Name: "Unit Tests", App: clients.CheckRunApp{Slug: "github-actions"}},
+			wantProvider: "GitHub Actions",
+			wantCategory: CategoryTests,
+		},
+		{
+			name:         "github actions label bot",
+			checkRun:     clients.CheckRun{Name: "Labeler", App: clients.CheckRunApp{Slug: "github-actions"}},
+			wantProvider: "GitHub Actions",
+			wantCategory: CategoryAutomation,
+		},
+		{
+			name:         "github actions lint",
+			checkRun:     clients.CheckRun{Name: "golangci-lint", App: clients.CheckRunApp{Slug: "github-actions"}},
+			wantProvider: "GitHub Actions",
+			wantCategory: CategoryLint,
+		},
+		{
+			name:         "github actions build on latest runner",
+			checkRun:     clients.CheckRun{Name: "build (ubuntu-latest)", App: clients.CheckRunApp{Slug: "github-actions"}},
+			wantProvider: "GitHub Actions",
+			wantCategory: CategoryBuild,
+		},
+		{
+			name:         "codeql",
+			checkRun:     clients.CheckRun{Name: "CodeQL", App: clients.CheckRunApp{Slug: "github-actions"}},
+			wantProvider: "GitHub Actions",
+			wantCategory: CategorySecurityScan,
+		},
+		{
+			name:         "cirrus ci",
+			checkRun:     clients.CheckRun{App: clients.CheckRunApp{Slug: "cirrus-ci"}},
+			wantProvider: "Cirrus CI",
+			wantCategory: CategoryTests,
+		},
+		{
+			name:         "azure pipelines",
+			checkRun:     clients.CheckRun{App: clients.CheckRunApp{Slug: "azure-pipelines"}},
+			wantProvider: "Azure Pipelines",
+			wantCategory: CategoryTests,
+		},
+		{
+			name:         "e2e",
+			checkRun:     clients.CheckRun{App: clients.CheckRunApp{Slug: "e2e"}},
+			wantCategory: CategoryTests,
+		},
+		{
+			name:         "mergeable",
+			checkRun:     clients.CheckRun{App: clients.CheckRunApp{Slug: "mergeable"}},
+			wantCategory: CategoryAutomation,
+		},
+		{
+			name:     "non-existing",
+			checkRun: clients.CheckRun{App: clients.CheckRunApp{Slug: "non-existing"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			run, err := ClassifyCheckRun(&tt.checkRun)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if run.Provider != tt.wantProvider {
+				t.Errorf("provider = %q, want %q", run.Provider, tt.wantProvider)
+			}
+			if run.Category != tt.wantCategory {
+				t.Errorf("category = %q, want %q", run.Category, tt.wantCategory)
+			}
+		})
+	}
+}
+
+func TestClassifyStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		status       clients.Status
+		wantProvider string
+		wantCategory Category
+	}{
+		{
+			name:         "travis",
+			status:       clients.Status{Context: "continuous-integration/travis-ci/pr"},
+			wantProvider: "Travis CI",
+			wantCategory: CategoryTests,
+		},
+		{
+			name:         "circleci lint",
+			status:       clients.Status{Context: "ci/circleci: lint"},
+			wantProvider: "CircleCI",
+			wantCategory: CategoryLint,
+		},
+		{
+			name:         "buildkite",
+			status:       clients.Status{Context: "buildkite/project"},
+			wantProvider: "Buildkite",
+			wantCategory: CategoryTests,
+		},
+		{
+			name:         "jenkins",
+			status:       clients.Status{Context: "Jenkins"},
+			wantProvider: "Jenkins",
+			wantCategory: CategoryTests,
+		},
+		{
+			name:         "tekton",
+			status:       clients.Status{Context: "tekton-pipelines"},
+			wantProvider: "Tekton",
+			wantCategory: CategoryTests,
+		},
+		{
+			name:         "drone",
+			status:       clients.Status{Context: "continuous-integration/drone/pr"},
+			wantProvider: "Drone",
+			wantCategory: CategoryTests,
+		},
+		{
+			name: "prow job",
+			status: clients.Status{
+				Context:   "pull-kubernetes-verify",
+				TargetURL: "https://prow.k8s.io/view/gs/kubernetes-jenkins/pr-logs/1",
+			},
+			wantProvider: "Prow",
+			wantCategory: CategoryTests,
+		},
+		{
+			name: "prow tide",
+			status: clients.Status{
+				Context:   "tide",
+				TargetURL: "https://prow.k8s.io/tide",
+			},
+			wantProvider: "Prow",
+			wantCategory: CategoryAutomation,
+		},
+		{
+			name: "gitlab job",
+			status: clients.Status{
+				Context:   "rspec",
+				TargetURL: "https://gitlab.example.com/group/project/-/jobs/1",
+			},
+			wantProvider: "GitLab pipelines",
+			wantCategory: CategoryTests,
+		},
+		{
+			name:   "deploy preview",
+			status: clients.Status{Context: "netlify/deploy-preview"},
+		},
+		{
+			name:         "dco",
+			status:       clients.Status{Context: "DCO"},
+			wantCategory: CategoryAutomation,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			run, err := ClassifyStatus(&tt.status)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if run.Provider != tt.wantProvider {
+				t.Errorf("provider = %q, want %q", run.Provider, tt.wantProvider)
+			}
+			if run.Category != tt.wantCategory {
+				t.Errorf("category = %q, want %q", run.Category, tt.wantCategory)
+			}
+		})
+	}
+}
+
+func TestCategories(t *testing.T) {
+	t.Parallel()
+	statuses := []clients.Status{
+		{State: "success", Context: "ci/circleci: build"},
+		{State: "success", Context: "ci/circleci: lint"},
+		{State: "failure", Context: "ci/circleci: test"},
+		{State: "success", Context: "mergeable"},
+	}
+	checkRuns := []clients.CheckRun{
+		{Status: "completed", Conclusion: "success", Name: "golangci-lint", App: clients.CheckRunApp{Slug: "github-actions"}},
+		{Status: "completed", Conclusion: "success", Name: "CodeQL", App: clients.CheckRunApp{Slug: "github-actions"}},
+		{Status: "in_progress", Name: "e2e", App: clients.CheckRunApp{Slug: "github-actions"}},
+	}
+	got, err := Categories(statuses, checkRuns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Failed and pending runs, and automation, are ignored.
+	want := []string{"build", "lint", "securityScan"}
+	if len(got) != len(want) {
+		t.Fatalf("Categories() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Categories() = %v, want %v", got, want)
+		}
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/evaluation"
 	"github.com/ossf/scorecard/v4/checks/raw"
+	"github.com/ossf/scorecard/v4/checks/raw/gitlab"
+	"github.com/ossf/scorecard/v4/clients/gitlabrepo"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
@@ -38,7 +40,15 @@ func init() {
 
 // DangerousWorkflow  will check the repository contains Dangerous-Workflow.
 func DangerousWorkflow(c *checker.CheckRequest) checker.CheckResult {
-	rawData, err := raw.DangerousWorkflow(c.RepoClient)
+	var rawData checker.DangerousWorkflowData
+	var err error
+
+	switch c.RepoClient.(type) {
+	case *gitlabrepo.Client:
+		rawData, err = gitlab.DangerousWorkflow(c)
+	default:
+		rawData, err = raw.DangerousWorkflow(c.RepoClient)
+	}
 	if err != nil {
 		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 		return checker.CreateRuntimeErrorResult(CheckDangerousWorkflow, e)
@@ -78,7 +78,7 @@ func BranchProtection(name string, dl checker.DetailLogger,
 		score.scores.thoroughReview, score.maxes.thoroughReview = nonAdminThoroughReviewProtection(&b, dl)
 		// Do we want this?
 		score.scores.adminThoroughReview, score.maxes.adminThoroughReview = adminThoroughReviewProtection(&b, dl)
-		score.scores.codeownerReview, score.maxes.codeownerReview = codeownerBranchProtection(&b, r.CodeownersFiles, dl)
+		score.scores.codeownerReview, score.maxes.codeownerReview = codeownerBranchProtection(&b, r.CodeownersFiles, r.Codeowners, dl)
 
 		scores = append(scores, score)
 	}
@@ -450,7 +450,7 @@ func nonAdminThoroughReviewProtection(branch *clients.BranchRef, dl checker.Deta
 }
 
 func codeownerBranchProtection(
-	branch *clients.BranchRef, codeownersFiles []string, dl checker.DetailLogger,
+	branch *clients.BranchRef, codeownersFiles []string, codeowners []checker.CodeownersFile, dl checker.DetailLogger,
 ) (int, int) {
 	score := 0
 	max := 1
@@ -461,10 +461,16 @@ func codeownerBranchProtection(
 		switch *branch.BranchProtectionRule.RequiredPullRequestReviews.RequireCodeOwnerReviews {
 		case true:
 			info(dl, log, "codeowner review is required on branch '%s'", *branch.Name)
-			if len(codeownersFiles) == 0 {
+			switch {
+			case len(codeownersFiles) == 0:
 				warn(dl, log, "codeowners branch protection is being ignored - but no codeowners file found in repo")
-			} else {
+			case len(codeowners) > 0 && !hasValidCodeowners(codeowners):
+				warn(dl, log, "codeowners branch protection is being ignored - but codeowners file assigns no valid owner")
+			default:
 				score++
+				if n := countUncoveredSensitivePaths(codeowners); n > 0 {
+					warn(dl, log, "%d sensitive file(s) have no codeowner", n)
+				}
 			}
 		default:
 			warn(dl, log, "codeowner review is not required on branch '%s'", *branch.Name)
@@ -473,3 +479,46 @@ func codeownerBranchProtection(
 
 	return score, max
 }
+
+// hasValidCodeowners returns true if a CODEOWNERS file assigns
+// at least one owner that is not known to be invalid.
+func hasValidCodeowners(codeowners []checker.CodeownersFile) bool {
+	for i := range codeowners {
+		for j := range codeowners[i].Rules {
+			if hasValidOwner(codeowners[i].Rules[j].Owners) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasValidOwner(owners []checker.Codeowner) bool {
+	for i := range owners {
+		if owners[i].Resolved == nil || *owners[i].Resolved {
+			return true
+		}
+	}
+	return false
+}
+
+func countUncoveredSensitivePaths(codeowners []checker.CodeownersFile) int {
+	uncovered := make(map[string]bool)
+	for i := range codeowners {
+		for _, s := range codeowners[i].SensitivePaths {
+			if _, seen := uncovered[s.Path]; !seen {
+				uncovered[s.Path] = true
+			}
+			if hasValidOwner(s.Owners) {
+				uncovered[s.Path] = false
+			}
+		}
+	}
+	n := 0
+	for _, u := range uncovered {
+		if u {
+			n++
+		}
+	}
+	return n
+}
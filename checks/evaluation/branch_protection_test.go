@@ -31,7 +31,7 @@ func testScore(branch *clients.BranchRef, codeownersFiles []string, dl checker.D
 	score.scores.context, score.maxes.context = nonAdminContextProtection(branch, dl)
 	score.scores.thoroughReview, score.maxes.thoroughReview = nonAdminThoroughReviewProtection(branch, dl)
 	score.scores.adminThoroughReview, score.maxes.adminThoroughReview = adminThoroughReviewProtection(branch, dl)
-	score.scores.codeownerReview, score.maxes.codeownerReview = codeownerBranchProtection(branch, codeownersFiles, dl)
+	score.scores.codeownerReview, score.maxes.codeownerReview = codeownerBranchProtection(branch, codeownersFiles, nil, dl)
 
 	return computeScore([]levelScore{score})
 }
@@ -423,3 +423,103 @@ func TestIsBranchProtected(t *testing.T) {
 		})
 	}
 }
+
+func TestCodeownerBranchProtection(t *testing.T) {
+	t.Parallel()
+	trueVal := true
+	falseVal := false
+	branchVal := "branch-name"
+	branch := &clients.BranchRef{
+		Name:      &branchVal,
+		Protected: &trueVal,
+		BranchProtectionRule: clients.BranchProtectionRule{
+			RequiredPullRequestReviews: clients.PullRequestReviewRule{
+				RequireCodeOwnerReviews: &trueVal,
+			},
+		},
+	}
+	//nolint:govet
+	tests := []struct {
+		name            string
+		codeownersFiles []string
+		codeowners      []checker.CodeownersFile
+		wantScore       int
+		wantWarn        int
+	}{
+		{
+			name:      "no codeowners file",
+			wantScore: 0,
+			wantWarn:  1,
+		},
+		{
+			name:            "codeowners file not parsed",
+			codeownersFiles: []string{"CODEOWNERS"},
+			wantScore:       1,
+		},
+		{
+			name:            "valid owners covering sensitive files",
+			codeownersFiles: []string{"CODEOWNERS"},
+			codeowners: []checker.CodeownersFile{
+				{
+					Rules: []checker.CodeownersRule{
+						{Pattern: "*", Owners: []checker.Codeowner{{Name: "@alice", Resolved: &trueVal}}},
+					},
+					SensitivePaths: []checker.CodeownersCoverage{
+						{Path: "Makefile", Owners: []checker.Codeowner{{Name: "@alice", Resolved: &trueVal}}},
+					},
+				},
+			},
+			wantScore: 1,
+		},
+		{
+			name:            "uncovered sensitive files",
+			codeownersFiles: []string{"CODEOWNERS"},
+			codeowners: []checker.CodeownersFile{
+				{
+					Rules: []checker.CodeownersRule{
+						{Pattern: "*.go", Owners: []checker.Codeowner{{Name: "@alice"}}},
+					},
+					SensitivePaths: []checker.CodeownersCoverage{
+						{Path: "Makefile"},
+						{Path: ".github/workflows/ci.yml"},
+					},
+				},
+			},
+			wantScore: 1,
+			wantWarn:  1,
+		},
+		{
+			name:            "no valid owner",
+			codeownersFiles: []string{"CODEOWNERS"},
+			codeowners: []checker.CodeownersFile{
+				{
+					Rules: []checker.CodeownersRule{
+						{Pattern: "*", Owners: []checker.Codeowner{{Name: "@ghost", Resolved: &falseVal}}},
+					},
+				},
+			},
+			wantScore: 0,
+			wantWarn:  1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			score, _ := codeownerBranchProtection(branch, tt.codeownersFiles, tt.codeowners, &dl)
+			if score != tt.wantScore {
+				t.Errorf("score = %d, want %d", score, tt.wantScore)
+			}
+			warns := 0
+			for _, m := range dl.Flush() {
+				if m.Type == checker.DetailWarn {
+					warns++
+				}
+			}
+			if warns != tt.wantWarn {
+				t.Errorf("warnings = %d, want %d", warns, tt.wantWarn)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/hasMultipleActiveMaintainers"
+	"github.com/ossf/scorecard/v4/probes/maintainersFromMultipleOrgs"
+	"github.com/ossf/scorecard/v4/probes/topContributorCommitShareLow"
+)
+
+// BusFactor applies the score policy for the Bus-Factor check.
+func BusFactor(name string, findings []finding.Finding) checker.CheckResult {
+	// We have 3 unique probes, each should have a finding.
+	expectedProbes := []string{
+		hasMultipleActiveMaintainers.Probe,
+		topContributorCommitShareLow.Probe,
+		maintainersFromMultipleOrgs.Probe,
+	}
+	if !finding.UniqueProbesEqual(findings, expectedProbes) {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "invalid probe results")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	score := 0
+	m := make(map[string]bool)
+	for i := range findings {
+		f := &findings[i]
+		if f.Outcome != finding.OutcomePositive {
+			continue
+		}
+		switch f.Probe {
+		case hasMultipleActiveMaintainers.Probe:
+			score += scoreProbeOnce(f.Probe, m, 4)
+		case topContributorCommitShareLow.Probe:
+			score += scoreProbeOnce(f.Probe, m, 3)
+		case maintainersFromMultipleOrgs.Probe:
+			score += scoreProbeOnce(f.Probe, m, 3)
+		default:
+			e := sce.WithMessage(sce.ErrScorecardInternal, "unknown probe results")
+			return checker.CreateRuntimeErrorResult(name, e)
+		}
+	}
+
+	if _, multiple := m[hasMultipleActiveMaintainers.Probe]; !multiple {
+		return checker.CreateMinScoreResult(name, "fewer than 2 maintainers active in the last year")
+	}
+
+	return checker.CreateResultWithScore(name, "multiple maintainers active in the last year", score)
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+func TestBusFactor(t *testing.T) {
+	t.Parallel()
+	//nolint
+	tests := []struct {
+		name     string
+		findings []finding.Finding
+		want     checker.CheckResult
+	}{
+		{
+			name: "missing findings",
+			findings: []finding.Finding{
+				{
+					Probe:   "hasMultipleActiveMaintainers",
+					Outcome: finding.OutcomePositive,
+				},
+			},
+			want: checker.CheckResult{
+				Score: -1,
+			},
+		},
+		{
+			name: "single maintainer",
+			findings: []finding.Finding{
+				{
+					Probe:   "hasMultipleActiveMaintainers",
+					Outcome: finding.OutcomeNegative,
+				},
+				{
+					Probe:   "topContributorCommitShareLow",
+					Outcome: finding.OutcomeNegative,
+				},
+				{
+					Probe:   "maintainersFromMultipleOrgs",
+					Outcome: finding.OutcomeNegative,
+				},
+			},
+			want: checker.CheckResult{
+				Score: 0,
+			},
+		},
+		{
+			name: "no activity",
+			findings: []finding.Finding{
+				{
+					Probe:   "hasMultipleActiveMaintainers",
+					Outcome: finding.OutcomeNegative,
+				},
+				{
+					Probe:   "topContributorCommitShareLow",
+					Outcome: finding.OutcomeNotAvailable,
+				},
+				{
+					Probe:   "maintainersFromMultipleOrgs",
+					Outcome: finding.OutcomeNotAvailable,
+				},
+			},
+			want: checker.CheckResult{
+				Score: 0,
+			},
+		},
+		{
+			name: "multiple maintainers, concentrated activity, single org",
+			findings: []finding.Finding{
+				{
+					Probe:   "hasMultipleActiveMaintainers",
+					Outcome: finding.OutcomePositive,
+				},
+				{
+					Probe:   "topContributorCommitShareLow",
+					Outcome: finding.OutcomeNegative,
+				},
+				{
+					Probe:   "maintainersFromMultipleOrgs",
+					Outcome: finding.OutcomeNegative,
+				},
+			},
+			want: checker.CheckResult{
+				Score: 4,
+			},
+		},
+		{
+			name: "multiple maintainers, shared activity, unknown orgs",
+			findings: []finding.Finding{
+				{
+					Probe:   "hasMultipleActiveMaintainers",
+					Outcome: finding.OutcomePositive,
+				},
+				{
+					Probe:   "topContributorCommitShareLow",
+					Outcome: finding.OutcomePositive,
+				},
+				{
+					Probe:   "maintainersFromMultipleOrgs",
+					Outcome: finding.OutcomeNotAvailable,
+				},
+			},
+			want: checker.CheckResult{
+				Score: 7,
+			},
+		},
+		{
+			name: "all probes positive",
+			findings: []finding.Finding{
+				{
+					Probe:   "hasMultipleActiveMaintainers",
+					Outcome: finding.OutcomePositive,
+				},
+				{
+					Probe:   "topContributorCommitShareLow",
+					Outcome: finding.OutcomePositive,
+				},
+				{
+					Probe:   "maintainersFromMultipleOrgs",
+					Outcome: finding.OutcomePositive,
+				},
+			},
+			want: checker.CheckResult{
+				Score: 10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := BusFactor("Bus-Factor", tt.findings)
+			if got.Score != tt.want.Score {
+				t.Errorf("BusFactor() = %v, want %v for %v", got.Score, tt.want.Score, tt.name)
+			}
+		})
+	}
+}
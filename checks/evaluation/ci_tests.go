@@ -19,13 +19,13 @@ import (
 	"strings"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/ci"
 	"github.com/ossf/scorecard/v4/finding"
 )
 
 const (
 	// CheckCITests is the registered name for CITests.
 	CheckCITests = "CI-Tests"
-	success      = "success"
 )
 
 func CITests(_ string, c *checker.CITestData, dl checker.DetailLogger) checker.CheckResult {
@@ -45,17 +45,25 @@ func CITests(_ string, c *checker.CITestData, dl checker.DetailLogger) checker.C
 		if prSuccessStatus {
 			totalTested++
 			foundCI = true
-			continue
 		}
 
 		// GitHub Check Runs.
-		prCheckSuccessful, err := prHasSuccessfulCheck(r, dl)
-		if err != nil {
-			return checker.CreateRuntimeErrorResult(CheckCITests, err)
+		if !foundCI {
+			prCheckSuccessful, err := prHasSuccessfulCheck(r, dl)
+			if err != nil {
+				return checker.CreateRuntimeErrorResult(CheckCITests, err)
+			}
+			if prCheckSuccessful {
+				totalTested++
+				foundCI = true
+			}
 		}
-		if prCheckSuccessful {
-			totalTested++
-			foundCI = true
+
+		// Report the kinds of CI runs, e.g., to spot PRs that were only linted.
+		if len(r.Categories) > 0 {
+			dl.Debug(&checker.LogMessage{
+				Text: fmt.Sprintf("merged PR %d ran: %s", r.PullRequestNumber, strings.Join(r.Categories, ", ")),
+			})
 		}
 
 		if !foundCI {
@@ -76,15 +84,18 @@ func CITests(_ string, c *checker.CITestData, dl checker.DetailLogger) checker.C
 	return checker.CreateProportionalScoreResult(CheckCITests, reason, totalTested, totalMerged)
 }
 
-// PR has a status marked 'success' and a CI-related context.
-//
-//nolint:unparam
+// PR has a status marked 'success' that runs tests.
 func prHasSuccessStatus(r checker.RevisionCIInfo, dl checker.DetailLogger) (bool, error) {
-	for _, status := range r.Statuses {
-		if status.State != success {
+	for i := range r.Statuses {
+		status := &r.Statuses[i]
+		if !ci.IsSuccessfulStatus(status) {
 			continue
 		}
-		if isTest(status.Context) || isTest(status.TargetURL) {
+		run, err := ci.ClassifyStatus(status)
+		if err != nil {
+			return false, fmt.Errorf("ci.ClassifyStatus: %w", err)
+		}
+		if run.RunsTests() {
 			dl.Debug(&checker.LogMessage{
 				Path: status.URL,
 				Type: finding.FileTypeURL,
@@ -97,43 +108,26 @@ func prHasSuccessStatus(r checker.RevisionCIInfo, dl checker.DetailLogger) (bool
 	return false, nil
 }
 
-// PR has a successful CI-related check.
-//
-//nolint:unparam
+// PR has a successful check that runs tests.
 func prHasSuccessfulCheck(r checker.RevisionCIInfo, dl checker.DetailLogger) (bool, error) {
-	for _, cr := range r.CheckRuns {
-		if cr.Status != "completed" {
+	for i := range r.CheckRuns {
+		cr := &r.CheckRuns[i]
+		if !ci.IsSuccessfulCheckRun(cr) {
 			continue
 		}
-		if cr.Conclusion != success {
-			continue
+		run, err := ci.ClassifyCheckRun(cr)
+		if err != nil {
+			return false, fmt.Errorf("ci.ClassifyCheckRun: %w", err)
 		}
-		if isTest(cr.App.Slug) {
+		if run.RunsTests() {
 			dl.Debug(&checker.LogMessage{
 				Path: cr.URL,
 				Type: finding.FileTypeURL,
 				Text: fmt.Sprintf("CI test found: pr: %d, context: %s", r.PullRequestNumber,
-					cr.App.Slug),
+					run.Name),
 			})
 			return true, nil
 		}
 	}
 	return false, nil
 }
-
-// isTest returns true if the given string is a CI test.
-func isTest(s string) bool {
-	l := strings.ToLower(s)
-
-	// Add more patterns here!
-	for _, pattern := range []string{
-		"appveyor", "buildkite", "circleci", "e2e", "github-actions", "jenkins",
-		"mergeable", "packit-as-a-service", "semaphoreci", "test", "travis-ci",
-		"flutter-dashboard", "Cirrus CI", "azure-pipelines",
-	} {
-		if strings.Contains(l, pattern) {
-			return true
-		}
-	}
-	return false
-}
@@ -21,6 +21,8 @@ import (
 	scut "github.com/ossf/scorecard/v4/utests"
 )
 
+// Test_isTest checks which status contexts and check run apps count as CI tests.
+// `mergeable` is a pull request rules bot, and runs that only lint the code do not test it.
 func Test_isTest(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -71,7 +73,7 @@ func Test_isTest(t *testing.T) {
 			args: args{
 				s: "mergeable",
 			},
-			want: true,
+			want: false,
 		},
 		{
 			name: "packit-as-a-service",
@@ -108,6 +110,20 @@ func Test_isTest(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "ci/circleci: build",
+			args: args{
+				s: "ci/circleci: build",
+			},
+			want: true,
+		},
+		{
+			name: "ci/circleci: lint",
+			args: args{
+				s: "ci/circleci: lint",
+			},
+			want: false,
+		},
 		{
 			name: "non-existing",
 			args: args{
@@ -120,8 +136,21 @@ func Test_isTest(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := isTest(tt.args.s); got != tt.want {
-				t.Errorf("isTest() = %v, want %v for test %v", got, tt.want, tt.name)
+			r := checker.RevisionCIInfo{
+				Statuses: []clients.Status{{State: "success", Context: tt.args.s}},
+			}
+			if got, err := prHasSuccessStatus(r, &scut.TestDetailLogger{}); err != nil || got != tt.want {
+				t.Errorf("prHasSuccessStatus() = %v, %v, want %v for status %v", got, err, tt.want, tt.name)
+			}
+			r = checker.RevisionCIInfo{
+				CheckRuns: []clients.CheckRun{{
+					Status:     "completed",
+					Conclusion: "success",
+					App:        clients.CheckRunApp{Slug: tt.args.s},
+				}},
+			}
+			if got, err := prHasSuccessfulCheck(r, &scut.TestDetailLogger{}); err != nil || got != tt.want {
+				t.Errorf("prHasSuccessfulCheck() = %v, %v, want %v for check run %v", got, err, tt.want, tt.name)
 			}
 		})
 	}
@@ -434,6 +463,40 @@ func TestCITests(t *testing.T) {
 			},
 			want: 10,
 		},
+		{
+			name: "only label bot and lint",
+			args: args{
+				in0: "",
+				c: &checker.CITestData{
+					CIInfo: []checker.RevisionCIInfo{
+						{
+							CheckRuns: []clients.CheckRun{
+								{
+									Status:     "completed",
+									Conclusion: "success",
+									Name:       "Labeler",
+									App:        clients.CheckRunApp{Slug: "github-actions"},
+								},
+								{
+									Status:     "completed",
+									Conclusion: "success",
+									Name:       "golangci-lint",
+									App:        clients.CheckRunApp{Slug: "github-actions"},
+								},
+							},
+							Statuses: []clients.Status{
+								{
+									State:   "success",
+									Context: "mergeable",
+								},
+							},
+						},
+					},
+				},
+				dl: &scut.TestDetailLogger{},
+			},
+			want: 0,
+		},
 		{
 			name: "no ci info",
 			args: args{
@@ -0,0 +1,49 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/noCommittedSecrets"
+)
+
+// CommittedSecrets applies the score policy for the Committed-Secrets check.
+func CommittedSecrets(name string, findings []finding.Finding) checker.CheckResult {
+	expectedProbes := []string{
+		noCommittedSecrets.Probe,
+	}
+	if !finding.UniqueProbesEqual(findings, expectedProbes) {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "invalid probe results")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	// A single leaked credential can compromise the project:
+	// any secret that is not allowlisted gives the minimum score.
+	secrets := 0
+	for i := range findings {
+		if findings[i].Outcome == finding.OutcomeNegative {
+			secrets++
+		}
+	}
+	if secrets > 0 {
+		return checker.CreateMinScoreResult(name,
+			fmt.Sprintf("%d potential secrets committed to the repository", secrets))
+	}
+	return checker.CreateMaxScoreResult(name, "no committed secrets detected")
+}
@@ -41,6 +41,16 @@ func DangerousWorkflow(name string, dl checker.DetailLogger,
 			text = fmt.Sprintf("untrusted code checkout '%v'", e.File.Snippet)
 		case checker.DangerousWorkflowScriptInjection:
 			text = fmt.Sprintf("script injection with untrusted input '%v'", e.File.Snippet)
+		case checker.DangerousWorkflowArtifactPoisoning:
+			text = fmt.Sprintf("untrusted artifact of the triggering run downloaded with '%v' and used", e.File.Snippet)
+		case checker.DangerousWorkflowCachePoisoning:
+			text = fmt.Sprintf("cache keyed on untrusted input '%v' in privileged workflow", e.File.Snippet)
+		case checker.DangerousWorkflowEnvInjection:
+			text = fmt.Sprintf("untrusted input written to environment or outputs '%v'", e.File.Snippet)
+		case checker.DangerousWorkflowSelfHostedRunner:
+			text = fmt.Sprintf("job reachable from forks runs on self-hosted runner '%v'", e.File.Snippet)
+		case checker.DangerousWorkflowSecretExposure:
+			text = fmt.Sprintf("secret '%v' exposed to merge requests from forks", e.File.Snippet)
 		default:
 			err := sce.WithMessage(sce.ErrScorecardInternal, "invalid type")
 			return checker.CreateRuntimeErrorResult(name, err)
@@ -121,6 +121,136 @@ func TestDangerousWorkflow(t *testing.T) {
 				Name:    "DangerousWorkflow",
 			},
 		},
+		{
+			name: "DangerousWorkflow - Artifact poisoning detected",
+			args: args{
+				name: "DangerousWorkflow",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.DangerousWorkflowData{
+					NumWorkflows: 1,
+					Workflows: []checker.DangerousWorkflow{
+						{
+							Type: checker.DangerousWorkflowArtifactPoisoning,
+							File: checker.File{
+								Path:    "a",
+								Snippet: "a",
+								Offset:  0,
+							},
+						},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score:   0,
+				Reason:  "dangerous workflow patterns detected",
+				Version: 2,
+				Name:    "DangerousWorkflow",
+			},
+		},
+		{
+			name: "DangerousWorkflow - Cache poisoning detected",
+			args: args{
+				name: "DangerousWorkflow",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.DangerousWorkflowData{
+					NumWorkflows: 1,
+					Workflows: []checker.DangerousWorkflow{
+						{
+							Type: checker.DangerousWorkflowCachePoisoning,
+							File: checker.File{
+								Path:    "a",
+								Snippet: "a",
+								Offset:  0,
+							},
+						},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score:   0,
+				Reason:  "dangerous workflow patterns detected",
+				Version: 2,
+				Name:    "DangerousWorkflow",
+			},
+		},
+		{
+			name: "DangerousWorkflow - Env injection detected",
+			args: args{
+				name: "DangerousWorkflow",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.DangerousWorkflowData{
+					NumWorkflows: 1,
+					Workflows: []checker.DangerousWorkflow{
+						{
+							Type: checker.DangerousWorkflowEnvInjection,
+							File: checker.File{
+								Path:    "a",
+								Snippet: "a",
+								Offset:  0,
+							},
+						},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score:   0,
+				Reason:  "dangerous workflow patterns detected",
+				Version: 2,
+				Name:    "DangerousWorkflow",
+			},
+		},
+		{
+			name: "DangerousWorkflow - Self-hosted runner detected",
+			args: args{
+				name: "DangerousWorkflow",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.DangerousWorkflowData{
+					NumWorkflows: 1,
+					Workflows: []checker.DangerousWorkflow{
+						{
+							Type: checker.DangerousWorkflowSelfHostedRunner,
+							File: checker.File{
+								Path:    "a",
+								Snippet: "self-hosted",
+								Offset:  0,
+							},
+						},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score:   0,
+				Reason:  "dangerous workflow patterns detected",
+				Version: 2,
+				Name:    "DangerousWorkflow",
+			},
+		},
+		{
+			name: "DangerousWorkflow - Secret exposure detected",
+			args: args{
+				name: "DangerousWorkflow",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.DangerousWorkflowData{
+					NumWorkflows: 1,
+					Workflows: []checker.DangerousWorkflow{
+						{
+							Type: checker.DangerousWorkflowSecretExposure,
+							File: checker.File{
+								Path:    "a",
+								Snippet: "$NPM_TOKEN",
+								Offset:  0,
+							},
+						},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score:   0,
+				Reason:  "dangerous workflow patterns detected",
+				Version: 2,
+				Name:    "DangerousWorkflow",
+			},
+		},
 		{
 			name: "DangerousWorkflow - unknown type",
 			args: args{
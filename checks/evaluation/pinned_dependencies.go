@@ -17,6 +17,7 @@ package evaluation
 import (
 	"errors"
 	"fmt"
+	"path"
 
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/fileparser"
@@ -96,6 +97,27 @@ func PinningDependencies(name string, c *checker.CheckRequest,
 		}
 	}
 
+	for i := range r.PinVerifications {
+		v := r.PinVerifications[i]
+		if v.Dependency.Location == nil {
+			e := sce.WithMessage(sce.ErrScorecardInternal, "empty File field")
+			return checker.CreateRuntimeErrorResult(name, e)
+		}
+		dl.Warn(&checker.LogMessage{
+			Path:      v.Dependency.Location.Path,
+			Type:      v.Dependency.Location.Type,
+			Offset:    v.Dependency.Location.Offset,
+			EndOffset: v.Dependency.Location.EndOffset,
+			Text:      generatePinVerificationText(&v),
+			Snippet:   v.Dependency.Location.Snippet,
+		})
+
+		// A commit that does not belong to the dependency's repository does not pin it.
+		if v.Issue == checker.PinVerificationIssueUnreachableCommit {
+			updatePinningResults(&v.Dependency, &wp, pr)
+		}
+	}
+
 	// Generate scores and Info results.
 	// GitHub actions.
 	actionScore, err := createReturnForIsGitHubActionsWorkflowPinned(wp, dl)
@@ -148,8 +170,19 @@ func PinningDependencies(name string, c *checker.CheckRequest,
 	npmScore = maxScore(0, npmScore)
 	goScore = maxScore(0, goScore)
 
-	score := checker.AggregateScores(actionScore, dockerFromScore,
-		dockerDownloadScore, scriptScore, pipScore, npmScore, goScore)
+	scores := []int{
+		actionScore, dockerFromScore,
+		dockerDownloadScore, scriptScore, pipScore, npmScore, goScore,
+	}
+
+	// Package manifests are reported, but do not count towards the score.
+	if len(r.Manifests) > 0 {
+		if _, err := createReturnForIsManifestPinned(pr, dl); err != nil {
+			return checker.CreateRuntimeErrorResult(name, err)
+		}
+	}
+
+	score := checker.AggregateScores(scores...)
 
 	if score == checker.MaxResultScore {
 		return checker.CreateMaxScoreResult(name, "all dependencies are pinned")
@@ -162,14 +195,27 @@ func PinningDependencies(name string, c *checker.CheckRequest,
 func generateRemediation(remediationMd *remediation.RemediationMetadata, rr *checker.Dependency) *rule.Remediation {
 	switch rr.Type {
 	case checker.DependencyUseTypeGHAction:
+		if !fileparser.IsWorkflowFile(rr.Location.Path) {
+			return remediation.CreateActionPinningRemediation(rr)
+		}
 		return remediationMd.CreateWorkflowPinningRemediation(rr.Location.Path)
 	case checker.DependencyUseTypeDockerfileContainerImage:
+		if isGitHubActionsFile(rr.Location.Path) {
+			return remediation.CreateContainerImagePinningRemediation(rr, remediation.CraneDigester{})
+		}
 		return remediation.CreateDockerfilePinningRemediation(rr, remediation.CraneDigester{})
 	default:
 		return nil
 	}
 }
 
+// isGitHubActionsFile returns true for workflows and action metadata files,
+// which may use container images outside of Dockerfiles.
+func isGitHubActionsFile(pathfn string) bool {
+	base := path.Base(pathfn)
+	return fileparser.IsWorkflowFile(pathfn) || base == "action.yml" || base == "action.yaml"
+}
+
 func updatePinningResults(rr *checker.Dependency,
 	wp *worklowPinningResult, pr map[checker.DependencyUseType]pinnedResult,
 ) {
@@ -195,9 +241,30 @@ func generateText(rr *checker.Dependency) string {
 		return fmt.Sprintf("%s %s not pinned by hash", owner, rr.Type)
 	}
 
+	if rr.Type == checker.DependencyUseTypeManifest && rr.Name != nil {
+		return fmt.Sprintf("dependency %s not pinned by a lockfile or hash", *rr.Name)
+	}
+
 	return fmt.Sprintf("%s not pinned by hash", rr.Type)
 }
 
+func generatePinVerificationText(v *checker.PinVerification) string {
+	name := v.Dependency.Location.Snippet
+	if v.Dependency.Name != nil {
+		name = *v.Dependency.Name
+	}
+	switch v.Issue {
+	case checker.PinVerificationIssueUnreachableCommit:
+		return fmt.Sprintf("%s %s pinned to a commit not found on the branches or tags of %s",
+			v.Dependency.Type, v.Dependency.Location.Snippet, name)
+	case checker.PinVerificationIssueVersionMismatch:
+		return fmt.Sprintf("%s %s: version comment %s does not match the pinned commit",
+			v.Dependency.Type, v.Dependency.Location.Snippet, v.Version)
+	default:
+		return fmt.Sprintf("%s %s failed pin verification", v.Dependency.Type, v.Dependency.Location.Snippet)
+	}
+}
+
 func generateOwnerToDisplay(gitHubOwned bool) string {
 	if gitHubOwned {
 		return "GitHub-owned"
@@ -292,6 +359,15 @@ func createReturnForIsGoInstallPinned(pr map[checker.DependencyUseType]pinnedRes
 		dl)
 }
 
+// Create the result for dependencies declared in package manifests.
+func createReturnForIsManifestPinned(pr map[checker.DependencyUseType]pinnedResult,
+	dl checker.DetailLogger,
+) (int, error) {
+	return createReturnValues(pr, checker.DependencyUseTypeManifest,
+		"dependencies in package manifests are pinned",
+		dl)
+}
+
 func createReturnValues(pr map[checker.DependencyUseType]pinnedResult,
 	t checker.DependencyUseType, infoMsg string,
 	dl checker.DetailLogger,
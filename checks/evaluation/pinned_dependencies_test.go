@@ -96,6 +96,8 @@ func Test_PinningDependencies(t *testing.T) {
 	tests := []struct {
 		name         string
 		dependencies []checker.Dependency
+		manifests    []checker.DependencyManifest
+		verified     []checker.PinVerification
 		expected     scut.TestReturn
 	}{
 		{
@@ -267,6 +269,84 @@ func Test_PinningDependencies(t *testing.T) {
 				NumberOfDebug: 0,
 			},
 		},
+		{
+			name: "unpinned manifest dependency",
+			dependencies: []checker.Dependency{
+				{
+					Location: &checker.File{},
+					Name:     asPointer("left-pad"),
+					Type:     checker.DependencyUseTypeManifest,
+				},
+			},
+			manifests: []checker.DependencyManifest{
+				{Ecosystem: "npm"},
+			},
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         checker.MaxResultScore,
+				NumberOfWarn:  1,
+				NumberOfInfo:  8,
+				NumberOfDebug: 0,
+			},
+		},
+		{
+			name: "pinned action with a commit from a fork",
+			verified: []checker.PinVerification{
+				{
+					Dependency: checker.Dependency{
+						Location: &checker.File{
+							Snippet: "actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab",
+						},
+						Name: asPointer("actions/checkout"),
+						Type: checker.DependencyUseTypeGHAction,
+					},
+					Issue: checker.PinVerificationIssueUnreachableCommit,
+				},
+			},
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         9,
+				NumberOfWarn:  1,
+				NumberOfInfo:  7,
+				NumberOfDebug: 0,
+			},
+		},
+		{
+			name: "pinned action with a mismatched version comment",
+			verified: []checker.PinVerification{
+				{
+					Dependency: checker.Dependency{
+						Location: &checker.File{
+							Snippet: "actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab",
+						},
+						Name: asPointer("actions/checkout"),
+						Type: checker.DependencyUseTypeGHAction,
+					},
+					Issue:   checker.PinVerificationIssueVersionMismatch,
+					Version: "v3",
+				},
+			},
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         checker.MaxResultScore,
+				NumberOfWarn:  1,
+				NumberOfInfo:  8,
+				NumberOfDebug: 0,
+			},
+		},
+		{
+			name: "manifests pinned by lockfiles",
+			manifests: []checker.DependencyManifest{
+				{Ecosystem: "npm", Lockfile: &checker.File{}},
+			},
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         checker.MaxResultScore,
+				NumberOfWarn:  0,
+				NumberOfInfo:  9,
+				NumberOfDebug: 0,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -278,7 +358,9 @@ func Test_PinningDependencies(t *testing.T) {
 			c := checker.CheckRequest{Dlogger: &dl}
 			actual := PinningDependencies("checkname", &c,
 				&checker.PinningDependenciesData{
-					Dependencies: tt.dependencies,
+					Dependencies:     tt.dependencies,
+					Manifests:        tt.manifests,
+					PinVerifications: tt.verified,
 				})
 
 			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &actual, &dl) {
@@ -0,0 +1,70 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/dependabotAlertsEnabled"
+	"github.com/ossf/scorecard/v4/probes/dependabotSecurityUpdatesEnabled"
+	"github.com/ossf/scorecard/v4/probes/forkingRestricted"
+	"github.com/ossf/scorecard/v4/probes/privateVulnerabilityReportingEnabled"
+	"github.com/ossf/scorecard/v4/probes/secretScanningEnabled"
+	"github.com/ossf/scorecard/v4/probes/secretScanningPushProtectionEnabled"
+)
+
+// RepositorySettings applies the score policy for the Repository-Settings check.
+func RepositorySettings(name string, findings []finding.Finding) checker.CheckResult {
+	// We have 6 unique probes, each should have a finding.
+	expectedProbes := []string{
+		secretScanningEnabled.Probe,
+		secretScanningPushProtectionEnabled.Probe,
+		dependabotAlertsEnabled.Probe,
+		dependabotSecurityUpdatesEnabled.Probe,
+		privateVulnerabilityReportingEnabled.Probe,
+		forkingRestricted.Probe,
+	}
+	if !finding.UniqueProbesEqual(findings, expectedProbes) {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "invalid probe results")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	// The score is the share of the settings that could be read which are enabled.
+	// Forking restrictions only matter for private repositories: they are reported, but not scored.
+	enabled, known := 0, 0
+	for i := range findings {
+		f := &findings[i]
+		if f.Probe == forkingRestricted.Probe {
+			continue
+		}
+		switch f.Outcome {
+		case finding.OutcomePositive:
+			enabled++
+			known++
+		case finding.OutcomeNegative:
+			known++
+		}
+	}
+
+	if known == 0 {
+		return checker.CreateInconclusiveResult(name,
+			"repository settings could not be read, the token may lack admin access to the repository")
+	}
+	return checker.CreateProportionalScoreResult(name,
+		fmt.Sprintf("%d out of %d repository security settings are enabled", enabled, known), enabled, known)
+}
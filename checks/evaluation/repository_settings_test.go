@@ -0,0 +1,108 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+func repositorySettingsFindings(outcomes ...finding.Outcome) []finding.Finding {
+	probes := []string{
+		"secretScanningEnabled",
+		"secretScanningPushProtectionEnabled",
+		"dependabotAlertsEnabled",
+		"dependabotSecurityUpdatesEnabled",
+		"privateVulnerabilityReportingEnabled",
+		"forkingRestricted",
+	}
+	findings := make([]finding.Finding, len(probes))
+	for i, probe := range probes {
+		findings[i] = finding.Finding{
+			Probe:   probe,
+			Outcome: outcomes[i],
+		}
+	}
+	return findings
+}
+
+func TestRepositorySettings(t *testing.T) {
+	t.Parallel()
+	//nolint
+	tests := []struct {
+		name     string
+		findings []finding.Finding
+		want     checker.CheckResult
+	}{
+		{
+			name: "missing findings",
+			findings: []finding.Finding{
+				{
+					Probe:   "secretScanningEnabled",
+					Outcome: finding.OutcomePositive,
+				},
+			},
+			want: checker.CheckResult{
+				Score: -1,
+			},
+		},
+		{
+			name: "all settings enabled",
+			findings: repositorySettingsFindings(finding.OutcomePositive, finding.OutcomePositive,
+				finding.OutcomePositive, finding.OutcomePositive, finding.OutcomePositive, finding.OutcomeNotAvailable),
+			want: checker.CheckResult{
+				Score: 10,
+			},
+		},
+		{
+			name: "forking is not scored",
+			findings: repositorySettingsFindings(finding.OutcomePositive, finding.OutcomeNegative,
+				finding.OutcomePositive, finding.OutcomeNegative, finding.OutcomeNegative, finding.OutcomeNegative),
+			want: checker.CheckResult{
+				Score: 4,
+			},
+		},
+		{
+			name: "settings without admin access",
+			findings: repositorySettingsFindings(finding.OutcomeNotAvailable, finding.OutcomeNotAvailable,
+				finding.OutcomeNotAvailable, finding.OutcomeNotAvailable, finding.OutcomePositive, finding.OutcomeNotAvailable),
+			want: checker.CheckResult{
+				Score: 10,
+			},
+		},
+		{
+			name: "no settings readable",
+			findings: repositorySettingsFindings(finding.OutcomeNotAvailable, finding.OutcomeNotAvailable,
+				finding.OutcomeNotAvailable, finding.OutcomeNotAvailable, finding.OutcomeNotAvailable, finding.OutcomePositive),
+			want: checker.CheckResult{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := RepositorySettings("Repository-Settings", tt.findings)
+			if got.Score != tt.want.Score {
+				t.Errorf("RepositorySettings() = %v, want %v for %v", got.Score, tt.want.Score, tt.name)
+			}
+		})
+	}
+}
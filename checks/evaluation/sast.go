@@ -0,0 +1,98 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/sastToolConfigured"
+	"github.com/ossf/scorecard/v4/probes/sastToolCoversLanguages"
+	"github.com/ossf/scorecard/v4/probes/sastToolRunsOnAllCommits"
+	"github.com/ossf/scorecard/v4/probes/sastToolRunsOnPullRequests"
+	"github.com/ossf/scorecard/v4/probes/sonarConfigured"
+)
+
+// SAST applies the score policy for the SAST check.
+func SAST(name string,
+	findings []finding.Finding,
+) checker.CheckResult {
+	expectedProbes := []string{
+		sastToolConfigured.Probe,
+		sastToolCoversLanguages.Probe,
+		sastToolRunsOnAllCommits.Probe,
+		sastToolRunsOnPullRequests.Probe,
+		sonarConfigured.Probe,
+	}
+
+	if !finding.UniqueProbesEqual(findings, expectedProbes) {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "invalid probe results")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	var sonar, workflow bool
+	var totalMerged, totalTested int
+	for i := range findings {
+		f := &findings[i]
+		switch f.Probe {
+		case sonarConfigured.Probe:
+			sonar = sonar || f.Outcome == finding.OutcomePositive
+		case sastToolConfigured.Probe:
+			workflow = workflow || f.Outcome == finding.OutcomePositive
+		case sastToolRunsOnAllCommits.Probe:
+			switch f.Outcome {
+			case finding.OutcomePositive:
+				totalMerged++
+				totalTested++
+			case finding.OutcomeNegative:
+				totalMerged++
+			default:
+			}
+		}
+	}
+
+	if sonar {
+		return checker.CreateMaxScoreResult(name, "SAST tool detected")
+	}
+
+	// No pull requests merged recently.
+	if totalMerged == 0 {
+		if workflow {
+			return checker.CreateMaxScoreResult(name, "SAST tool detected")
+		}
+		return checker.CreateMinScoreResult(name, "no SAST tool detected")
+	}
+
+	// We encourage developers to have sast check run on every pre-submit rather
+	// than as cron jobs thru the score computation below.
+	// Warning: there is a hidden assumption that *any* sast tool is equally good.
+	sastScore := checker.CreateProportionalScore(totalTested, totalMerged)
+	switch {
+	case sastScore == checker.MaxResultScore:
+		return checker.CreateMaxScoreResult(name, "SAST tool is run on all commits")
+	case !workflow:
+		return checker.CreateResultWithScore(name,
+			checker.NormalizeReason("SAST tool is not run on all commits", sastScore), sastScore)
+	default:
+		// A SAST workflow is enabled and sast has 0+ (but not all) PRs checks.
+		const sastWeight = 3
+		const workflowWeight = 7
+		score := checker.AggregateScoresWithWeight(map[int]int{
+			sastScore:              sastWeight,
+			checker.MaxResultScore: workflowWeight,
+		})
+		return checker.CreateResultWithScore(name, "SAST tool detected but not run on all commits", score)
+	}
+}
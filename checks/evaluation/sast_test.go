@@ -0,0 +1,159 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+func TestSAST(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		findings []finding.Finding
+		want     checker.CheckResult
+	}{
+		{
+			name: "sonar configured",
+			findings: []finding.Finding{
+				{Probe: "sastToolConfigured", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnPullRequests", Outcome: finding.OutcomeNotAvailable},
+				{Probe: "sastToolCoversLanguages", Outcome: finding.OutcomeNotAvailable},
+				{Probe: "sonarConfigured", Outcome: finding.OutcomePositive},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomeNotAvailable},
+			},
+			want: checker.CheckResult{
+				Score:   10,
+				Name:    "SAST",
+				Version: 2,
+				Reason:  "SAST tool detected",
+			},
+		},
+		{
+			name: "all commits checked",
+			findings: []finding.Finding{
+				{Probe: "sastToolConfigured", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnPullRequests", Outcome: finding.OutcomeNotAvailable},
+				{Probe: "sastToolCoversLanguages", Outcome: finding.OutcomeNotAvailable},
+				{Probe: "sonarConfigured", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomePositive},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomePositive},
+			},
+			want: checker.CheckResult{
+				Score:   10,
+				Name:    "SAST",
+				Version: 2,
+				Reason:  "SAST tool is run on all commits",
+			},
+		},
+		{
+			name: "some commits checked without workflow",
+			findings: []finding.Finding{
+				{Probe: "sastToolConfigured", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnPullRequests", Outcome: finding.OutcomeNotAvailable},
+				{Probe: "sastToolCoversLanguages", Outcome: finding.OutcomeNotAvailable},
+				{Probe: "sonarConfigured", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomePositive},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomeNegative},
+			},
+			want: checker.CheckResult{
+				Score:   5,
+				Name:    "SAST",
+				Version: 2,
+				Reason:  "SAST tool is not run on all commits -- score normalized to 5",
+			},
+		},
+		{
+			name: "some commits checked with workflow",
+			findings: []finding.Finding{
+				{Probe: "sastToolConfigured", Outcome: finding.OutcomePositive},
+				{Probe: "sastToolRunsOnPullRequests", Outcome: finding.OutcomePositive},
+				{Probe: "sastToolCoversLanguages", Outcome: finding.OutcomePositive},
+				{Probe: "sonarConfigured", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomePositive},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomeNegative},
+			},
+			want: checker.CheckResult{
+				Score:   7,
+				Name:    "SAST",
+				Version: 2,
+				Reason:  "SAST tool detected but not run on all commits",
+			},
+		},
+		{
+			name: "workflow without pull requests",
+			findings: []finding.Finding{
+				{Probe: "sastToolConfigured", Outcome: finding.OutcomePositive},
+				{Probe: "sastToolRunsOnPullRequests", Outcome: finding.OutcomePositive},
+				{Probe: "sastToolCoversLanguages", Outcome: finding.OutcomePositive},
+				{Probe: "sonarConfigured", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomeNotAvailable},
+			},
+			want: checker.CheckResult{
+				Score:   10,
+				Name:    "SAST",
+				Version: 2,
+				Reason:  "SAST tool detected",
+			},
+		},
+		{
+			name: "no SAST tool",
+			findings: []finding.Finding{
+				{Probe: "sastToolConfigured", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnPullRequests", Outcome: finding.OutcomeNotAvailable},
+				{Probe: "sastToolCoversLanguages", Outcome: finding.OutcomeNotAvailable},
+				{Probe: "sonarConfigured", Outcome: finding.OutcomeNegative},
+				{Probe: "sastToolRunsOnAllCommits", Outcome: finding.OutcomeNotAvailable},
+			},
+			want: checker.CheckResult{
+				Score:   0,
+				Name:    "SAST",
+				Version: 2,
+				Reason:  "no SAST tool detected",
+			},
+		},
+		{
+			name: "missing probe",
+			findings: []finding.Finding{
+				{Probe: "sastToolConfigured", Outcome: finding.OutcomePositive},
+				{Probe: "sonarConfigured", Outcome: finding.OutcomeNegative},
+			},
+			want: checker.CheckResult{
+				Score:   -1,
+				Name:    "SAST",
+				Version: 2,
+				Reason:  "internal error: invalid probe results",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := SAST("SAST", tt.findings)
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(checker.CheckResult{}, "Error")); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
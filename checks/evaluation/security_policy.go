@@ -18,20 +18,38 @@ import (
 	"github.com/ossf/scorecard/v4/checker"
 	sce "github.com/ossf/scorecard/v4/errors"
 	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/securityAdvisoriesHaveCVE"
+	"github.com/ossf/scorecard/v4/probes/securityAdvisoriesPublished"
+	"github.com/ossf/scorecard/v4/probes/securityAdvisoriesPublishedPromptly"
 	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsLinks"
+	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsPrivateReportingChannel"
+	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsResponseTime"
+	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsSupportedVersions"
 	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsText"
 	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsVulnerabilityDisclosure"
 	"github.com/ossf/scorecard/v4/probes/securityPolicyPresent"
+	"github.com/ossf/scorecard/v4/probes/securityPolicyPrivateReportingEnabled"
+	"github.com/ossf/scorecard/v4/probes/securityTxtContainsContact"
+	"github.com/ossf/scorecard/v4/probes/securityTxtNotExpired"
 )
 
 // SecurityPolicy applies the score policy for the Security-Policy check.
 func SecurityPolicy(name string, findings []finding.Finding) checker.CheckResult {
-	// We have 4 unique probes, each should have a finding.
+	// We have 13 unique probes, each should have a finding.
 	expectedProbes := []string{
 		securityPolicyContainsVulnerabilityDisclosure.Probe,
 		securityPolicyContainsLinks.Probe,
 		securityPolicyContainsText.Probe,
 		securityPolicyPresent.Probe,
+		securityPolicyContainsSupportedVersions.Probe,
+		securityPolicyContainsResponseTime.Probe,
+		securityPolicyContainsPrivateReportingChannel.Probe,
+		securityTxtContainsContact.Probe,
+		securityTxtNotExpired.Probe,
+		securityAdvisoriesPublished.Probe,
+		securityAdvisoriesHaveCVE.Probe,
+		securityAdvisoriesPublishedPromptly.Probe,
+		securityPolicyPrivateReportingEnabled.Probe,
 	}
 	if !finding.UniqueProbesEqual(findings, expectedProbes) {
 		e := sce.WithMessage(sce.ErrScorecardInternal, "invalid probe results")
@@ -52,6 +70,17 @@ func SecurityPolicy(name string, findings []finding.Finding) checker.CheckResult
 				score += scoreProbeOnce(f.Probe, m, 3)
 			case securityPolicyPresent.Probe:
 				m[f.Probe] = true
+			case securityPolicyContainsSupportedVersions.Probe,
+				securityPolicyContainsResponseTime.Probe,
+				securityPolicyContainsPrivateReportingChannel.Probe,
+				securityTxtContainsContact.Probe,
+				securityTxtNotExpired.Probe:
+				// The structure of the policy is reported, but not scored.
+			case securityAdvisoriesPublished.Probe,
+				securityAdvisoriesHaveCVE.Probe,
+				securityAdvisoriesPublishedPromptly.Probe,
+				securityPolicyPrivateReportingEnabled.Probe:
+				// The handling of vulnerabilities is reported, but not scored.
 			default:
 				e := sce.WithMessage(sce.ErrScorecardInternal, "unknown probe results")
 				return checker.CreateRuntimeErrorResult(name, e)
@@ -21,6 +21,31 @@ import (
 	"github.com/ossf/scorecard/v4/finding"
 )
 
+// securityPolicyUnscoredFindings returns the findings of the probes
+// analyzing the structure of the policy and the handling of
+// vulnerabilities, which are not scored.
+func securityPolicyUnscoredFindings(outcome finding.Outcome) []finding.Finding {
+	probes := []string{
+		"securityPolicyContainsSupportedVersions",
+		"securityPolicyContainsResponseTime",
+		"securityPolicyContainsPrivateReportingChannel",
+		"securityTxtContainsContact",
+		"securityTxtNotExpired",
+		"securityAdvisoriesPublished",
+		"securityAdvisoriesHaveCVE",
+		"securityAdvisoriesPublishedPromptly",
+		"securityPolicyPrivateReportingEnabled",
+	}
+	findings := make([]finding.Finding, len(probes))
+	for i, probe := range probes {
+		findings[i] = finding.Finding{
+			Probe:   probe,
+			Outcome: outcome,
+		}
+	}
+	return findings
+}
+
 func TestSecurityPolicy(t *testing.T) {
 	t.Parallel()
 	//nolint
@@ -80,7 +105,7 @@ func TestSecurityPolicy(t *testing.T) {
 		},
 		{
 			name: "file found only",
-			findings: []finding.Finding{
+			findings: append([]finding.Finding{
 				{
 					Probe:   "securityPolicyContainsVulnerabilityDisclosure",
 					Outcome: finding.OutcomeNegative,
@@ -97,14 +122,14 @@ func TestSecurityPolicy(t *testing.T) {
 					Probe:   "securityPolicyPresent",
 					Outcome: finding.OutcomePositive,
 				},
-			},
+			}, securityPolicyUnscoredFindings(finding.OutcomeNegative)...),
 			want: checker.CheckResult{
 				Score: 0,
 			},
 		},
 		{
 			name: "file not found with positive probes",
-			findings: []finding.Finding{
+			findings: append([]finding.Finding{
 				{
 					Probe:   "securityPolicyContainsVulnerabilityDisclosure",
 					Outcome: finding.OutcomePositive,
@@ -121,14 +146,14 @@ func TestSecurityPolicy(t *testing.T) {
 					Probe:   "securityPolicyPresent",
 					Outcome: finding.OutcomeNegative,
 				},
-			},
+			}, securityPolicyUnscoredFindings(finding.OutcomeNotAvailable)...),
 			want: checker.CheckResult{
 				Score: -1,
 			},
 		},
 		{
 			name: "file found with no disclosure and text",
-			findings: []finding.Finding{
+			findings: append([]finding.Finding{
 				{
 					Probe:   "securityPolicyContainsVulnerabilityDisclosure",
 					Outcome: finding.OutcomeNegative,
@@ -145,14 +170,14 @@ func TestSecurityPolicy(t *testing.T) {
 					Probe:   "securityPolicyPresent",
 					Outcome: finding.OutcomePositive,
 				},
-			},
+			}, securityPolicyUnscoredFindings(finding.OutcomePositive)...),
 			want: checker.CheckResult{
 				Score: 6,
 			},
 		},
 		{
 			name: "file found all positive",
-			findings: []finding.Finding{
+			findings: append([]finding.Finding{
 				{
 					Probe:   "securityPolicyContainsVulnerabilityDisclosure",
 					Outcome: finding.OutcomePositive,
@@ -169,7 +194,31 @@ func TestSecurityPolicy(t *testing.T) {
 					Probe:   "securityPolicyPresent",
 					Outcome: finding.OutcomePositive,
 				},
+			}, securityPolicyUnscoredFindings(finding.OutcomePositive)...),
+			want: checker.CheckResult{
+				Score: 10,
 			},
+		},
+		{
+			name: "file found with expired security.txt",
+			findings: append([]finding.Finding{
+				{
+					Probe:   "securityPolicyContainsVulnerabilityDisclosure",
+					Outcome: finding.OutcomePositive,
+				},
+				{
+					Probe:   "securityPolicyContainsLinks",
+					Outcome: finding.OutcomePositive,
+				},
+				{
+					Probe:   "securityPolicyContainsText",
+					Outcome: finding.OutcomePositive,
+				},
+				{
+					Probe:   "securityPolicyPresent",
+					Outcome: finding.OutcomePositive,
+				},
+			}, securityPolicyUnscoredFindings(finding.OutcomeNegative)...),
 			want: checker.CheckResult{
 				Score: 10,
 			},
@@ -16,8 +16,10 @@ package evaluation
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
 	sce "github.com/ossf/scorecard/v4/errors"
 	"github.com/ossf/scorecard/v4/finding"
 )
@@ -35,27 +37,80 @@ func Webhooks(name string, dl checker.DetailLogger,
 		return checker.CreateMaxScoreResult(name, "no webhooks defined")
 	}
 
-	hasNoSecretCount := 0
+	insecureCount := 0
+	unknownSecrets := 0
 	for _, hook := range r.Webhooks {
-		if !hook.UsesAuthSecret {
+		insecure := false
+		switch {
+		case hook.AuthSecretUnknown:
+			unknownSecrets++
+			dl.Debug(&checker.LogMessage{
+				Path: hook.Path,
+				Type: finding.FileTypeURL,
+				Text: "cannot determine whether the webhook has a secret configured",
+			})
+		case !hook.UsesAuthSecret:
 			dl.Warn(&checker.LogMessage{
 				Path: hook.Path,
 				Type: finding.FileTypeURL,
 				Text: "Webhook with no secret configured",
 			})
-			hasNoSecretCount++
+			insecure = true
+		}
+		if strings.EqualFold(hook.Scheme, "http") {
+			dl.Warn(&checker.LogMessage{
+				Path: hook.Path,
+				Type: finding.FileTypeURL,
+				Text: "Webhook delivers payloads over plaintext HTTP",
+			})
+			insecure = true
+		}
+		if hook.InsecureSSL {
+			dl.Warn(&checker.LogMessage{
+				Path: hook.Path,
+				Type: finding.FileTypeURL,
+				Text: "Webhook does not verify the TLS certificate of its URL",
+			})
+			insecure = true
+		}
+		// Broad subscriptions send more data than the service may need, but are not scored.
+		if subscribesToAllEvents(hook.Events) {
+			dl.Info(&checker.LogMessage{
+				Path: hook.Path,
+				Type: finding.FileTypeURL,
+				Text: "Webhook subscribed to all events",
+			})
+		}
+		if insecure {
+			insecureCount++
 		}
 	}
 
-	if hasNoSecretCount == 0 {
-		return checker.CreateMaxScoreResult(name, fmt.Sprintf("all %d hook(s) have a secret configured", len(r.Webhooks)))
+	if insecureCount == 0 && unknownSecrets > 0 {
+		return checker.CreateMaxScoreResult(name,
+			fmt.Sprintf("all %d hook(s) deliver payloads over verified TLS, %d with an unknown secret",
+				len(r.Webhooks), unknownSecrets))
+	}
+	if insecureCount == 0 {
+		return checker.CreateMaxScoreResult(name,
+			fmt.Sprintf("all %d hook(s) have a secret configured and deliver payloads over verified TLS", len(r.Webhooks)))
 	}
 
-	if len(r.Webhooks) == hasNoSecretCount {
-		return checker.CreateMinScoreResult(name, fmt.Sprintf("%d hook(s) do not have a secret configured", len(r.Webhooks)))
+	if len(r.Webhooks) == insecureCount {
+		return checker.CreateMinScoreResult(name,
+			fmt.Sprintf("%d hook(s) lack a secret or deliver payloads insecurely", len(r.Webhooks)))
 	}
 
 	return checker.CreateProportionalScoreResult(name,
-		fmt.Sprintf("%d/%d hook(s) with no secrets configured detected",
-			hasNoSecretCount, len(r.Webhooks)), hasNoSecretCount, len(r.Webhooks))
+		fmt.Sprintf("%d/%d hook(s) with no secrets configured or insecure delivery detected",
+			insecureCount, len(r.Webhooks)), insecureCount, len(r.Webhooks))
+}
+
+func subscribesToAllEvents(events []string) bool {
+	for _, e := range events {
+		if e == clients.AllWebhookEvents {
+			return true
+		}
+	}
+	return false
 }
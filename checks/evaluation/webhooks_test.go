@@ -133,6 +133,81 @@ func TestWebhooks(t *testing.T) {
 				Score: 6,
 			},
 		},
+		{
+			name: "webhook with secret over plaintext HTTP",
+			args: args{
+				name: "webhook with secret over plaintext HTTP",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.WebhooksData{
+					Webhooks: []clients.Webhook{
+						{
+							Path:           "https://github.com/owner/repo/settings/hooks/1234",
+							ID:             1234,
+							UsesAuthSecret: true,
+							Scheme:         "http",
+						},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score: 0,
+			},
+		},
+		{
+			name: "webhooks skipping TLS verification and subscribed to all events",
+			args: args{
+				name: "webhooks skipping TLS verification and subscribed to all events",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.WebhooksData{
+					Webhooks: []clients.Webhook{
+						{
+							Path:           "https://github.com/owner/repo/settings/hooks/1234",
+							ID:             1234,
+							UsesAuthSecret: true,
+							Scheme:         "https",
+							InsecureSSL:    true,
+						},
+						{
+							Path:           "https://github.com/owner/repo/settings/hooks/1111",
+							ID:             1111,
+							UsesAuthSecret: true,
+							Scheme:         "https",
+							Events:         []string{clients.AllWebhookEvents},
+						},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score: 5,
+			},
+		},
+		{
+			name: "webhooks with an unknown secret",
+			args: args{
+				name: "webhooks with an unknown secret",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.WebhooksData{
+					Webhooks: []clients.Webhook{
+						{
+							Path:              "https://gitlab.com/owner/repo/-/hooks/1234",
+							ID:                1234,
+							AuthSecretUnknown: true,
+							Scheme:            "https",
+						},
+						{
+							Path:              "https://gitlab.com/owner/repo/-/hooks/1111",
+							ID:                1111,
+							AuthSecretUnknown: true,
+							Scheme:            "https",
+							InsecureSSL:       true,
+						},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score: 5,
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -209,6 +209,66 @@ func GetOSesForJob(job *actionlint.Job) ([]string, error) {
 	return jobOSes, nil
 }
 
+var matrixExpression = regexp.MustCompile(`^\$\{\{\s*matrix\.([\w-]+)\s*\}\}$`)
+
+// GetRunsOnLabelsForJob returns the runner labels of a job. Labels of the form
+// '${{ matrix.<key> }}' are expanded to all the values the matrix assigns to the key.
+func GetRunsOnLabelsForJob(job *actionlint.Job) []string {
+	var labels []string
+	for _, label := range getJobRunsOnLabels(job) {
+		if label == nil {
+			continue
+		}
+		labels = append(labels, ExpandMatrixExpression(job, label.Value)...)
+	}
+	return labels
+}
+
+// ExpandMatrixExpression returns all the values the matrix of a job assigns to the key
+// of a '${{ matrix.<key> }}' expression, or the value itself if it is not such an expression.
+func ExpandMatrixExpression(job *actionlint.Job, value string) []string {
+	m := matrixExpression.FindStringSubmatch(value)
+	if m == nil {
+		return []string{value}
+	}
+	var values []string
+	for rowKey, rowValue := range getJobStrategyMatrixRows(job) {
+		if rowKey != m[1] || rowValue == nil {
+			continue
+		}
+		for _, v := range rowValue.Values {
+			values = append(values, rawYAMLStrings(v)...)
+		}
+	}
+	for _, combination := range getJobStrategyMatrixIncludeCombinations(job) {
+		if combination == nil {
+			continue
+		}
+		for _, assign := range combination.Assigns {
+			if assign.Key == nil || assign.Key.Value != m[1] || assign.Value == nil {
+				continue
+			}
+			values = append(values, rawYAMLStrings(assign.Value)...)
+		}
+	}
+	return values
+}
+
+// rawYAMLStrings returns the string values of a scalar or of an array of scalars.
+func rawYAMLStrings(v actionlint.RawYAMLValue) []string {
+	switch v := v.(type) {
+	case *actionlint.RawYAMLString:
+		return []string{v.Value}
+	case *actionlint.RawYAMLArray:
+		var ret []string
+		for _, e := range v.Elems {
+			ret = append(ret, rawYAMLStrings(e)...)
+		}
+		return ret
+	}
+	return nil
+}
+
 // JobAlwaysRunsOnWindows returns true if the only OS that this job runs on is Windows.
 func JobAlwaysRunsOnWindows(job *actionlint.Job) (bool, error) {
 	jobOSes, err := GetOSesForJob(job)
@@ -317,6 +377,8 @@ func IsGitHubOwnedAction(actionName string) bool {
 type JobMatcher struct {
 	// The text to be logged when a job match is found.
 	LogText string
+	// If set, the registry the matched job publishes to.
+	Registry string
 	// Each step in this field has a matching step in the job.
 	Steps []*JobMatcherStep
 }
@@ -329,12 +391,23 @@ type JobMatcherStep struct {
 	With map[string]string
 	// If set, the step's 'Run' must match this field. Does a regex match using this field.
 	Run string
+	// If set, a regex whose first group extracts the name of the published package
+	// from the step's 'Run', or from the input named by NameInput.
+	Name string
+	// If set, the input of the step that Name applies to.
+	NameInput string
 }
 
 // JobMatchResult represents the result of a matche.
 type JobMatchResult struct {
 	Msg  string
 	File checker.File
+	// Registry and Name are set from the matcher, when known.
+	Registry string
+	Name     string
+	// Auth is set by IsPackagingWorkflow.
+	Auth checker.PackageAuth
+	job  *actionlint.Job
 }
 
 // AnyJobsMatch returns true if any of the jobs have a match in the given workflow.
@@ -343,7 +416,8 @@ func AnyJobsMatch(workflow *actionlint.Workflow, jobMatchers []JobMatcher, fp st
 ) (JobMatchResult, bool) {
 	for _, job := range workflow.Jobs {
 		for _, matcher := range jobMatchers {
-			if !matcher.matches(job) {
+			steps, ok := matcher.matches(job)
+			if !ok {
 				continue
 			}
 
@@ -353,7 +427,10 @@ func AnyJobsMatch(workflow *actionlint.Workflow, jobMatchers []JobMatcher, fp st
 					Type:   finding.FileTypeSource,
 					Offset: GetLineNumber(job.Pos),
 				},
-				Msg: fmt.Sprintf("%v: %v", matcher.LogText, fp),
+				Msg:      fmt.Sprintf("%v: %v", matcher.LogText, fp),
+				Registry: matcher.Registry,
+				Name:     matcher.packageName(steps),
+				job:      job,
 			}, true
 		}
 	}
@@ -368,30 +445,57 @@ func AnyJobsMatch(workflow *actionlint.Workflow, jobMatchers []JobMatcher, fp st
 	}, false
 }
 
-// matches returns true if the job matches the job matcher.
-func (m *JobMatcher) matches(job *actionlint.Job) bool {
-	for _, stepToMatch := range m.Steps {
-		hasMatch := false
-
-		// First look for re-usable workflow calls.
-		if job.WorkflowCall != nil &&
-			job.WorkflowCall.Uses != nil &&
-			strings.HasPrefix(job.WorkflowCall.Uses.Value, stepToMatch.Uses+"@") {
-			return true
-		}
+// matches returns true if the job matches the job matcher,
+// along with the step matching each of the matcher's steps.
+func (m *JobMatcher) matches(job *actionlint.Job) ([]*actionlint.Step, bool) {
+	// First look for re-usable workflow calls.
+	if len(m.Steps) > 0 &&
+		job.WorkflowCall != nil &&
+		job.WorkflowCall.Uses != nil &&
+		strings.HasPrefix(job.WorkflowCall.Uses.Value, m.Steps[0].Uses+"@") {
+		return nil, true
+	}
 
-		// Second looks for steps in the job.
+	// Second looks for steps in the job.
+	matched := make([]*actionlint.Step, len(m.Steps))
+	for i, stepToMatch := range m.Steps {
 		for _, step := range job.Steps {
 			if stepsMatch(stepToMatch, step) {
-				hasMatch = true
+				matched[i] = step
 				break
 			}
 		}
-		if !hasMatch {
-			return false
+		if matched[i] == nil {
+			return nil, false
 		}
 	}
-	return true
+	return matched, true
+}
+
+// packageName returns the name of the package published by the matched steps, if known.
+func (m *JobMatcher) packageName(steps []*actionlint.Step) string {
+	for i, step := range steps {
+		stepToMatch := m.Steps[i]
+		if step == nil || stepToMatch.Name == "" {
+			continue
+		}
+		var value *actionlint.String
+		if stepToMatch.NameInput != "" {
+			if input, ok := getWith(step)[stepToMatch.NameInput]; ok && input != nil {
+				value = input.Value
+			}
+		} else {
+			value = getRun(step)
+		}
+		if value == nil {
+			continue
+		}
+		match := regexp.MustCompile(stepToMatch.Name).FindStringSubmatch(value.Value)
+		if len(match) > 1 && match[1] != "" && !strings.Contains(match[1], "${{") {
+			return match[1]
+		}
+	}
+	return ""
 }
 
 // stepsMatch returns true if the fields on 'stepToMatch' match what's in 'step'.
@@ -437,6 +541,23 @@ func stepsMatch(stepToMatch *JobMatcherStep, step *actionlint.Step) bool {
 	return true
 }
 
+// Registries that packaging workflows publish to.
+const (
+	registryNpm            = "npm"
+	registryMavenCentral   = "Maven Central"
+	registryGradlePlugins  = "Gradle Plugin Portal"
+	registryRubyGems       = "RubyGems"
+	registryNuGet          = "NuGet"
+	registryContainer      = "container registry"
+	registryPyPI           = "PyPI"
+	registryGitHubReleases = "GitHub Releases"
+	registryGoModuleProxy  = "Go module proxy"
+	registryCratesIO       = "crates.io"
+	registryHex            = "Hex"
+	registryHelmRepository = "Helm chart repository"
+	registryHelmOCI        = "OCI registry"
+)
+
 // IsPackagingWorkflow checks for a packaging workflow.
 func IsPackagingWorkflow(workflow *actionlint.Workflow, fp string) (JobMatchResult, bool) {
 	jobMatchers := []JobMatcher{
@@ -450,7 +571,8 @@ func IsPackagingWorkflow(workflow *actionlint.Workflow, fp string) (JobMatchResu
 					Run: "npm.*publish",
 				},
 			},
-			LogText: "candidate node publishing workflow using npm",
+			LogText:  "candidate node publishing workflow using npm",
+			Registry: registryNpm,
 		},
 		{
 			// Java packages with maven.
@@ -462,7 +584,21 @@ func IsPackagingWorkflow(workflow *actionlint.Workflow, fp string) (JobMatchResu
 					Run: "mvn.*deploy",
 				},
 			},
-			LogText: "candidate java publishing workflow using maven",
+			LogText:  "candidate java publishing workflow using maven",
+			Registry: registryMavenCentral,
+		},
+		{
+			// Gradle plugins. https://plugins.gradle.org/docs/publish-plugin
+			Steps: []*JobMatcherStep{
+				{
+					Uses: "actions/setup-java",
+				},
+				{
+					Run: "gradle.*publishPlugins",
+				},
+			},
+			LogText:  "candidate gradle plugin publishing workflow",
+			Registry: registryGradlePlugins,
 		},
 		{
 			// Java packages with gradle.
@@ -474,43 +610,63 @@ func IsPackagingWorkflow(workflow *actionlint.Workflow, fp string) (JobMatchResu
 					Run: "gradle.*publish",
 				},
 			},
-			LogText: "candidate java publishing workflow using gradle",
+			LogText:  "candidate java publishing workflow using gradle",
+			Registry: registryMavenCentral,
+		},
+		{
+			// Ruby packages with trusted publishing. https://github.com/rubygems/release-gem
+			Steps: []*JobMatcherStep{
+				{
+					Uses: "rubygems/release-gem",
+				},
+			},
+			LogText:  "candidate ruby publishing workflow using rubygems/release-gem",
+			Registry: registryRubyGems,
 		},
 		{
 			// Ruby packages.
 			Steps: []*JobMatcherStep{
 				{
-					Run: "gem.*push",
+					Run:  "gem.*push",
+					Name: `gem\s+push\s+(?:\S*/)?([\w.-]+?)-v?\d[\w.-]*\.gem`,
 				},
 			},
-			LogText: "candidate ruby publishing workflow using gem",
+			LogText:  "candidate ruby publishing workflow using gem",
+			Registry: registryRubyGems,
 		},
 		{
 			// NuGet packages.
 			Steps: []*JobMatcherStep{
 				{
-					Run: "nuget.*push",
+					Run:  "nuget.*push",
+					Name: `nuget\s+push\s+(?:\S*/)?([\w.-]+?)\.\d[\w.-]*\.s?nupkg`,
 				},
 			},
-			LogText: "candidate nuget publishing workflow",
+			LogText:  "candidate nuget publishing workflow",
+			Registry: registryNuGet,
 		},
 		{
 			// Docker packages.
 			Steps: []*JobMatcherStep{
 				{
-					Run: "docker.*push",
+					Run:  "docker.*push",
+					Name: `docker\s+(?:image\s+)?push\s+(?:--?\S+\s+)*([^\s:@$]+)`,
 				},
 			},
-			LogText: "candidate docker publishing workflow",
+			LogText:  "candidate docker publishing workflow",
+			Registry: registryContainer,
 		},
 		{
 			// Docker packages.
 			Steps: []*JobMatcherStep{
 				{
-					Uses: "docker/build-push-action",
+					Uses:      "docker/build-push-action",
+					Name:      `^\s*([^\s,:@$]+)`,
+					NameInput: "tags",
 				},
 			},
-			LogText: "candidate docker publishing workflow",
+			LogText:  "candidate docker publishing workflow",
+			Registry: registryContainer,
 		},
 		{
 			// Python packages.
@@ -519,7 +675,8 @@ func IsPackagingWorkflow(workflow *actionlint.Workflow, fp string) (JobMatchResu
 					Uses: "pypa/gh-action-pypi-publish",
 				},
 			},
-			LogText: "candidate python publishing workflow using pypi",
+			LogText:  "candidate python publishing workflow using pypi",
+			Registry: registryPyPI,
 		},
 		{
 			// Python packages.
@@ -530,7 +687,8 @@ func IsPackagingWorkflow(workflow *actionlint.Workflow, fp string) (JobMatchResu
 					Uses: "relekang/python-semantic-release",
 				},
 			},
-			LogText: "candidate python publishing workflow using python-semantic-release",
+			LogText:  "candidate python publishing workflow using python-semantic-release",
+			Registry: registryPyPI,
 		},
 		{
 			// Go packages.
@@ -542,16 +700,82 @@ func IsPackagingWorkflow(workflow *actionlint.Workflow, fp string) (JobMatchResu
 					Uses: "goreleaser/goreleaser-action",
 				},
 			},
-			LogText: "candidate golang publishing workflow",
+			LogText:  "candidate golang publishing workflow",
+			Registry: registryGitHubReleases,
+		},
+		{
+			// Releases with goreleaser, for any language. https://goreleaser.com
+			Steps: []*JobMatcherStep{
+				{
+					Uses: "goreleaser/goreleaser-action",
+				},
+			},
+			LogText:  "candidate publishing workflow using goreleaser",
+			Registry: registryGitHubReleases,
+		},
+		{
+			// Releases with goreleaser, for any language. https://goreleaser.com
+			Steps: []*JobMatcherStep{
+				{
+					Run: `goreleaser\s+release`,
+				},
+			},
+			LogText:  "candidate publishing workflow using goreleaser",
+			Registry: registryGitHubReleases,
+		},
+		{
+			// Go modules are published by tagging them and requesting
+			// the tag from the module proxy. https://go.dev/doc/modules/publishing
+			Steps: []*JobMatcherStep{
+				{
+					Run:  `proxy\.golang\.org|go\s+list\s+-m\s+\S+@`,
+					Name: `go\s+list\s+-m\s+([^\s@$]+)@`,
+				},
+			},
+			LogText:  "candidate golang module publishing workflow",
+			Registry: registryGoModuleProxy,
 		},
 		{
 			// Rust packages. https://doc.rust-lang.org/cargo/reference/publishing.html
 			Steps: []*JobMatcherStep{
 				{
-					Run: "cargo.*publish",
+					Run:  "cargo.*publish",
+					Name: `cargo\s+publish\b.*?(?:-p|--package)[\s=]+([\w-]+)`,
 				},
 			},
-			LogText: "candidate rust publishing workflow using cargo",
+			LogText:  "candidate rust publishing workflow using cargo",
+			Registry: registryCratesIO,
+		},
+		{
+			// Elixir and Erlang packages. https://hex.pm/docs/publish
+			Steps: []*JobMatcherStep{
+				{
+					Run: `mix\s+hex\.publish|rebar3\s+hex\s+publish`,
+				},
+			},
+			LogText:  "candidate elixir publishing workflow using hex",
+			Registry: registryHex,
+		},
+		{
+			// Helm charts pushed to an OCI registry. https://helm.sh/docs/topics/registries/
+			Steps: []*JobMatcherStep{
+				{
+					Run:  `helm\s+push\s`,
+					Name: `helm\s+push\s+(?:\S*/)?([\w.-]+?)-v?\d[\w.-]*\.tgz`,
+				},
+			},
+			LogText:  "candidate helm chart publishing workflow",
+			Registry: registryHelmOCI,
+		},
+		{
+			// Helm charts released to a chart repository on GitHub Pages.
+			Steps: []*JobMatcherStep{
+				{
+					Uses: "helm/chart-releaser-action",
+				},
+			},
+			LogText:  "candidate helm chart publishing workflow using chart-releaser",
+			Registry: registryHelmRepository,
 		},
 		{
 			// Ko container action. https://github.com/google/ko
@@ -563,7 +787,8 @@ func IsPackagingWorkflow(workflow *actionlint.Workflow, fp string) (JobMatchResu
 					Uses: "ko-build/setup-ko",
 				},
 			},
-			LogText: "candidate container publishing workflow using ko",
+			LogText:  "candidate container publishing workflow using ko",
+			Registry: registryContainer,
 		},
 		{
 			// Commonly JavaScript packages, but supports multiple ecosystems
@@ -576,5 +801,77 @@ func IsPackagingWorkflow(workflow *actionlint.Workflow, fp string) (JobMatchResu
 		},
 	}
 
-	return AnyJobsMatch(workflow, jobMatchers, fp, "not a publishing workflow")
+	match, ok := AnyJobsMatch(workflow, jobMatchers, fp, "not a publishing workflow")
+	if ok && match.job != nil {
+		match.Auth = publishingAuth(workflow, match.job)
+	}
+	return match, ok
+}
+
+var secretReference = regexp.MustCompile(`\$\{\{[^}]*\bsecrets\.([\w-]+)`)
+
+// publishingAuth returns how a publishing job authenticates to the registry.
+// Jobs that use secrets other than GITHUB_TOKEN rely on long-lived credentials.
+// Jobs that can request an OIDC token and use no such secret rely on trusted publishing.
+func publishingAuth(workflow *actionlint.Workflow, job *actionlint.Job) checker.PackageAuth {
+	var values []string
+	values = append(values, envValues(workflow.Env)...)
+	values = append(values, envValues(job.Env)...)
+	for _, step := range job.Steps {
+		if step == nil {
+			continue
+		}
+		values = append(values, envValues(step.Env)...)
+		for _, input := range getWith(step) {
+			if input != nil && input.Value != nil {
+				values = append(values, input.Value.Value)
+			}
+		}
+		if run := getRun(step); run != nil {
+			values = append(values, run.Value)
+		}
+	}
+	for _, v := range values {
+		for _, m := range secretReference.FindAllStringSubmatch(v, -1) {
+			if !strings.EqualFold(m[1], "GITHUB_TOKEN") {
+				return checker.PackageAuthSecret
+			}
+		}
+	}
+
+	permissions := job.Permissions
+	if permissions == nil {
+		permissions = workflow.Permissions
+	}
+	if canRequestIDToken(permissions) {
+		return checker.PackageAuthTrustedPublishing
+	}
+	return checker.PackageAuthUnknown
+}
+
+func envValues(env *actionlint.Env) []string {
+	if env == nil {
+		return nil
+	}
+	var values []string
+	if env.Expression != nil {
+		values = append(values, env.Expression.Value)
+	}
+	for _, v := range env.Vars {
+		if v != nil && v.Value != nil {
+			values = append(values, v.Value.Value)
+		}
+	}
+	return values
+}
+
+func canRequestIDToken(permissions *actionlint.Permissions) bool {
+	if permissions == nil {
+		return false
+	}
+	if permissions.All != nil {
+		return permissions.All.Value == "write-all"
+	}
+	scope, ok := permissions.Scopes["id-token"]
+	return ok && scope != nil && scope.Value != nil && scope.Value.Value == "write"
 }
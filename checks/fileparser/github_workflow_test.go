@@ -22,6 +22,8 @@ import (
 
 	"github.com/rhysd/actionlint"
 	"gotest.tools/assert/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
 )
 
 func TestGitHubWorkflowShell(t *testing.T) {
@@ -149,6 +151,71 @@ func TestGitHubWorkflowShell(t *testing.T) {
 	}
 }
 
+func TestGetRunsOnLabelsForJob(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		workflow string
+		want     []string
+	}{
+		{
+			name: "labels",
+			workflow: `
+on: push
+jobs:
+  build:
+    runs-on: [self-hosted, linux]
+    steps:
+    - run: make
+`,
+			want: []string{"self-hosted", "linux"},
+		},
+		{
+			name: "matrix rows and include",
+			workflow: `
+on: push
+jobs:
+  build:
+    strategy:
+      matrix:
+        runner: [ubuntu-latest, [self-hosted, gpu]]
+        include:
+        - runner: macos-12
+    runs-on: ${{ matrix.runner }}
+    steps:
+    - run: make
+`,
+			want: []string{"ubuntu-latest", "self-hosted", "gpu", "macos-12"},
+		},
+		{
+			name: "unresolved expression",
+			workflow: `
+on: push
+jobs:
+  build:
+    runs-on: ${{ inputs.runner }}
+    steps:
+    - run: make
+`,
+			want: []string{"${{ inputs.runner }}"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			workflow, errs := actionlint.Parse([]byte(tt.workflow))
+			if len(errs) > 0 {
+				t.Fatalf("cannot parse workflow: %v", errs)
+			}
+			got := GetRunsOnLabelsForJob(workflow.Jobs["build"])
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetRunsOnLabelsForJob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsWorkflowFile(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -921,12 +988,17 @@ func TestIsPackagingWorkflow(t *testing.T) {
 	tests := []struct {
 		name     string
 		filename string
+		registry string
+		pkgName  string
+		auth     checker.PackageAuth
 		expected bool
 	}{
 		{
 			name:     "npmjs.org publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-npm.yaml",
 			expected: true,
+			registry: "npm",
+			auth:     checker.PackageAuthSecret,
 		},
 		{
 			name:     "npm github publish",
@@ -937,46 +1009,60 @@ func TestIsPackagingWorkflow(t *testing.T) {
 			name:     "maven publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-maven.yaml",
 			expected: true,
+			registry: "Maven Central",
 		},
 		{
 			name:     "maven publish multi-line",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-maven-multi-line.yaml",
 			expected: true,
+			registry: "Maven Central",
 		},
 		{
 			name:     "gradle publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-gradle.yaml",
 			expected: true,
+			registry: "Maven Central",
 		},
 		{
 			name:     "gem publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-gem.yaml",
 			expected: true,
+			registry: "RubyGems",
 		},
 		{
 			name:     "nuget publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-nuget.yaml",
 			expected: true,
+			registry: "NuGet",
+			auth:     checker.PackageAuthSecret,
 		},
 		{
 			name:     "docker action publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-docker-action.yaml",
 			expected: true,
+			registry: "container registry",
+			pkgName:  "user/app",
 		},
 		{
 			name:     "docker push publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-docker-push.yaml",
 			expected: true,
+			registry: "container registry",
+			pkgName:  "myapp/myimage",
 		},
 		{
 			name:     "pypi publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-pypi.yaml",
 			expected: true,
+			registry: "PyPI",
+			auth:     checker.PackageAuthSecret,
 		},
 		{
 			name:     "pypi publish minimal",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-pypi-minimal.yaml",
 			expected: true,
+			registry: "PyPI",
+			auth:     checker.PackageAuthSecret,
 		},
 		{
 			name:     "pypi publish failing",
@@ -987,22 +1073,76 @@ func TestIsPackagingWorkflow(t *testing.T) {
 			name:     "python semantic release publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-python-semantic-release.yaml",
 			expected: true,
+			registry: "PyPI",
+			auth:     checker.PackageAuthSecret,
 		},
 		{
 			name:     "go publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-go.yaml",
 			expected: true,
+			registry: "GitHub Releases",
 		},
 		{
 			name:     "cargo publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-cargo.yaml",
 			expected: true,
+			registry: "crates.io",
 		},
 		{
 			name:     "semantic-release publish",
 			filename: "../testdata/.github/workflows/github-workflow-packaging-semantic-release.yaml",
 			expected: true,
 		},
+		{
+			name:     "pypi trusted publishing",
+			filename: "../testdata/.github/workflows/github-workflow-packaging-pypi-trusted.yaml",
+			expected: true,
+			registry: "PyPI",
+			auth:     checker.PackageAuthTrustedPublishing,
+		},
+		{
+			name:     "cargo publish package",
+			filename: "../testdata/.github/workflows/github-workflow-packaging-cargo-package.yaml",
+			expected: true,
+			registry: "crates.io",
+			pkgName:  "my-crate",
+			auth:     checker.PackageAuthSecret,
+		},
+		{
+			name:     "gem trusted publishing",
+			filename: "../testdata/.github/workflows/github-workflow-packaging-gem-trusted.yaml",
+			expected: true,
+			registry: "RubyGems",
+			auth:     checker.PackageAuthTrustedPublishing,
+		},
+		{
+			name:     "hex publish",
+			filename: "../testdata/.github/workflows/github-workflow-packaging-hex.yaml",
+			expected: true,
+			registry: "Hex",
+			auth:     checker.PackageAuthSecret,
+		},
+		{
+			name:     "helm oci push",
+			filename: "../testdata/.github/workflows/github-workflow-packaging-helm.yaml",
+			expected: true,
+			registry: "OCI registry",
+			pkgName:  "my-chart",
+		},
+		{
+			name:     "go module proxy",
+			filename: "../testdata/.github/workflows/github-workflow-packaging-go-proxy.yaml",
+			expected: true,
+			registry: "Go module proxy",
+			pkgName:  "example.com/my/module",
+		},
+		{
+			name:     "goreleaser",
+			filename: "../testdata/.github/workflows/github-workflow-packaging-goreleaser.yaml",
+			expected: true,
+			registry: "GitHub Releases",
+			auth:     checker.PackageAuthTrustedPublishing,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -1018,10 +1158,19 @@ func TestIsPackagingWorkflow(t *testing.T) {
 			}
 			p := strings.Replace(tt.filename, "../testdata/", "", 1)
 
-			_, ok := IsPackagingWorkflow(workflow, p)
+			match, ok := IsPackagingWorkflow(workflow, p)
 			if ok != tt.expected {
 				t.Errorf("isPackagingWorkflow() = %v, expected %v", ok, tt.expected)
 			}
+			if match.Registry != tt.registry {
+				t.Errorf("registry = %q, expected %q", match.Registry, tt.registry)
+			}
+			if match.Name != tt.pkgName {
+				t.Errorf("name = %q, expected %q", match.Name, tt.pkgName)
+			}
+			if match.Auth != tt.auth {
+				t.Errorf("auth = %q, expected %q", match.Auth, tt.auth)
+			}
 		})
 	}
 }
@@ -18,6 +18,8 @@ import (
 	"github.com/ossf/scorecard/v4/checker"
 	evaluation "github.com/ossf/scorecard/v4/checks/evaluation/permissions"
 	"github.com/ossf/scorecard/v4/checks/raw"
+	"github.com/ossf/scorecard/v4/checks/raw/gitlab"
+	"github.com/ossf/scorecard/v4/clients/gitlabrepo"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
@@ -38,7 +40,15 @@ func init() {
 
 // TokenPermissions will run the Token-Permissions check.
 func TokenPermissions(c *checker.CheckRequest) checker.CheckResult {
-	rawData, err := raw.TokenPermissions(c)
+	var rawData checker.TokenPermissionsData
+	var err error
+
+	switch c.RepoClient.(type) {
+	case *gitlabrepo.Client:
+		rawData, err = gitlab.TokenPermissions(c)
+	default:
+		rawData, err = raw.TokenPermissions(c)
+	}
 	if err != nil {
 		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 		return checker.CreateRuntimeErrorResult(CheckTokenPermissions, e)
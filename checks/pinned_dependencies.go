@@ -15,9 +15,13 @@
 package checks
 
 import (
+	"os"
+
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/evaluation"
 	"github.com/ossf/scorecard/v4/checks/raw"
+	"github.com/ossf/scorecard/v4/checks/raw/github"
+	"github.com/ossf/scorecard/v4/clients/githubrepo"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
@@ -44,6 +48,16 @@ func PinningDependencies(c *checker.CheckRequest) checker.CheckResult {
 		return checker.CreateRuntimeErrorResult(CheckPinnedDependencies, e)
 	}
 
+	// Verifying pinned actions queries the actions' repositories, so it is opt-in.
+	if _, enabled := os.LookupEnv(github.EnvVarVerifyPinnedActions); enabled {
+		if _, ok := c.RepoClient.(*githubrepo.Client); ok {
+			if err := github.VerifyPinnedActions(c, &rawData); err != nil {
+				e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+				return checker.CreateRuntimeErrorResult(CheckPinnedDependencies, e)
+			}
+		}
+	}
+
 	// Set the raw results.
 	if c.RawResults != nil {
 		c.RawResults.PinningDependenciesResults = rawData
@@ -112,10 +112,16 @@ func BranchProtection(c clients.RepoClient) (checker.BranchProtectionsData, erro
 		return checker.BranchProtectionsData{}, err
 	}
 
+	codeowners, err := collectCodeowners(c)
+	if err != nil {
+		return checker.BranchProtectionsData{}, err
+	}
+
 	// No error, return the data.
 	return checker.BranchProtectionsData{
 		Branches:        branches.set,
 		CodeownersFiles: codeownersFiles,
+		Codeowners:      codeowners,
 	}, nil
 }
 
@@ -0,0 +1,100 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const busFactorLookbackDays = 365
+
+// BusFactor retrieves the raw data for the Bus-Factor check.
+func BusFactor(c clients.RepoClient) (checker.BusFactorData, error) {
+	lookback := time.Now().AddDate(0 /*years*/, 0 /*months*/, -1*busFactorLookbackDays /*days*/)
+	data := checker.BusFactorData{
+		LookbackDate: lookback,
+	}
+
+	commits, err := c.ListCommits()
+	if err != nil {
+		return checker.BusFactorData{}, fmt.Errorf("Client.Repositories.ListCommits: %w", err)
+	}
+
+	maintainers := make(map[string]*checker.Maintainer)
+	getMaintainer := func(login string) *checker.Maintainer {
+		if _, ok := maintainers[login]; !ok {
+			maintainers[login] = &checker.Maintainer{
+				User: clients.User{Login: login},
+			}
+		}
+		return maintainers[login]
+	}
+
+	merges := make(map[int]bool)
+	for i := range commits {
+		commit := &commits[i]
+		if commit.CommittedDate.Before(lookback) {
+			continue
+		}
+		data.NumCommits++
+
+		// Commits created by GitHub on behalf of a user (e.g., squash-merges via the UI)
+		// are attributed to the user who merged the associated pull request below.
+		if login := commit.Committer.Login; login != "" && login != "github" && !commit.Committer.IsBot {
+			getMaintainer(login).NumCommits++
+		}
+
+		pr := &commit.AssociatedMergeRequest
+		if pr.MergedBy.Login == "" || pr.MergedBy.IsBot || pr.MergedAt.Before(lookback) {
+			continue
+		}
+		if merges[pr.Number] {
+			continue
+		}
+		merges[pr.Number] = true
+		getMaintainer(pr.MergedBy.Login).NumMerges++
+	}
+
+	// Reuse the affiliations collected for the Contributors check.
+	contributors, err := Contributors(c)
+	if err != nil && !errors.Is(err, clients.ErrUnsupportedFeature) {
+		return checker.BusFactorData{}, err
+	}
+	for i := range contributors.Users {
+		user := &contributors.Users[i]
+		m, ok := maintainers[user.Login]
+		if !ok {
+			continue
+		}
+		m.User.Organizations = user.Organizations
+		m.User.Companies = user.Companies
+	}
+
+	for _, m := range maintainers {
+		data.Maintainers = append(data.Maintainers, *m)
+	}
+	// Keep results stable across runs.
+	sort.Slice(data.Maintainers, func(i, j int) bool {
+		return data.Maintainers[i].User.Login < data.Maintainers[j].User.Login
+	})
+
+	return data, nil
+}
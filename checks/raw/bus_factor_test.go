@@ -0,0 +1,148 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func TestBusFactor(t *testing.T) {
+	t.Parallel()
+	recent := time.Now().AddDate(0, -1, 0)
+	old := time.Now().AddDate(-2, 0, 0)
+	//nolint:govet
+	tests := []struct {
+		name            string
+		commits         []clients.Commit
+		contributors    []clients.User
+		contributorsErr error
+		wantCommits     int
+		want            []checker.Maintainer
+	}{
+		{
+			name: "commits and merges",
+			commits: []clients.Commit{
+				{
+					CommittedDate: recent,
+					Committer:     clients.User{Login: "alice"},
+				},
+				{
+					CommittedDate: recent,
+					Committer:     clients.User{Login: "github"},
+					AssociatedMergeRequest: clients.PullRequest{
+						Number:   1,
+						MergedAt: recent,
+						MergedBy: clients.User{Login: "bob"},
+					},
+				},
+				{
+					// Same PR as above.
+					CommittedDate: recent,
+					Committer:     clients.User{Login: "github"},
+					AssociatedMergeRequest: clients.PullRequest{
+						Number:   1,
+						MergedAt: recent,
+						MergedBy: clients.User{Login: "bob"},
+					},
+				},
+				{
+					CommittedDate: recent,
+					Committer:     clients.User{Login: "dependabot", IsBot: true},
+				},
+				{
+					CommittedDate: old,
+					Committer:     clients.User{Login: "charlie"},
+				},
+			},
+			contributors: []clients.User{
+				{
+					Login:         "alice",
+					Organizations: []clients.User{{Login: "org1"}},
+					Companies:     []string{"@Company1"},
+				},
+				{
+					Login:         "charlie",
+					Organizations: []clients.User{{Login: "org2"}},
+				},
+			},
+			wantCommits: 4,
+			want: []checker.Maintainer{
+				{
+					User: clients.User{
+						Login:         "alice",
+						Organizations: []clients.User{{Login: "org1"}},
+						Companies:     []string{"company1"},
+					},
+					NumCommits: 1,
+				},
+				{
+					User:      clients.User{Login: "bob"},
+					NumMerges: 1,
+				},
+			},
+		},
+		{
+			name: "contributors unsupported",
+			commits: []clients.Commit{
+				{
+					CommittedDate: recent,
+					Committer:     clients.User{Login: "alice"},
+				},
+			},
+			contributorsErr: fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature),
+			wantCommits:     1,
+			want: []checker.Maintainer{
+				{
+					User:       clients.User{Login: "alice"},
+					NumCommits: 1,
+				},
+			},
+		},
+		{
+			name:        "no commits",
+			wantCommits: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListCommits().Return(tt.commits, nil)
+			mockRepoClient.EXPECT().ListContributors().Return(tt.contributors, tt.contributorsErr)
+
+			data, err := BusFactor(mockRepoClient)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if data.NumCommits != tt.wantCommits {
+				t.Errorf("NumCommits: got %d, want %d", data.NumCommits, tt.wantCommits)
+			}
+			if diff := cmp.Diff(tt.want, data.Maintainers); diff != "" {
+				t.Errorf("unexpected maintainers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/ci"
 	"github.com/ossf/scorecard/v4/clients"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
@@ -74,10 +75,15 @@ func CITests(c clients.RepoClient) (checker.CITestData, error) {
 	for headsha := range runs {
 		crs := runs[headsha]
 		statuses := commitStatuses[headsha]
+		categories, err := ci.Categories(statuses, crs)
+		if err != nil {
+			return checker.CITestData{}, fmt.Errorf("ci.Categories: %w", err)
+		}
 		infos = append(infos, checker.RevisionCIInfo{
 			HeadSHA:           headsha,
 			CheckRuns:         crs,
 			Statuses:          statuses,
+			Categories:        categories,
 			PullRequestNumber: prNos[headsha],
 		})
 	}
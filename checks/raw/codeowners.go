@@ -0,0 +1,418 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// codeownersLocations are the locations where GitHub and GitLab look for
+// a CODEOWNERS file. The first three are listed in GitHub's order of precedence.
+var codeownersLocations = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+var (
+	// GitLab section headers, e.g., `[Docs]`, `^[Optional][2] @owner`.
+	codeownersSection = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(.*)$`)
+	codeownersUser    = regexp.MustCompile(`^@[\w.-]+$`)
+	codeownersTeam    = regexp.MustCompile(`^@[\w.-]+(/[\w.-]+)+$`)
+	codeownersRole    = regexp.MustCompile(`^@@[\w-]+$`)
+	codeownersEmail   = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+var buildFiles = map[string]bool{
+	"Makefile":         true,
+	"Dockerfile":       true,
+	"BUILD":            true,
+	"BUILD.bazel":      true,
+	"WORKSPACE":        true,
+	"CMakeLists.txt":   true,
+	"go.mod":           true,
+	"package.json":     true,
+	"pom.xml":          true,
+	"build.gradle":     true,
+	"build.gradle.kts": true,
+	"settings.gradle":  true,
+	"setup.py":         true,
+	"setup.cfg":        true,
+	"pyproject.toml":   true,
+	"Cargo.toml":       true,
+	"Gemfile":          true,
+	"Rakefile":         true,
+	"meson.build":      true,
+}
+
+var workflowFiles = map[string]bool{
+	".gitlab-ci.yml":       true,
+	".travis.yml":          true,
+	".circleci/config.yml": true,
+	"azure-pipelines.yml":  true,
+	"cloudbuild.yaml":      true,
+	"Jenkinsfile":          true,
+}
+
+// collectCodeowners parses the CODEOWNERS files and computes
+// which sensitive files of the repository they assign owners to.
+func collectCodeowners(c clients.RepoClient) ([]checker.CodeownersFile, error) {
+	files, err := c.ListFiles(func(string) (bool, error) { return true, nil })
+	if err != nil {
+		return nil, fmt.Errorf("error during ListFiles: %w", err)
+	}
+
+	present := make(map[string]bool)
+	var sensitive []checker.CodeownersCoverage
+	for _, f := range files {
+		present[f] = true
+		if t, ok := sensitivePathType(f); ok {
+			sensitive = append(sensitive, checker.CodeownersCoverage{
+				Path: f,
+				Type: t,
+			})
+		}
+	}
+
+	var ret []checker.CodeownersFile
+	for _, location := range codeownersLocations {
+		if !present[location] {
+			continue
+		}
+		content, err := c.GetFileContent(location)
+		if err != nil {
+			return nil, fmt.Errorf("error during GetFileContent: %w", err)
+		}
+		ret = append(ret, checker.CodeownersFile{
+			File: checker.File{
+				Path:     location,
+				Type:     finding.FileTypeText,
+				FileSize: uint(len(content)),
+			},
+			Rules: parseCodeowners(content),
+		})
+	}
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	resolveCodeowners(c, ret)
+
+	for i := range ret {
+		patterns := compileCodeownersPatterns(ret[i].Rules)
+		for _, s := range sensitive {
+			s.Owners = codeownersFor(ret[i].Rules, patterns, s.Path)
+			ret[i].SensitivePaths = append(ret[i].SensitivePaths, s)
+		}
+	}
+	return ret, nil
+}
+
+// resolveCodeowners sets whether owners resolve to existing users or teams, using
+// the errors reported by the hosting platform. Resolution is best effort: if the
+// platform does not support it or the request fails, owners are left unresolved.
+func resolveCodeowners(c clients.RepoClient, files []checker.CodeownersFile) {
+	codeownersErrors, err := c.ListCodeownersErrors()
+	validated := err == nil
+
+	// GitHub only validates the CODEOWNERS file it uses,
+	// i.e., the first one found in order of precedence.
+	validatedPath := ""
+	if validated && files[0].File.Path != ".gitlab/CODEOWNERS" {
+		validatedPath = files[0].File.Path
+	}
+
+	t, f := true, false
+	for i := range files {
+		file := &files[i]
+		for j := range file.Rules {
+			rule := &file.Rules[j]
+			for k := range rule.Owners {
+				owner := &rule.Owners[k]
+				switch {
+				case owner.Type == checker.CodeownerTypeInvalid:
+					owner.Resolved = &f
+				case owner.Type == checker.CodeownerTypeRole:
+					// Roles are not validated by the platform.
+				case file.File.Path != validatedPath:
+				case hasCodeownersError(codeownersErrors, file.File.Path, rule.Line, owner.Name):
+					owner.Resolved = &f
+				default:
+					owner.Resolved = &t
+				}
+			}
+		}
+	}
+}
+
+func hasCodeownersError(errs []clients.CodeownersError, filepath string, line uint, owner string) bool {
+	for i := range errs {
+		e := &errs[i]
+		if e.Path != filepath || e.Line < 0 || uint(e.Line) != line {
+			continue
+		}
+		if e.Column < 1 || e.Column > len(e.Source) {
+			continue
+		}
+		token := strings.Fields(e.Source[e.Column-1:])
+		if len(token) > 0 && token[0] == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCodeowners parses the content of a CODEOWNERS file, using
+// the GitHub syntax and GitLab extensions for sections.
+func parseCodeowners(content []byte) []checker.CodeownersRule {
+	var rules []checker.CodeownersRule
+	var section string
+	var sectionOwners []checker.Codeowner
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	var lineNum uint
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if m := codeownersSection.FindStringSubmatch(line); m != nil {
+			section = strings.TrimSpace(m[1])
+			sectionOwners = parseCodeownersOwners(splitCodeownersLine(m[2]))
+			continue
+		}
+
+		fields := splitCodeownersLine(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rule := checker.CodeownersRule{
+			Pattern: fields[0],
+			Section: section,
+			Owners:  parseCodeownersOwners(fields[1:]),
+			Line:    lineNum,
+		}
+		// GitLab: entries without owners use the default owners of their section.
+		if len(rule.Owners) == 0 && section != "" {
+			rule.Owners = append(rule.Owners, sectionOwners...)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// splitCodeownersLine splits a line on whitespace, handling escaped
+// characters in patterns and stripping trailing comments.
+func splitCodeownersLine(line string) []string {
+	var fields []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '#' && current.Len() == 0:
+			// Start of a comment.
+			return fields
+		case r == ' ' || r == '\t':
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+func parseCodeownersOwners(fields []string) []checker.Codeowner {
+	var owners []checker.Codeowner
+	for _, name := range fields {
+		owner := checker.Codeowner{Name: name}
+		switch {
+		case codeownersRole.MatchString(name):
+			owner.Type = checker.CodeownerTypeRole
+		case codeownersUser.MatchString(name):
+			owner.Type = checker.CodeownerTypeUser
+		case codeownersTeam.MatchString(name):
+			owner.Type = checker.CodeownerTypeTeam
+		case codeownersEmail.MatchString(name):
+			owner.Type = checker.CodeownerTypeEmail
+		default:
+			owner.Type = checker.CodeownerTypeInvalid
+		}
+		owners = append(owners, owner)
+	}
+	return owners
+}
+
+// compileCodeownersPatterns returns the regexps of the patterns of the rules, in the same
+// order. Invalid patterns, which match no file, have a nil regexp.
+func compileCodeownersPatterns(rules []checker.CodeownersRule) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, len(rules))
+	for i := range rules {
+		if re, err := codeownersPatternRegexp(rules[i].Pattern); err == nil {
+			patterns[i] = re
+		}
+	}
+	return patterns
+}
+
+// codeownersFor returns the owners of a file, using the compiled patterns of the rules.
+// Within a section, the last matching rule takes precedence. On GitLab, the owners of
+// all sections apply.
+func codeownersFor(rules []checker.CodeownersRule, patterns []*regexp.Regexp, filepath string) []checker.Codeowner {
+	var sections []string
+	matches := make(map[string]*checker.CodeownersRule)
+	for i := range rules {
+		rule := &rules[i]
+		if patterns[i] == nil || !patterns[i].MatchString(filepath) {
+			continue
+		}
+		if _, exists := matches[rule.Section]; !exists {
+			sections = append(sections, rule.Section)
+		}
+		matches[rule.Section] = rule
+	}
+
+	var owners []checker.Codeowner
+	for _, s := range sections {
+		owners = append(owners, matches[s].Owners...)
+	}
+	return owners
+}
+
+// codeownersPatternMatches follows the gitignore rules used by GitHub and GitLab.
+func codeownersPatternMatches(pattern, filepath string) bool {
+	re, err := codeownersPatternRegexp(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(filepath)
+}
+
+func codeownersPatternRegexp(pattern string) (*regexp.Regexp, error) {
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// Patterns with a leading or middle slash are relative to the root.
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case ch == '*':
+			sb.WriteString("[^/]*")
+		case ch == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	switch {
+	case directory:
+		sb.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*"):
+		// `docs/*` only matches files directly in `docs`.
+		sb.WriteString("$")
+	default:
+		// A pattern matches a file or all the files in a directory.
+		sb.WriteString("(?:/.*)?$")
+	}
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid CODEOWNERS pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+func sensitivePathType(filepath string) (checker.SensitivePathType, bool) {
+	dir, name := path.Split(filepath)
+	switch {
+	case strings.HasPrefix(filepath, ".github/workflows/") &&
+		(strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")),
+		workflowFiles[filepath]:
+		return checker.SensitivePathTypeWorkflow, true
+	case name == "CODEOWNERS" && isCodeownersLocation(filepath):
+		return checker.SensitivePathTypeCodeowners, true
+	case dir == "" && buildFiles[name]:
+		return checker.SensitivePathTypeBuild, true
+	case isReleaseScript(dir, name):
+		return checker.SensitivePathTypeRelease, true
+	}
+	return "", false
+}
+
+func isCodeownersLocation(filepath string) bool {
+	for _, l := range codeownersLocations {
+		if l == filepath {
+			return true
+		}
+	}
+	return false
+}
+
+func isReleaseScript(dir, name string) bool {
+	if name == ".goreleaser.yml" || name == ".goreleaser.yaml" {
+		return true
+	}
+	// Only consider scripts at the root or in directories commonly used for tooling.
+	switch strings.TrimSuffix(dir, "/") {
+	case "", "scripts", "script", "hack", "build", "tools", "release":
+	default:
+		return false
+	}
+	lower := strings.ToLower(name)
+	if !strings.Contains(lower, "release") && !strings.Contains(lower, "publish") {
+		return false
+	}
+	switch path.Ext(lower) {
+	case "", ".sh", ".bash", ".ps1", ".py", ".js", ".mk", ".yml", ".yaml":
+		return true
+	}
+	return false
+}
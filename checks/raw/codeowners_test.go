@@ -0,0 +1,350 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+func TestParseCodeowners(t *testing.T) {
+	t.Parallel()
+	//nolint:govet
+	tests := []struct {
+		name    string
+		content string
+		want    []checker.CodeownersRule
+	}{
+		{
+			name: "github syntax",
+			content: `# Default owners.
+*       @org/maintainers
+
+/docs/  docs@example.com # Documentation.
+\#file  @alice
+*.go    alice
+`,
+			want: []checker.CodeownersRule{
+				{
+					Pattern: "*",
+					Line:    2,
+					Owners: []checker.Codeowner{
+						{Name: "@org/maintainers", Type: checker.CodeownerTypeTeam},
+					},
+				},
+				{
+					Pattern: "/docs/",
+					Line:    4,
+					Owners: []checker.Codeowner{
+						{Name: "docs@example.com", Type: checker.CodeownerTypeEmail},
+					},
+				},
+				{
+					Pattern: "#file",
+					Line:    5,
+					Owners: []checker.Codeowner{
+						{Name: "@alice", Type: checker.CodeownerTypeUser},
+					},
+				},
+				{
+					Pattern: "*.go",
+					Line:    6,
+					Owners: []checker.Codeowner{
+						{Name: "alice", Type: checker.CodeownerTypeInvalid},
+					},
+				},
+			},
+		},
+		{
+			name: "gitlab sections",
+			content: `[Build] @group/build
+Makefile
+^[Docs][2] @@maintainer
+README.md @bob
+`,
+			want: []checker.CodeownersRule{
+				{
+					Pattern: "Makefile",
+					Section: "Build",
+					Line:    2,
+					Owners: []checker.Codeowner{
+						{Name: "@group/build", Type: checker.CodeownerTypeTeam},
+					},
+				},
+				{
+					Pattern: "README.md",
+					Section: "Docs",
+					Line:    4,
+					Owners: []checker.Codeowner{
+						{Name: "@bob", Type: checker.CodeownerTypeUser},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := parseCodeowners([]byte(tt.content))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCodeownersPatternMatches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*", path: "Makefile", want: true},
+		{pattern: "*", path: ".github/workflows/ci.yml", want: true},
+		{pattern: "*.yml", path: ".github/workflows/ci.yml", want: true},
+		{pattern: "*.yml", path: "Makefile", want: false},
+		{pattern: "/.github/", path: ".github/workflows/ci.yml", want: true},
+		{pattern: ".github/", path: ".github/workflows/ci.yml", want: true},
+		{pattern: "/.github/workflows/*", path: ".github/workflows/ci.yml", want: true},
+		{pattern: "/.github/*", path: ".github/workflows/ci.yml", want: false},
+		{pattern: "workflows", path: ".github/workflows/ci.yml", want: true},
+		{pattern: "/Makefile", path: "Makefile", want: true},
+		{pattern: "/Makefile", path: "src/Makefile", want: false},
+		{pattern: "**/release.sh", path: "scripts/release.sh", want: true},
+		{pattern: "docs/**", path: "docs/a/b.md", want: true},
+		{pattern: "scripts/", path: "scripts.sh", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			t.Parallel()
+			if got := codeownersPatternMatches(tt.pattern, tt.path); got != tt.want {
+				t.Errorf("codeownersPatternMatches(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSensitivePathType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path   string
+		want   checker.SensitivePathType
+		wantOk bool
+	}{
+		{path: ".github/workflows/ci.yml", want: checker.SensitivePathTypeWorkflow, wantOk: true},
+		{path: ".gitlab-ci.yml", want: checker.SensitivePathTypeWorkflow, wantOk: true},
+		{path: "Makefile", want: checker.SensitivePathTypeBuild, wantOk: true},
+		{path: "src/Makefile", wantOk: false},
+		{path: "scripts/release.sh", want: checker.SensitivePathTypeRelease, wantOk: true},
+		{path: ".goreleaser.yml", want: checker.SensitivePathTypeRelease, wantOk: true},
+		{path: "docs/release-notes.md", wantOk: false},
+		{path: ".github/CODEOWNERS", want: checker.SensitivePathTypeCodeowners, wantOk: true},
+		{path: "main.go", wantOk: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			got, ok := sensitivePathType(tt.path)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("sensitivePathType(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestCollectCodeowners(t *testing.T) {
+	t.Parallel()
+	resolved, unresolved := true, false
+	content := `* @org/maintainers
+/.github/ @ghost @alice
+release.sh nobody
+`
+	//nolint:govet
+	tests := []struct {
+		name      string
+		files     []string
+		errs      []clients.CodeownersError
+		errsErr   error
+		want      []checker.CodeownersFile
+		wantEmpty bool
+	}{
+		{
+			name:      "no codeowners file",
+			files:     []string{"Makefile"},
+			wantEmpty: true,
+		},
+		{
+			name:  "resolved owners",
+			files: []string{".github/CODEOWNERS", ".github/workflows/ci.yml", "release.sh", "main.go"},
+			errs: []clients.CodeownersError{
+				{
+					Path:   ".github/CODEOWNERS",
+					Line:   2,
+					Column: 11,
+					Kind:   "Unknown owner",
+					Source: "/.github/ @ghost @alice",
+				},
+			},
+			want: []checker.CodeownersFile{
+				{
+					File: checker.File{
+						Path:     ".github/CODEOWNERS",
+						Type:     finding.FileTypeText,
+						FileSize: uint(len(content)),
+					},
+					Rules: []checker.CodeownersRule{
+						{
+							Pattern: "*",
+							Line:    1,
+							Owners: []checker.Codeowner{
+								{Name: "@org/maintainers", Type: checker.CodeownerTypeTeam, Resolved: &resolved},
+							},
+						},
+						{
+							Pattern: "/.github/",
+							Line:    2,
+							Owners: []checker.Codeowner{
+								{Name: "@ghost", Type: checker.CodeownerTypeUser, Resolved: &unresolved},
+								{Name: "@alice", Type: checker.CodeownerTypeUser, Resolved: &resolved},
+							},
+						},
+						{
+							Pattern: "release.sh",
+							Line:    3,
+							Owners: []checker.Codeowner{
+								{Name: "nobody", Type: checker.CodeownerTypeInvalid, Resolved: &unresolved},
+							},
+						},
+					},
+					SensitivePaths: []checker.CodeownersCoverage{
+						{
+							Path: ".github/CODEOWNERS",
+							Type: checker.SensitivePathTypeCodeowners,
+							Owners: []checker.Codeowner{
+								{Name: "@ghost", Type: checker.CodeownerTypeUser, Resolved: &unresolved},
+								{Name: "@alice", Type: checker.CodeownerTypeUser, Resolved: &resolved},
+							},
+						},
+						{
+							Path: ".github/workflows/ci.yml",
+							Type: checker.SensitivePathTypeWorkflow,
+							Owners: []checker.Codeowner{
+								{Name: "@ghost", Type: checker.CodeownerTypeUser, Resolved: &unresolved},
+								{Name: "@alice", Type: checker.CodeownerTypeUser, Resolved: &resolved},
+							},
+						},
+						{
+							Path: "release.sh",
+							Type: checker.SensitivePathTypeRelease,
+							Owners: []checker.Codeowner{
+								{Name: "nobody", Type: checker.CodeownerTypeInvalid, Resolved: &unresolved},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "resolution not supported",
+			files:   []string{"CODEOWNERS", "release.sh"},
+			errsErr: clients.ErrUnsupportedFeature,
+			want: []checker.CodeownersFile{
+				{
+					File: checker.File{
+						Path:     "CODEOWNERS",
+						Type:     finding.FileTypeText,
+						FileSize: uint(len(content)),
+					},
+					Rules: []checker.CodeownersRule{
+						{
+							Pattern: "*",
+							Line:    1,
+							Owners: []checker.Codeowner{
+								{Name: "@org/maintainers", Type: checker.CodeownerTypeTeam},
+							},
+						},
+						{
+							Pattern: "/.github/",
+							Line:    2,
+							Owners: []checker.Codeowner{
+								{Name: "@ghost", Type: checker.CodeownerTypeUser},
+								{Name: "@alice", Type: checker.CodeownerTypeUser},
+							},
+						},
+						{
+							Pattern: "release.sh",
+							Line:    3,
+							Owners: []checker.Codeowner{
+								{Name: "nobody", Type: checker.CodeownerTypeInvalid, Resolved: &unresolved},
+							},
+						},
+					},
+					SensitivePaths: []checker.CodeownersCoverage{
+						{
+							Path: "CODEOWNERS",
+							Type: checker.SensitivePathTypeCodeowners,
+							Owners: []checker.Codeowner{
+								{Name: "@org/maintainers", Type: checker.CodeownerTypeTeam},
+							},
+						},
+						{
+							Path: "release.sh",
+							Type: checker.SensitivePathTypeRelease,
+							Owners: []checker.Codeowner{
+								{Name: "nobody", Type: checker.CodeownerTypeInvalid, Resolved: &unresolved},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return(tt.files, nil)
+			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).Return([]byte(content), nil).AnyTimes()
+			mockRepoClient.EXPECT().ListCodeownersErrors().Return(tt.errs, tt.errsErr).AnyTimes()
+
+			got, err := collectCodeowners(mockRepoClient)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantEmpty {
+				if got != nil {
+					t.Errorf("expected no codeowners, got %v", got)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
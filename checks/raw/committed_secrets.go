@@ -0,0 +1,311 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// SecretsAllowlistFile is the file, at the root of the repository,
+// listing the detected secrets that are known to be harmless.
+const SecretsAllowlistFile = ".scorecard-secrets.yml"
+
+const (
+	// maxSecretsFileSize is the size above which files are not scanned:
+	// they are most likely generated or vendored.
+	maxSecretsFileSize = 1 << 20
+	// Values assigned to secret-looking keys in configuration files are
+	// reported when they are long and random enough.
+	minSecretValueLength  = 20
+	minSecretValueEntropy = 3.7
+)
+
+type secretRule struct {
+	pattern *regexp.Regexp
+	// verify, if set, confirms a match using the rest of the line and the next line.
+	verify func(match []byte, rest, next []byte) bool
+	id     string
+	// configOnly restricts the rule to configuration files.
+	configOnly bool
+}
+
+var (
+	privateKeyBody = regexp.MustCompile(`^(?:\\n|\\r\\n)?[A-Za-z0-9+/]{40,}`)
+	// The key is captured to leave it out of the match, only the value is inspected.
+	secretAssignment = regexp.MustCompile(
+		`(?i)(?:secret|token|passw(?:or)?d|api[_-]?key|access[_-]?key|private[_-]?key|credentials?)` +
+			`[\w.-]*["']?\s*[:=]\s*["']?([^\s"'#,;]+)`)
+)
+
+var secretRules = []secretRule{
+	{
+		id:      "private-key",
+		pattern: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`),
+		verify:  hasPrivateKeyBody,
+	},
+	{
+		id:      "aws-access-key-id",
+		pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+	{
+		id:      "google-api-key",
+		pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`),
+	},
+	{
+		id:      "github-token",
+		pattern: regexp.MustCompile(`\b(?:gh[pousr]_[0-9A-Za-z]{36}|github_pat_[0-9A-Za-z_]{82})\b`),
+	},
+	{
+		id:      "gitlab-token",
+		pattern: regexp.MustCompile(`\bglpat-[0-9A-Za-z_-]{20}\b`),
+	},
+	{
+		id:      "slack-token",
+		pattern: regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z-]{10,}`),
+	},
+	{
+		id:      "stripe-secret-key",
+		pattern: regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}\b`),
+	},
+	{
+		id:      "npm-token",
+		pattern: regexp.MustCompile(`\bnpm_[0-9A-Za-z]{36}\b`),
+	},
+	{
+		id:      "pypi-token",
+		pattern: regexp.MustCompile(`\bpypi-AgEIcHlwaS5vcmc[0-9A-Za-z_-]{50,}`),
+	},
+	{
+		id:         "high-entropy-value",
+		pattern:    secretAssignment,
+		verify:     isHighEntropyValue,
+		configOnly: true,
+	},
+}
+
+var configExtensions = map[string]bool{
+	".env":        true,
+	".yml":        true,
+	".yaml":       true,
+	".json":       true,
+	".properties": true,
+	".ini":        true,
+	".toml":       true,
+	".cfg":        true,
+	".conf":       true,
+	".xml":        true,
+}
+
+// secretsAllowlist is the content of the SecretsAllowlistFile.
+type secretsAllowlist struct {
+	Allowlist []secretsAllowlistEntry `yaml:"allowlist"`
+}
+
+type secretsAllowlistEntry struct {
+	// Path is a glob matched against the full path of the file.
+	// A path ending with `/` matches all the files of the directory.
+	Path string `yaml:"path"`
+	// Rule restricts the entry to a detection rule. All rules match if empty.
+	Rule   string `yaml:"rule"`
+	Reason string `yaml:"reason"`
+}
+
+type secretsScan struct {
+	allowlist *secretsAllowlist
+	secrets   []checker.Secret
+}
+
+// CommittedSecrets retrieves the raw data for the Committed-Secrets check.
+// Only the files of the scanned commit are inspected, not the history.
+func CommittedSecrets(c clients.RepoClient) (checker.CommittedSecretsData, error) {
+	var scan secretsScan
+	err := fileparser.OnMatchingFileContentDo(c, fileparser.PathMatcher{
+		Pattern:       "*",
+		CaseSensitive: false,
+	}, scanSecrets, &scan)
+	if err != nil {
+		return checker.CommittedSecretsData{}, fmt.Errorf("%w", err)
+	}
+
+	if scan.allowlist != nil {
+		for i := range scan.secrets {
+			applySecretsAllowlist(&scan.secrets[i], scan.allowlist)
+		}
+	}
+	return checker.CommittedSecretsData{Secrets: scan.secrets}, nil
+}
+
+var scanSecrets fileparser.DoWhileTrueOnFileContent = func(path string, content []byte,
+	args ...interface{},
+) (bool, error) {
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"scanSecrets requires exactly one argument: %w", errInvalidArgLength)
+	}
+	scan, ok := args[0].(*secretsScan)
+	if !ok {
+		return false, fmt.Errorf(
+			"scanSecrets requires argument of type *secretsScan: %w", errInvalidArgType)
+	}
+
+	if path == SecretsAllowlistFile {
+		var allowlist secretsAllowlist
+		if err := yaml.Unmarshal(content, &allowlist); err != nil {
+			return false, sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("invalid %s: %v", SecretsAllowlistFile, err))
+		}
+		scan.allowlist = &allowlist
+		return true, nil
+	}
+
+	if len(content) == 0 || len(content) > maxSecretsFileSize || !isText(content) {
+		return true, nil
+	}
+
+	scan.secrets = append(scan.secrets, findSecrets(path, content)...)
+	return true, nil
+}
+
+// findSecrets returns the location of the secrets found in content.
+// At most one secret per rule is reported for each line.
+func findSecrets(path string, content []byte) []checker.Secret {
+	config := isConfigFile(path)
+	lines := bytes.Split(content, []byte("\n"))
+	var secrets []checker.Secret
+	for i, line := range lines {
+		var next []byte
+		if i+1 < len(lines) {
+			next = lines[i+1]
+		}
+		for j := range secretRules {
+			rule := &secretRules[j]
+			if rule.configOnly && !config {
+				continue
+			}
+			if !ruleMatches(rule, line, next) {
+				continue
+			}
+			secrets = append(secrets, checker.Secret{
+				Rule: rule.id,
+				File: checker.File{
+					Path:   path,
+					Type:   finding.FileTypeText,
+					Offset: uint(i + 1),
+				},
+			})
+		}
+	}
+	return secrets
+}
+
+func ruleMatches(rule *secretRule, line, next []byte) bool {
+	for _, loc := range rule.pattern.FindAllSubmatchIndex(line, -1) {
+		if rule.verify == nil {
+			return true
+		}
+		// Verify the first submatch if any, the whole match otherwise.
+		match := line[loc[0]:loc[1]]
+		if len(loc) > 3 && loc[2] >= 0 {
+			match = line[loc[2]:loc[3]]
+		}
+		if rule.verify(match, line[loc[1]:], next) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrivateKeyBody ignores the PEM headers that are not followed by a key,
+// e.g., in code parsing keys or in documentation.
+func hasPrivateKeyBody(_, rest, next []byte) bool {
+	return privateKeyBody.Match(bytes.TrimLeft(rest, ` "'`)) ||
+		privateKeyBody.Match(bytes.TrimLeft(next, " \t\"'"))
+}
+
+func isHighEntropyValue(value, _, _ []byte) bool {
+	v := string(value)
+	if len(v) < minSecretValueLength {
+		return false
+	}
+	// Skip URLs and references to variables, e.g., `${TOKEN}`, `{{ .Token }}` or `$(cat token)`.
+	if strings.Contains(v, "://") || strings.ContainsAny(v, "$%{}<>()") {
+		return false
+	}
+	return shannonEntropy(v) >= minSecretValueEntropy
+}
+
+// shannonEntropy returns the number of bits of entropy per character of s.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func isConfigFile(p string) bool {
+	base := strings.ToLower(path.Base(p))
+	if base == ".env" || strings.HasPrefix(base, ".env.") {
+		return true
+	}
+	return configExtensions[path.Ext(base)]
+}
+
+func applySecretsAllowlist(secret *checker.Secret, allowlist *secretsAllowlist) {
+	for i := range allowlist.Allowlist {
+		entry := &allowlist.Allowlist[i]
+		if entry.Rule != "" && entry.Rule != secret.Rule {
+			continue
+		}
+		if !allowlistPathMatches(entry.Path, secret.File.Path) {
+			continue
+		}
+		secret.Allowlisted = true
+		secret.Reason = entry.Reason
+		return
+	}
+}
+
+func allowlistPathMatches(pattern, p string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(p, pattern)
+	}
+	matched, err := path.Match(pattern, p)
+	return err == nil && matched
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// The fake credentials are built at runtime so that this file
+// is not reported by the Committed-Secrets check itself.
+var (
+	fakeAWSKey      = "AKIA" + strings.Repeat("Q", 16)
+	fakeGitHubToken = "ghp" + "_" + strings.Repeat("a1B2", 9)
+	fakeKeyHeader   = "-----BEGIN RSA " + "PRIVATE KEY-----"
+	fakeKeyBody     = strings.Repeat("MIIEowIBAAKCAQEA", 4)
+)
+
+func secretAt(path string, line uint, rule string) checker.Secret {
+	return checker.Secret{
+		Rule: rule,
+		File: checker.File{
+			Path:   path,
+			Type:   finding.FileTypeText,
+			Offset: line,
+		},
+	}
+}
+
+func TestCommittedSecrets(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    []checker.Secret
+		wantErr bool
+	}{
+		{
+			name: "no secrets",
+			files: map[string]string{
+				"main.go":     "package main\n\nfunc main() {}\n",
+				"config.yaml": "token: ${GITHUB_TOKEN}\npassword: changeme\n",
+			},
+		},
+		{
+			name: "token with known prefix",
+			files: map[string]string{
+				"scripts/release.sh": fmt.Sprintf("#!/bin/sh\nexport GH_TOKEN=%s\n", fakeGitHubToken),
+			},
+			want: []checker.Secret{
+				secretAt("scripts/release.sh", 2, "github-token"),
+			},
+		},
+		{
+			name: "cloud key in source",
+			files: map[string]string{
+				"src/aws.py": fmt.Sprintf("import boto3\n\nkey = %q\n", fakeAWSKey),
+			},
+			want: []checker.Secret{
+				secretAt("src/aws.py", 3, "aws-access-key-id"),
+			},
+		},
+		{
+			name: "private key",
+			files: map[string]string{
+				"deploy/id_rsa": fmt.Sprintf("%s\n%s\n-----END RSA PRIVATE KEY-----\n", fakeKeyHeader, fakeKeyBody),
+				"service.json":  fmt.Sprintf("{\"private_key\": \"%s\\n%s\\n\"}\n", fakeKeyHeader, fakeKeyBody),
+			},
+			want: []checker.Secret{
+				secretAt("deploy/id_rsa", 1, "private-key"),
+				secretAt("service.json", 1, "private-key"),
+			},
+		},
+		{
+			name: "private key header without key",
+			files: map[string]string{
+				"pem.go": fmt.Sprintf("package pem\n\nconst header = %q\n", fakeKeyHeader),
+			},
+		},
+		{
+			name: "high entropy value in config file",
+			files: map[string]string{
+				".env":        "DB_PASSWORD=q8Z3vR7tLw2KmX9pYc4N\nDB_HOST=localhost\n",
+				"app.toml":    "api_key = \"https://example.com/keys/current\"\n",
+				"settings.py": "DB_PASSWORD = 'q8Z3vR7tLw2KmX9pYc4N'\n",
+			},
+			want: []checker.Secret{
+				secretAt(".env", 1, "high-entropy-value"),
+			},
+		},
+		{
+			name: "allowlist",
+			files: map[string]string{
+				SecretsAllowlistFile: "allowlist:\n" +
+					"  - path: examples/\n" +
+					"    reason: revoked credentials used in the examples\n" +
+					"  - path: scripts/*.sh\n" +
+					"    rule: aws-access-key-id\n",
+				"examples/aws.py":    fmt.Sprintf("key = %q\n", fakeAWSKey),
+				"scripts/release.sh": fmt.Sprintf("AWS_KEY=%s\nGH_TOKEN=%s\n", fakeAWSKey, fakeGitHubToken),
+			},
+			want: []checker.Secret{
+				{
+					Rule:        "aws-access-key-id",
+					Reason:      "revoked credentials used in the examples",
+					Allowlisted: true,
+					File: checker.File{
+						Path:   "examples/aws.py",
+						Type:   finding.FileTypeText,
+						Offset: 1,
+					},
+				},
+				{
+					Rule:        "aws-access-key-id",
+					Allowlisted: true,
+					File: checker.File{
+						Path:   "scripts/release.sh",
+						Type:   finding.FileTypeText,
+						Offset: 1,
+					},
+				},
+				secretAt("scripts/release.sh", 2, "github-token"),
+			},
+		},
+		{
+			name: "invalid allowlist",
+			files: map[string]string{
+				SecretsAllowlistFile: "allowlist: [",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var files []string
+					for f := range tt.files {
+						if ok, err := predicate(f); err == nil && ok {
+							files = append(files, f)
+						}
+					}
+					sort.Strings(files)
+					return files, nil
+				}).AnyTimes()
+			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(f string) ([]byte, error) {
+				content, ok := tt.files[f]
+				if !ok {
+					return nil, os.ErrNotExist
+				}
+				return []byte(content), nil
+			}).AnyTimes()
+
+			got, err := CommittedSecrets(mockRepoClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CommittedSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got.Secrets); diff != "" {
+				t.Errorf("CommittedSecrets() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAllowlistPathMatches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "config/dev.env", path: "config/dev.env", want: true},
+		{pattern: "/config/dev.env", path: "config/dev.env", want: true},
+		{pattern: "config/*.env", path: "config/dev.env", want: true},
+		{pattern: "*.env", path: "config/dev.env", want: false},
+		{pattern: "docs/", path: "docs/examples/key.pem", want: true},
+		{pattern: "docs/", path: "documentation/key.pem", want: false},
+		{pattern: "", path: "key.pem", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			t.Parallel()
+			if got := allowlistPathMatches(tt.pattern, tt.path); got != tt.want {
+				t.Errorf("allowlistPathMatches(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -15,8 +15,10 @@
 package raw
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/rhysd/actionlint"
@@ -61,10 +63,44 @@ type triggerName string
 var (
 	triggerPullRequestTarget        = triggerName("pull_request_target")
 	triggerWorkflowRun              = triggerName("workflow_run")
+	triggerIssueComment             = triggerName("issue_comment")
 	checkoutUntrustedPullRequestRef = "github.event.pull_request"
 	checkoutUntrustedWorkflowRunRef = "github.event.workflow_run"
 )
 
+// privilegedTriggers run with access to the secrets and the cache of the base repository,
+// even when started by an external contributor.
+var privilegedTriggers = []triggerName{
+	triggerPullRequestTarget,
+	triggerWorkflowRun,
+	triggerIssueComment,
+}
+
+// forkTriggers run the code of pull requests from forks.
+var forkTriggers = []triggerName{
+	triggerName("pull_request"),
+	triggerName("pull_request_review"),
+	triggerName("pull_request_review_comment"),
+}
+
+// Labels of the runners hosted by GitHub, e.g., `ubuntu-22.04`, `macos-latest`,
+// or the labels of larger runners, e.g., `ubuntu-24.04-arm` or `macos-14-xlarge`.
+var githubHostedRunnerLabel = regexp.MustCompile(`(?i)^(ubuntu|windows|macos)-(latest|\d)[\w.-]*$`)
+
+// Job conditions that prevent pull requests from forks from running the job,
+// as rendered by exprString.
+var forkGuards = map[string]bool{
+	"github.event.pull_request.head.repo.fork == false":                  true,
+	"github.event.pull_request.head.repo.fork != true":                   true,
+	"!github.event.pull_request.head.repo.fork":                          true,
+	"github.event.pull_request.head.repo.full_name == github.repository": true,
+	"github.repository == github.event.pull_request.head.repo.full_name": true,
+}
+
+// Writes to the files of GITHUB_ENV or GITHUB_OUTPUT, e.g.,
+// `echo "X=Y" >> $GITHUB_ENV` or `"X=Y" | Out-File -Append $env:GITHUB_OUTPUT`.
+var envFileWritePattern = regexp.MustCompile(`(>|Out-File|Add-Content|tee).*\$\{?(env:)?(GITHUB_ENV|GITHUB_OUTPUT)\b`)
+
 // DangerousWorkflow retrieves the raw data for the DangerousWorkflow check.
 func DangerousWorkflow(c clients.RepoClient) (checker.DangerousWorkflowData, error) {
 	// data is shared across all GitHub workflows.
@@ -73,8 +109,42 @@ func DangerousWorkflow(c clients.RepoClient) (checker.DangerousWorkflowData, err
 		Pattern:       ".github/workflows/*",
 		CaseSensitive: false,
 	}, validateGitHubActionWorkflowPatterns, &data)
+	if err != nil {
+		return data, err
+	}
 
-	return data, err
+	// Only the runners of public repositories can be used by anyone opening a pull request.
+	if hasDangerousWorkflowType(&data, checker.DangerousWorkflowSelfHostedRunner) {
+		private, err := c.IsPrivate()
+		switch {
+		case errors.Is(err, clients.ErrUnsupportedFeature):
+			// the visibility is unknown, keep the findings.
+		case err != nil:
+			return data, fmt.Errorf("Client.IsPrivate: %w", err)
+		case private:
+			removeDangerousWorkflowType(&data, checker.DangerousWorkflowSelfHostedRunner)
+		}
+	}
+	return data, nil
+}
+
+func hasDangerousWorkflowType(data *checker.DangerousWorkflowData, t checker.DangerousWorkflowType) bool {
+	for i := range data.Workflows {
+		if data.Workflows[i].Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+func removeDangerousWorkflowType(data *checker.DangerousWorkflowData, t checker.DangerousWorkflowType) {
+	workflows := data.Workflows[:0]
+	for i := range data.Workflows {
+		if data.Workflows[i].Type != t {
+			workflows = append(workflows, data.Workflows[i])
+		}
+	}
+	data.Workflows = workflows
 }
 
 // Check file content.
@@ -119,6 +189,18 @@ var validateGitHubActionWorkflowPatterns fileparser.DoWhileTrueOnFileContent = f
 		return false, err
 	}
 
+	// 3. Check for artifacts downloaded from the triggering run and then used by a workflow_run workflow.
+	validateArtifactPoisoning(workflow, path, pdata)
+
+	// 4. Check for caches keyed on untrusted input in privileged workflows.
+	validateCachePoisoning(workflow, path, pdata)
+
+	// 5. Check for writes of untrusted input to GITHUB_ENV or GITHUB_OUTPUT.
+	validateEnvInjection(workflow, path, pdata)
+
+	// 6. Check for jobs reachable from forks running on self-hosted runners.
+	validateSelfHostedRunners(workflow, path, pdata)
+
 	// TODO: Check other dangerous patterns.
 	return true, nil
 }
@@ -271,3 +353,412 @@ func checkVariablesInScript(script string, pos *actionlint.Pos,
 	}
 	return nil
 }
+
+// expressions returns the content of the `${{ }}` expressions in a string.
+func expressions(s string) []string {
+	var ret []string
+	for {
+		start := strings.Index(s, "${{")
+		if start == -1 {
+			return ret
+		}
+		end := strings.Index(s[start:], "}}")
+		if end == -1 {
+			return ret
+		}
+		ret = append(ret, s[start+3:start+end])
+		s = s[start+end:]
+	}
+}
+
+func containsUntrustedExpression(s string) bool {
+	for _, e := range expressions(s) {
+		if containsUntrustedContextPattern(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func usesPrivilegedTrigger(workflow *actionlint.Workflow) bool {
+	for _, t := range privilegedTriggers {
+		if usesEventTrigger(workflow, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func actionInput(e *actionlint.ExecAction, name string) string {
+	input, ok := e.Inputs[name]
+	if !ok || input.Value == nil {
+		return ""
+	}
+	return input.Value.Value
+}
+
+func validateArtifactPoisoning(workflow *actionlint.Workflow, path string,
+	pdata *checker.DangerousWorkflowData,
+) {
+	if !usesEventTrigger(workflow, triggerWorkflowRun) {
+		return
+	}
+
+	for _, job := range workflow.Jobs {
+		if job == nil {
+			continue
+		}
+		var download *actionlint.Step
+		var snippet string
+		for _, step := range job.Steps {
+			if step == nil || step.Exec == nil {
+				continue
+			}
+			if download == nil {
+				if s, ok := downloadsTriggeringRunArtifact(step); ok {
+					download, snippet = step, s
+				}
+				continue
+			}
+			// The artifact is controlled by the triggering run, which may come from a fork:
+			// any later step that executes or reads it runs with the privileges of workflow_run.
+			if !usesArtifactContent(step) {
+				continue
+			}
+			pdata.Workflows = append(pdata.Workflows,
+				checker.DangerousWorkflow{
+					Type: checker.DangerousWorkflowArtifactPoisoning,
+					File: checker.File{
+						Path:    path,
+						Type:    finding.FileTypeSource,
+						Offset:  fileparser.GetLineNumber(download.Pos),
+						Snippet: snippet,
+					},
+					Job: createJob(job),
+				},
+			)
+			break
+		}
+	}
+}
+
+// downloadsTriggeringRunArtifact returns true if a step downloads the artifacts
+// of another workflow run, and a snippet describing the download.
+func downloadsTriggeringRunArtifact(step *actionlint.Step) (string, bool) {
+	switch e := step.Exec.(type) {
+	case *actionlint.ExecAction:
+		if e.Uses == nil {
+			return "", false
+		}
+		switch {
+		// Without a run-id, actions/download-artifact only downloads artifacts of the current run.
+		case strings.Contains(e.Uses.Value, "actions/download-artifact") && actionInput(e, "run-id") != "",
+			strings.Contains(e.Uses.Value, "dawidd6/action-download-artifact"):
+			return e.Uses.Value, true
+		case strings.Contains(e.Uses.Value, "actions/github-script") &&
+			strings.Contains(actionInput(e, "script"), "downloadArtifact"):
+			return e.Uses.Value, true
+		}
+	case *actionlint.ExecRun:
+		if e.Run != nil && strings.Contains(e.Run.Value, "gh run download") {
+			return "gh run download", true
+		}
+	}
+	return "", false
+}
+
+func usesArtifactContent(step *actionlint.Step) bool {
+	switch e := step.Exec.(type) {
+	case *actionlint.ExecRun:
+		return e.Run != nil
+	case *actionlint.ExecAction:
+		if e.Uses == nil {
+			return false
+		}
+		// Local actions may have been overwritten by the artifact.
+		if strings.HasPrefix(e.Uses.Value, "./") {
+			return true
+		}
+		return strings.Contains(e.Uses.Value, "actions/github-script") &&
+			strings.Contains(actionInput(e, "script"), "readFileSync")
+	}
+	return false
+}
+
+func validateCachePoisoning(workflow *actionlint.Workflow, path string,
+	pdata *checker.DangerousWorkflowData,
+) {
+	if !usesPrivilegedTrigger(workflow) {
+		return
+	}
+
+	for _, job := range workflow.Jobs {
+		if job == nil {
+			continue
+		}
+		untrustedCheckout := false
+		for _, step := range job.Steps {
+			if step == nil || step.Exec == nil {
+				continue
+			}
+			e, ok := step.Exec.(*actionlint.ExecAction)
+			if !ok || e.Uses == nil {
+				continue
+			}
+			if strings.Contains(e.Uses.Value, "actions/checkout") {
+				ref := actionInput(e, "ref")
+				if strings.Contains(ref, checkoutUntrustedPullRequestRef) ||
+					strings.Contains(ref, checkoutUntrustedWorkflowRunRef) {
+					untrustedCheckout = true
+				}
+				continue
+			}
+			if !strings.Contains(e.Uses.Value, "actions/cache") {
+				continue
+			}
+			for _, input := range []string{"key", "restore-keys"} {
+				key := actionInput(e, input)
+				// Once untrusted code is checked out, files hashed in the key are controlled by the attacker.
+				if !containsUntrustedExpression(key) &&
+					!(untrustedCheckout && strings.Contains(key, "hashFiles(")) {
+					continue
+				}
+				pdata.Workflows = append(pdata.Workflows,
+					checker.DangerousWorkflow{
+						Type: checker.DangerousWorkflowCachePoisoning,
+						File: checker.File{
+							Path:    path,
+							Type:    finding.FileTypeSource,
+							Offset:  fileparser.GetLineNumber(step.Pos),
+							Snippet: key,
+						},
+						Job: createJob(job),
+					},
+				)
+				break
+			}
+		}
+	}
+}
+
+func validateEnvInjection(workflow *actionlint.Workflow, path string,
+	pdata *checker.DangerousWorkflowData,
+) {
+	if !usesPrivilegedTrigger(workflow) {
+		return
+	}
+
+	workflowEnv := untrustedEnvVars(workflow.Env, nil)
+	for _, job := range workflow.Jobs {
+		if job == nil {
+			continue
+		}
+		jobEnv := untrustedEnvVars(job.Env, workflowEnv)
+		for _, step := range job.Steps {
+			if step == nil {
+				continue
+			}
+			run, ok := step.Exec.(*actionlint.ExecRun)
+			if !ok || run.Run == nil {
+				continue
+			}
+			stepEnv := untrustedEnvVars(step.Env, jobEnv)
+			for i, line := range strings.Split(run.Run.Value, "\n") {
+				if !envFileWritePattern.MatchString(line) {
+					continue
+				}
+				// Untrusted expressions used directly in the script are
+				// reported by validateScriptInjection.
+				if containsUntrustedExpression(line) || !referencesEnvVar(line, stepEnv) {
+					continue
+				}
+				pdata.Workflows = append(pdata.Workflows,
+					checker.DangerousWorkflow{
+						Type: checker.DangerousWorkflowEnvInjection,
+						File: checker.File{
+							Path:    path,
+							Type:    finding.FileTypeSource,
+							Offset:  scriptLineNumber(run.Run, i),
+							Snippet: strings.TrimSpace(line),
+						},
+						Job: createJob(job),
+					},
+				)
+			}
+		}
+	}
+}
+
+// scriptLineNumber returns the line number of the i-th line of a script.
+func scriptLineNumber(script *actionlint.String, i int) uint {
+	line := fileparser.GetLineNumber(script.Pos)
+	if script.Pos == nil {
+		return line
+	}
+	// The content of a block scalar, e.g., `run: |`, starts on the next line.
+	if !script.Quoted && strings.Contains(script.Value, "\n") {
+		line++
+	}
+	return line + uint(i)
+}
+
+// untrustedEnvVars returns the names of the environment variables set to untrusted input,
+// including the ones inherited from the parent scope.
+func untrustedEnvVars(env *actionlint.Env, parent map[string]bool) map[string]bool {
+	ret := make(map[string]bool, len(parent))
+	for k, v := range parent {
+		ret[k] = v
+	}
+	if env == nil {
+		return ret
+	}
+	for _, v := range env.Vars {
+		if v == nil || v.Name == nil {
+			continue
+		}
+		// Variables redefined in a nested scope override the parent ones.
+		// Names are compared case-insensitively, as actionlint lowercases them.
+		ret[strings.ToLower(v.Name.Value)] = v.Value != nil && containsUntrustedExpression(v.Value.Value)
+	}
+	return ret
+}
+
+func referencesEnvVar(line string, vars map[string]bool) bool {
+	line = strings.ToLower(line)
+	for name, untrusted := range vars {
+		if !untrusted {
+			continue
+		}
+		for _, ref := range []string{"$" + name, "${" + name, "$env:" + name} {
+			i := strings.Index(line, ref)
+			if i == -1 {
+				continue
+			}
+			// Do not match variables that only share a prefix, e.g., $TITLE and $TITLE_LENGTH.
+			end := i + len(ref)
+			if end == len(line) || !isEnvVarChar(line[end]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isEnvVarChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+func validateSelfHostedRunners(workflow *actionlint.Workflow, path string,
+	pdata *checker.DangerousWorkflowData,
+) {
+	reachable := false
+	for _, t := range forkTriggers {
+		if usesEventTrigger(workflow, t) {
+			reachable = true
+		}
+	}
+	if !reachable {
+		return
+	}
+
+	for _, job := range workflow.Jobs {
+		if job == nil || job.RunsOn == nil || isGuardedAgainstForks(job) {
+			continue
+		}
+		for _, label := range fileparser.GetRunsOnLabelsForJob(job) {
+			if !isSelfHostedRunnerLabel(label) {
+				continue
+			}
+			pos := job.Pos
+			if len(job.RunsOn.Labels) > 0 && job.RunsOn.Labels[0] != nil {
+				pos = job.RunsOn.Labels[0].Pos
+			}
+			pdata.Workflows = append(pdata.Workflows,
+				checker.DangerousWorkflow{
+					Type: checker.DangerousWorkflowSelfHostedRunner,
+					File: checker.File{
+						Path:    path,
+						Type:    finding.FileTypeSource,
+						Offset:  fileparser.GetLineNumber(pos),
+						Snippet: label,
+					},
+					Job: createJob(job),
+				},
+			)
+			break
+		}
+	}
+}
+
+// isSelfHostedRunnerLabel returns true for the `self-hosted` label and for custom labels.
+// Labels that are expressions cannot be resolved statically and are ignored.
+func isSelfHostedRunnerLabel(label string) bool {
+	if label == "" || strings.Contains(label, "${{") {
+		return false
+	}
+	return !githubHostedRunnerLabel.MatchString(label)
+}
+
+func isGuardedAgainstForks(job *actionlint.Job) bool {
+	if job.If == nil {
+		return false
+	}
+	cond := strings.TrimSpace(job.If.Value)
+	if strings.HasPrefix(cond, "${{") && strings.HasSuffix(cond, "}}") {
+		cond = strings.TrimSuffix(strings.TrimPrefix(cond, "${{"), "}}")
+	}
+	expr, err := actionlint.NewExprParser().Parse(actionlint.NewExprLexer(cond + "}}"))
+	if err != nil {
+		return false
+	}
+	return excludesForks(expr)
+}
+
+// excludesForks returns true if the condition is false for pull requests from forks,
+// i.e., if a fork guard must hold for the condition to hold.
+func excludesForks(expr actionlint.ExprNode) bool {
+	if op, ok := expr.(*actionlint.LogicalOpNode); ok {
+		switch op.Kind {
+		case actionlint.LogicalOpNodeKindAnd:
+			return excludesForks(op.Left) || excludesForks(op.Right)
+		case actionlint.LogicalOpNodeKindOr:
+			return excludesForks(op.Left) && excludesForks(op.Right)
+		default:
+			return false
+		}
+	}
+	return forkGuards[exprString(expr)]
+}
+
+// exprString renders the variables, properties, negations and comparisons of an expression.
+// Other expressions are rendered as an empty string.
+func exprString(expr actionlint.ExprNode) string {
+	switch e := expr.(type) {
+	case *actionlint.VariableNode:
+		return e.Name
+	case *actionlint.ObjectDerefNode:
+		if receiver := exprString(e.Receiver); receiver != "" {
+			return receiver + "." + e.Property
+		}
+	case *actionlint.BoolNode:
+		return strconv.FormatBool(e.Value)
+	case *actionlint.NotOpNode:
+		if operand := exprString(e.Operand); operand != "" {
+			return "!" + operand
+		}
+	case *actionlint.CompareOpNode:
+		left, right := exprString(e.Left), exprString(e.Right)
+		if left == "" || right == "" {
+			return ""
+		}
+		switch e.Kind {
+		case actionlint.CompareOpNodeKindEq:
+			return left + " == " + right
+		case actionlint.CompareOpNodeKindNotEq:
+			return left + " != " + right
+		default:
+			return ""
+		}
+	}
+	return ""
+}
@@ -23,7 +23,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/rhysd/actionlint"
 
+	"github.com/ossf/scorecard/v4/checker"
 	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
 )
 
@@ -96,6 +98,7 @@ func TestGithubDangerousWorkflow(t *testing.T) {
 	tests := []struct {
 		name     string
 		filename string
+		private  bool
 		expected ret
 	}{
 		{
@@ -148,6 +151,52 @@ func TestGithubDangerousWorkflow(t *testing.T) {
 			filename: ".github/workflows/github-workflow-dangerous-pattern-untrusted-script-injection-wildcard.yml",
 			expected: ret{nb: 1},
 		},
+		{
+			name:     "run artifact poisoning",
+			filename: ".github/workflows/github-workflow-dangerous-pattern-artifact-poisoning.yml",
+			expected: ret{nb: 1},
+		},
+		{
+			name:     "run artifact of current run",
+			filename: ".github/workflows/github-workflow-dangerous-pattern-artifact-current-run.yml",
+			expected: ret{nb: 0},
+		},
+		{
+			name:     "run cache poisoning",
+			filename: ".github/workflows/github-workflow-dangerous-pattern-cache-poisoning.yml",
+			expected: ret{nb: 3},
+		},
+		{
+			name:     "run trusted cache",
+			filename: ".github/workflows/github-workflow-dangerous-pattern-trusted-cache.yml",
+			expected: ret{nb: 0},
+		},
+		{
+			name:     "run env injection",
+			filename: ".github/workflows/github-workflow-dangerous-pattern-env-injection.yml",
+			expected: ret{nb: 4},
+		},
+		{
+			name:     "run env injection without privileged trigger",
+			filename: ".github/workflows/github-workflow-dangerous-pattern-env-injection-unprivileged.yml",
+			expected: ret{nb: 0},
+		},
+		{
+			name:     "run self-hosted runner",
+			filename: ".github/workflows/github-workflow-dangerous-pattern-self-hosted-runner.yml",
+			expected: ret{nb: 3},
+		},
+		{
+			name:     "run self-hosted runner in private repository",
+			filename: ".github/workflows/github-workflow-dangerous-pattern-self-hosted-runner.yml",
+			private:  true,
+			expected: ret{nb: 0},
+		},
+		{
+			name:     "run self-hosted runner not reachable from forks",
+			filename: ".github/workflows/github-workflow-dangerous-pattern-self-hosted-runner-push.yml",
+			expected: ret{nb: 0},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -165,6 +214,7 @@ func TestGithubDangerousWorkflow(t *testing.T) {
 				}
 				return content, nil
 			})
+			mockRepoClient.EXPECT().IsPrivate().Return(tt.private, nil).AnyTimes()
 
 			dw, err := DangerousWorkflow(mockRepoClient)
 
@@ -182,3 +232,103 @@ func TestGithubDangerousWorkflow(t *testing.T) {
 		})
 	}
 }
+
+func TestGithubDangerousWorkflowEnvInjectionLines(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Type   checker.DangerousWorkflowType
+		Offset uint
+	}
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return(
+		[]string{".github/workflows/github-workflow-dangerous-pattern-env-injection.yml"}, nil)
+	mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(file string) ([]byte, error) {
+		content, err := os.ReadFile("../testdata/" + file)
+		if err != nil {
+			return content, fmt.Errorf("%w", err)
+		}
+		return content, nil
+	})
+
+	dw, err := DangerousWorkflow(mockRepoClient)
+	if err != nil {
+		t.Fatalf("DangerousWorkflow: %v", err)
+	}
+	var got []result
+	for i := range dw.Workflows {
+		got = append(got, result{Type: dw.Workflows[i].Type, Offset: dw.Workflows[i].File.Offset})
+	}
+	// The untrusted expression used directly on line 33 is only
+	// reported as a script injection, at the start of the script.
+	want := []result{
+		{Type: checker.DangerousWorkflowScriptInjection, Offset: 28},
+		{Type: checker.DangerousWorkflowEnvInjection, Offset: 29},
+		{Type: checker.DangerousWorkflowEnvInjection, Offset: 30},
+		{Type: checker.DangerousWorkflowEnvInjection, Offset: 36},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b result) bool { return a.Offset < b.Offset })); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_isGuardedAgainstForks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		cond     string
+		expected bool
+	}{
+		{
+			name:     "fork check",
+			cond:     "github.event.pull_request.head.repo.fork == false",
+			expected: true,
+		},
+		{
+			name:     "negated fork check in expression",
+			cond:     "${{ !github.event.pull_request.head.repo.fork }}",
+			expected: true,
+		},
+		{
+			name:     "guard in conjunction",
+			cond:     "github.repository == github.event.pull_request.head.repo.full_name && github.actor != 'bot'",
+			expected: true,
+		},
+		{
+			name:     "guard in disjunction",
+			cond:     "github.event.pull_request.head.repo.fork == false || always()",
+			expected: false,
+		},
+		{
+			name:     "guards in disjunction",
+			cond:     "github.event.pull_request.head.repo.fork != true || github.repository == github.event.pull_request.head.repo.full_name", //nolint:lll
+			expected: true,
+		},
+		{
+			name:     "guard compared to another value",
+			cond:     "github.event.pull_request.head.repo.fork == false == false",
+			expected: false,
+		},
+		{
+			name:     "no guard",
+			cond:     "github.actor != 'bot'",
+			expected: false,
+		},
+		{
+			name:     "invalid expression",
+			cond:     "github.event.pull_request.head.repo.fork ==",
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			job := &actionlint.Job{If: &actionlint.String{Value: tt.cond}}
+			if got := isGuardedAgainstForks(job); got != tt.expected {
+				t.Errorf("isGuardedAgainstForks(%q) = %v, want %v", tt.cond, got, tt.expected)
+			}
+		})
+	}
+}
@@ -73,6 +73,8 @@ func Packaging(c *checker.CheckRequest) (checker.PackagingData, error) {
 		if len(runs) > 0 {
 			// Create package.
 			pkg := checker.Package{
+				Registry: match.Registry,
+				Auth:     match.Auth,
 				File: &checker.File{
 					Path:   fp,
 					Type:   finding.FileTypeSource,
@@ -84,6 +86,9 @@ func Packaging(c *checker.CheckRequest) (checker.PackagingData, error) {
 					},
 				},
 			}
+			if match.Name != "" {
+				pkg.Name = &match.Name
+			}
 			// Create runs.
 			for _, run := range runs {
 				pkg.Runs = append(pkg.Runs,
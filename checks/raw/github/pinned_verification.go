@@ -0,0 +1,323 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rhysd/actionlint"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/githubrepo"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+// EnvVarVerifyPinnedActions is the environment variable which enables
+// the verification of the actions pinned by hash.
+const EnvVarVerifyPinnedActions = "SCORECARD_VERIFY_PINNED_ACTIONS"
+
+// maxComparedRefs is the maximum number of branches and tags compared with a pinned commit,
+// as each comparison is an API call.
+const maxComparedRefs = 10
+
+var (
+	errInvalidArgType   = errors.New("invalid arg type")
+	errInvalidArgLength = errors.New("invalid arg length")
+	errTooManyRefs      = errors.New("too many branches and tags")
+)
+
+var (
+	// owner/repo[/path]@sha, e.g., actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab.
+	pinnedActionRegex = regexp.MustCompile(`^([\w.-]+/[\w.-]+)(/[^@]*)?@([a-fA-F\d]{40})$`)
+	// The version comment following a pinned action, e.g., `@<sha> # v3.5.2`.
+	versionCommentRegex = regexp.MustCompile(`@[a-fA-F\d]{40}['"]?\s+#\s*(\S+)`)
+)
+
+// pinnedAction is an action or a reusable workflow pinned by hash.
+type pinnedAction struct {
+	path    string
+	uses    string
+	repo    string
+	sha     string
+	version string
+	line    uint
+}
+
+// repoClientFactory returns a RepoClient for a repository, e.g., owner/repo.
+type repoClientFactory func(ctx context.Context, repo string) (clients.RepoClient, error)
+
+// VerifyPinnedActions verifies that the actions and reusable workflows pinned by hash in
+// the workflows of the repository use commits of the action's repository, and that the
+// version comments following the hashes match the tags' commits.
+// Pinned commits may otherwise belong to a fork, because forks share objects with their parent.
+func VerifyPinnedActions(c *checker.CheckRequest, r *checker.PinningDependenciesData) error {
+	return verifyPinnedActions(c, r, newGitHubRepoClient)
+}
+
+func newGitHubRepoClient(ctx context.Context, repo string) (clients.RepoClient, error) {
+	ghRepo, err := githubrepo.MakeGithubRepo(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error during MakeGithubRepo: %w", err)
+	}
+	client := githubrepo.CreateGithubRepoClient(ctx, log.NewLogger(log.InfoLevel))
+	if err := client.InitRepo(ghRepo, clients.HeadSHA, 0); err != nil {
+		return nil, fmt.Errorf("error during InitRepo: %w", err)
+	}
+	return client, nil
+}
+
+func verifyPinnedActions(c *checker.CheckRequest, r *checker.PinningDependenciesData,
+	newClient repoClientFactory,
+) error {
+	var actions []pinnedAction
+	if err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+		Pattern:       ".github/workflows/*",
+		CaseSensitive: true,
+	}, collectPinnedActions, &actions); err != nil {
+		return err
+	}
+
+	repoClients := make(map[string]clients.RepoClient)
+	failed := make(map[string]bool)
+	defer func() {
+		for _, client := range repoClients {
+			client.Close()
+		}
+	}()
+
+	for i := range actions {
+		action := &actions[i]
+		key := strings.ToLower(action.repo)
+		if failed[key] {
+			continue
+		}
+		client, ok := repoClients[key]
+		if !ok {
+			var err error
+			client, err = newClient(c.Ctx, action.repo)
+			if err != nil {
+				// The repository may have been deleted or made private.
+				failed[key] = true
+				r.Dependencies = append(r.Dependencies, checker.Dependency{
+					Msg: asPointer(fmt.Sprintf("cannot verify the actions of %s: %v", action.repo, err)),
+				})
+				continue
+			}
+			repoClients[key] = client
+		}
+
+		issue, err := verifyPinnedAction(client, action)
+		if err != nil {
+			r.Dependencies = append(r.Dependencies, checker.Dependency{
+				Msg: asPointer(fmt.Sprintf("cannot verify %s: %v", action.uses, err)),
+			})
+			continue
+		}
+		if issue == "" {
+			continue
+		}
+
+		parts := strings.SplitN(action.uses, "@", 2)
+		r.PinVerifications = append(r.PinVerifications, checker.PinVerification{
+			Dependency: checker.Dependency{
+				Location: &checker.File{
+					Path:      action.path,
+					Type:      finding.FileTypeSource,
+					Offset:    action.line,
+					EndOffset: action.line,
+					Snippet:   action.uses,
+				},
+				Name:     asPointer(parts[0]),
+				PinnedAt: asPointer(action.sha),
+				Type:     checker.DependencyUseTypeGHAction,
+			},
+			Issue:   issue,
+			Version: action.version,
+		})
+	}
+	return nil
+}
+
+// verifyPinnedAction returns the issue of a pinned action, if any.
+func verifyPinnedAction(client clients.RepoClient, action *pinnedAction) (checker.PinVerificationIssue, error) {
+	tags, err := client.ListTags()
+	if err != nil {
+		return "", fmt.Errorf("ListTags: %w", err)
+	}
+
+	var versionTag *clients.Tag
+	reachable := false
+	for i := range tags {
+		if strings.EqualFold(tags[i].SHA, action.sha) {
+			reachable = true
+		}
+		if action.version != "" && tags[i].Name == action.version {
+			versionTag = &tags[i]
+		}
+	}
+
+	if !reachable {
+		reachable, err = isReachableFromAnyRef(client, action.sha, tags, versionTag)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	switch {
+	case !reachable:
+		return checker.PinVerificationIssueUnreachableCommit, nil
+	case versionTag != nil && !strings.EqualFold(versionTag.SHA, action.sha):
+		return checker.PinVerificationIssueVersionMismatch, nil
+	default:
+		return "", nil
+	}
+}
+
+// isReachableFromAnyRef returns true if the commit is reachable from a branch or a tag.
+// The refs most likely to contain the commit, i.e., the default branch and the tag of the
+// version comment, are compared first. At most maxComparedRefs refs are compared: if the
+// commit is not reachable from any of them, the commit cannot be verified and errTooManyRefs
+// is returned.
+func isReachableFromAnyRef(client clients.RepoClient, sha string, tags []clients.Tag,
+	versionTag *clients.Tag,
+) (bool, error) {
+	defaultBranch, err := client.GetDefaultBranchName()
+	if err != nil {
+		return false, fmt.Errorf("GetDefaultBranchName: %w", err)
+	}
+	refs := []string{defaultBranch}
+	if versionTag != nil {
+		refs = append(refs, versionTag.Name)
+	}
+	// Commits pinned from release or maintenance branches are not on the default branch.
+	branches, err := client.ListBranchHeads()
+	if err != nil {
+		return false, fmt.Errorf("ListBranchHeads: %w", err)
+	}
+	for _, branch := range branches {
+		if strings.EqualFold(branch.SHA, sha) {
+			return true, nil
+		}
+		if branch.Name != defaultBranch {
+			refs = append(refs, branch.Name)
+		}
+	}
+	for i := range tags {
+		if versionTag == nil || tags[i].Name != versionTag.Name {
+			refs = append(refs, tags[i].Name)
+		}
+	}
+
+	compared := refs
+	if len(compared) > maxComparedRefs {
+		compared = compared[:maxComparedRefs]
+	}
+	for _, ref := range compared {
+		reachable, err := client.IsCommitReachable(sha, ref)
+		if err != nil {
+			return false, fmt.Errorf("IsCommitReachable: %w", err)
+		}
+		if reachable {
+			return true, nil
+		}
+	}
+	if len(compared) < len(refs) {
+		return false, fmt.Errorf("%w: compared %d of %d refs", errTooManyRefs, len(compared), len(refs))
+	}
+	return false, nil
+}
+
+// collectPinnedActions collects the actions and reusable workflows pinned by hash in a workflow.
+var collectPinnedActions fileparser.DoWhileTrueOnFileContent = func(
+	pathfn string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if !fileparser.IsWorkflowFile(pathfn) {
+		return true, nil
+	}
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"collectPinnedActions requires exactly 1 arguments: got %v: %w", len(args), errInvalidArgLength)
+	}
+	actions, ok := args[0].(*[]pinnedAction)
+	if !ok {
+		return false, fmt.Errorf("collectPinnedActions expects arg of type *[]pinnedAction: %w", errInvalidArgType)
+	}
+
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 && workflow == nil {
+		// Malformed workflows are reported by the pinning analysis.
+		return true, nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	add := func(uses *actionlint.String) {
+		if uses == nil {
+			return
+		}
+		m := pinnedActionRegex.FindStringSubmatch(uses.Value)
+		if m == nil {
+			return
+		}
+		action := pinnedAction{
+			path: pathfn,
+			uses: uses.Value,
+			repo: m[1],
+			sha:  m[3],
+			line: uint(uses.Pos.Line),
+		}
+		if uses.Pos.Line > 0 && uses.Pos.Line <= len(lines) {
+			if v := versionCommentRegex.FindStringSubmatch(lines[uses.Pos.Line-1]); v != nil {
+				action.version = v[1]
+			}
+		}
+		*actions = append(*actions, action)
+	}
+
+	// Visit jobs in a deterministic order.
+	jobIDs := make([]string, 0, len(workflow.Jobs))
+	for id := range workflow.Jobs {
+		jobIDs = append(jobIDs, id)
+	}
+	sort.Strings(jobIDs)
+	for _, id := range jobIDs {
+		job := workflow.Jobs[id]
+		if job == nil {
+			continue
+		}
+		if job.WorkflowCall != nil {
+			add(job.WorkflowCall.Uses)
+		}
+		for _, step := range job.Steps {
+			if execAction, ok := step.Exec.(*actionlint.ExecAction); ok && execAction != nil {
+				add(execAction.Uses)
+			}
+		}
+	}
+	return true, nil
+}
+
+func asPointer(s string) *string {
+	return &s
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+const (
+	shaV3     = "8e5e7e5ab8b370d6c329ec480221332ada57f0ab"
+	shaMain   = "3d58c274f17dffee475a5520cbe67f0a882c4dbb"
+	shaFork   = "a81bbbf8298c0fa03ea29cdc473d45769f953675"
+	shaOldTag = "0ad4b8fadaa221de15dcec353f45205ec38ea70b"
+	// shaRelease is only on a maintenance branch.
+	shaRelease = "f43a0e5ff2bd294095638e18286ca9a3d1956744"
+)
+
+var errRepoNotFound = errors.New("repo not found")
+
+func TestVerifyPinnedActions(t *testing.T) {
+	t.Parallel()
+	workflow := `on: push
+jobs:
+  call:
+    uses: octo-org/workflows/.github/workflows/build.yml@` + shaFork + `
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@` + shaV3 + ` # v3
+      - uses: actions/checkout@` + shaMain + `
+      - uses: actions/checkout@` + shaOldTag + ` # v3
+      - uses: actions/setup-go@v4
+      - uses: actions/checkout@` + shaRelease + `
+      - uses: deleted/action@` + shaMain + `
+      - uses: octo-org/busy-action@` + shaFork + `
+`
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return([]string{".github/workflows/ci.yml"}, nil)
+	mockRepoClient.EXPECT().GetFileContent(".github/workflows/ci.yml").Return([]byte(workflow), nil)
+
+	newClient := func(ctx context.Context, repo string) (clients.RepoClient, error) {
+		client := mockrepo.NewMockRepoClient(ctrl)
+		switch repo {
+		case "actions/checkout":
+			client.EXPECT().ListTags().Return([]clients.Tag{
+				{Name: "v3", SHA: shaV3},
+				{Name: "v2", SHA: shaOldTag},
+			}, nil).AnyTimes()
+			client.EXPECT().ListBranchHeads().Return([]clients.BranchHead{
+				{Name: "main", SHA: shaMain},
+				{Name: "releases/v2", SHA: shaV3},
+			}, nil).AnyTimes()
+		case "octo-org/busy-action":
+			var branches []clients.BranchHead
+			for i := 0; i < 2*maxComparedRefs; i++ {
+				branches = append(branches, clients.BranchHead{Name: fmt.Sprintf("feature-%d", i), SHA: shaMain})
+			}
+			client.EXPECT().ListTags().Return(nil, nil).AnyTimes()
+			client.EXPECT().ListBranchHeads().Return(branches, nil).AnyTimes()
+		case "octo-org/workflows":
+			client.EXPECT().ListTags().Return(nil, nil).AnyTimes()
+			client.EXPECT().ListBranchHeads().Return([]clients.BranchHead{{Name: "main", SHA: shaMain}}, nil).AnyTimes()
+		default:
+			return nil, errRepoNotFound
+		}
+		client.EXPECT().GetDefaultBranchName().Return("main", nil).AnyTimes()
+		client.EXPECT().IsCommitReachable(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(sha, ref string) (bool, error) {
+				return (sha == shaMain && ref == "main") || (sha == shaRelease && ref == "releases/v2"), nil
+			}).MaxTimes(maxComparedRefs)
+		client.EXPECT().Close().Return(nil)
+		return client, nil
+	}
+
+	c := checker.CheckRequest{RepoClient: mockRepoClient}
+	var r checker.PinningDependenciesData
+	if err := verifyPinnedActions(&c, &r, newClient); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	type result struct {
+		snippet string
+		issue   checker.PinVerificationIssue
+		version string
+		line    uint
+	}
+	var got []result
+	for _, v := range r.PinVerifications {
+		got = append(got, result{
+			snippet: v.Dependency.Location.Snippet,
+			issue:   v.Issue,
+			version: v.Version,
+			line:    v.Dependency.Location.Offset,
+		})
+	}
+	// Jobs are visited in the order of their names.
+	want := []result{
+		{
+			snippet: "actions/checkout@" + shaOldTag,
+			issue:   checker.PinVerificationIssueVersionMismatch,
+			version: "v3",
+			line:    10,
+		},
+		{
+			snippet: "octo-org/workflows/.github/workflows/build.yml@" + shaFork,
+			issue:   checker.PinVerificationIssueUnreachableCommit,
+			line:    4,
+		},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(result{})); diff != "" {
+		t.Errorf("unexpected verifications (-want +got):\n%s", diff)
+	}
+
+	// The deleted repository and the commit compared with too many refs are reported
+	// as debug messages rather than as unreachable.
+	if len(r.Dependencies) != 2 || r.Dependencies[0].Msg == nil || r.Dependencies[1].Msg == nil {
+		t.Fatalf("expected 2 debug messages, got %v", r.Dependencies)
+	}
+	if msg := *r.Dependencies[1].Msg; !strings.Contains(msg, "octo-org/busy-action") ||
+		!strings.Contains(msg, errTooManyRefs.Error()) {
+		t.Errorf("unexpected debug message: %s", msg)
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var errInvalidCIConfig = errors.New("invalid GitLab CI configuration")
+
+// maxExtendsDepth is the maximum nesting of `extends:` supported by GitLab.
+const maxExtendsDepth = 11
+
+// Top-level keywords of a GitLab CI configuration that do not define jobs.
+// See https://docs.gitlab.com/ee/ci/yaml/#global-keywords.
+var globalKeywords = map[string]bool{
+	"default":       true,
+	"include":       true,
+	"stages":        true,
+	"variables":     true,
+	"workflow":      true,
+	"image":         true,
+	"services":      true,
+	"cache":         true,
+	"before_script": true,
+	"after_script":  true,
+}
+
+var (
+	// Rules conditions that restrict a job to merge requests from the project itself.
+	sameProjectCondition = regexp.MustCompile(
+		`\$?CI_MERGE_REQUEST_SOURCE_PROJECT_(ID|PATH|URL)\s*==\s*\$?CI_(MERGE_REQUEST_)?PROJECT_(ID|PATH|URL)|` +
+			`\$?CI_(MERGE_REQUEST_)?PROJECT_(ID|PATH|URL)\s*==\s*\$?CI_MERGE_REQUEST_SOURCE_PROJECT_(ID|PATH|URL)`)
+	// Rules conditions that match merge requests from forks.
+	forkProjectCondition = regexp.MustCompile(
+		`\$?CI_MERGE_REQUEST_SOURCE_PROJECT_(ID|PATH|URL)\s*!=\s*\$?CI_(MERGE_REQUEST_)?PROJECT_(ID|PATH|URL)|` +
+			`\$?CI_(MERGE_REQUEST_)?PROJECT_(ID|PATH|URL)\s*!=\s*\$?CI_MERGE_REQUEST_SOURCE_PROJECT_(ID|PATH|URL)`)
+	// Rules conditions that match merge request pipelines.
+	mergeRequestCondition = regexp.MustCompile(
+		`CI_PIPELINE_SOURCE\s*==\s*["']merge_request_event["']|` +
+			`\$CI_MERGE_REQUEST_I?ID\b(\s*$|\s*&&|\s*\|\||\s*\)|\s*!=)`)
+)
+
+// ciScriptLine is a line of a `script`, `before_script` or `after_script` section.
+type ciScriptLine struct {
+	Value string
+	Line  uint
+}
+
+// ciRule is an entry of a `rules` section.
+type ciRule struct {
+	If   string
+	When string
+	Line uint
+}
+
+type ciJob struct {
+	Variables map[string]string
+	Name      string
+	// Environment is the name of the environment the job deploys to.
+	Environment string
+	// Secrets lists the names of the secrets and ID tokens the job requests.
+	Secrets []string
+	// Dotenv lists the dotenv reports of the job, whose content is exported to later jobs.
+	Dotenv  []string
+	Scripts []ciScriptLine
+	Rules   []ciRule
+	Only    []string
+	Line    uint
+	Release bool
+	// SASTReport is true if the job produces a SAST report.
+	SASTReport bool
+}
+
+// ciInclude is an entry of an `include` section.
+type ciInclude struct {
+	Template string
+	Line     uint
+}
+
+type ciConfig struct {
+	Variables     map[string]string
+	Jobs          []ciJob
+	WorkflowRules []ciRule
+	// Includes only lists the templates, as other includes are expanded in flattened configurations.
+	Includes []ciInclude
+}
+
+// parseCIConfig parses a flattened GitLab CI configuration, as returned by the CI lint API.
+// In flattened configurations, includes are expanded, but `extends:` are not: they are
+// resolved here from the jobs and hidden jobs of the configuration. `!reference` tags are
+// not resolved.
+func parseCIConfig(content []byte) (*ciConfig, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidCIConfig, err)
+	}
+	config := &ciConfig{
+		Variables: map[string]string{},
+	}
+	if len(root.Content) == 0 {
+		return config, nil
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: expected a mapping", errInvalidCIConfig)
+	}
+
+	var defaultScripts []ciScriptLine
+	var jobs, names []*yaml.Node
+	templates := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if value := doc.Content[i+1]; value.Kind == yaml.MappingNode {
+			templates[doc.Content[i].Value] = value
+		}
+	}
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		switch {
+		case key.Value == "variables":
+			config.Variables = parseVariables(value)
+		case key.Value == "workflow":
+			config.WorkflowRules = parseRules(mappingValue(value, "rules"))
+		case key.Value == "include":
+			config.Includes = parseIncludes(value)
+		case key.Value == "default":
+			defaultScripts = append(defaultScripts, parseScript(mappingValue(value, "before_script"))...)
+			defaultScripts = append(defaultScripts, parseScript(mappingValue(value, "after_script"))...)
+		case key.Value == "before_script", key.Value == "after_script":
+			defaultScripts = append(defaultScripts, parseScript(value)...)
+		case globalKeywords[key.Value], strings.HasPrefix(key.Value, "."):
+			// Hidden jobs are templates.
+		case value.Kind == yaml.MappingNode:
+			jobs = append(jobs, resolveExtends(value, templates, 0))
+			names = append(names, key)
+		}
+	}
+
+	for i, node := range jobs {
+		job := ciJob{
+			Name:      names[i].Value,
+			Line:      uint(names[i].Line),
+			Variables: parseVariables(mappingValue(node, "variables")),
+			Rules:     parseRules(mappingValue(node, "rules")),
+			Release:   mappingValue(node, "release") != nil,
+		}
+		if mappingValue(node, "before_script") == nil && mappingValue(node, "after_script") == nil {
+			job.Scripts = append(job.Scripts, defaultScripts...)
+		}
+		job.Scripts = append(job.Scripts, parseScript(mappingValue(node, "before_script"))...)
+		job.Scripts = append(job.Scripts, parseScript(mappingValue(node, "script"))...)
+		job.Scripts = append(job.Scripts, parseScript(mappingValue(node, "after_script"))...)
+
+		if only := mappingValue(node, "only"); only != nil {
+			if refs := mappingValue(only, "refs"); refs != nil {
+				only = refs
+			}
+			job.Only = scalarValues(only)
+			// An empty `only` still overrides the default of running on branches and tags.
+			if job.Only == nil {
+				job.Only = []string{}
+			}
+		}
+		if env := mappingValue(node, "environment"); env != nil {
+			job.Environment = env.Value
+			if name := mappingValue(env, "name"); name != nil {
+				job.Environment = name.Value
+			}
+		}
+		job.Secrets = append(job.Secrets, mappingKeys(mappingValue(node, "secrets"))...)
+		job.Secrets = append(job.Secrets, mappingKeys(mappingValue(node, "id_tokens"))...)
+		if reports := mappingValue(mappingValue(node, "artifacts"), "reports"); reports != nil {
+			job.Dotenv = scalarValues(mappingValue(reports, "dotenv"))
+			job.SASTReport = mappingValue(reports, "sast") != nil
+		}
+		config.Jobs = append(config.Jobs, job)
+	}
+	return config, nil
+}
+
+// resolveExtends returns the job with the keys inherited from the jobs it extends.
+// See https://docs.gitlab.com/ee/ci/yaml/#extends.
+func resolveExtends(job *yaml.Node, templates map[string]*yaml.Node, depth int) *yaml.Node {
+	extends := mappingValue(job, "extends")
+	if extends == nil || depth >= maxExtendsDepth {
+		return job
+	}
+	merged := &yaml.Node{Kind: yaml.MappingNode}
+	// When a job extends several jobs, the last one takes precedence.
+	for _, name := range scalarValues(extends) {
+		if parent, ok := templates[name]; ok {
+			merged = mergeMappings(merged, resolveExtends(parent, templates, depth+1))
+		}
+	}
+	return mergeMappings(merged, job)
+}
+
+// mergeMappings returns the deep merge of two mapping nodes, the keys of override
+// taking precedence. As in GitLab, only mappings are merged: other values are replaced.
+func mergeMappings(base, override *yaml.Node) *yaml.Node {
+	merged := &yaml.Node{Kind: yaml.MappingNode, Line: override.Line, Column: override.Column}
+	for i := 0; i+1 < len(base.Content); i += 2 {
+		key, value := base.Content[i], base.Content[i+1]
+		if o := mappingValue(override, key.Value); o != nil {
+			if o.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+				value = mergeMappings(value, o)
+			} else {
+				value = o
+			}
+		}
+		merged.Content = append(merged.Content, key, value)
+	}
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key := override.Content[i]
+		if mappingValue(base, key.Value) == nil {
+			merged.Content = append(merged.Content, key, override.Content[i+1])
+		}
+	}
+	return merged
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func mappingKeys(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var keys []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	return keys
+}
+
+// scalarValues returns the value of a scalar node, or the values of a sequence of scalars.
+func scalarValues(node *yaml.Node) []string {
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []string{node.Value}
+	case yaml.SequenceNode:
+		var values []string
+		for _, n := range node.Content {
+			values = append(values, scalarValues(n)...)
+		}
+		return values
+	}
+	return nil
+}
+
+func parseVariables(node *yaml.Node) map[string]string {
+	variables := map[string]string{}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return variables
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+		// Variables can be defined with a description, e.g., `VAR: {value: x, description: y}`.
+		if v := mappingValue(value, "value"); v != nil {
+			value = v
+		}
+		variables[node.Content[i].Value] = value.Value
+	}
+	return variables
+}
+
+func parseIncludes(node *yaml.Node) []ciInclude {
+	if node == nil {
+		return nil
+	}
+	var includes []ciInclude
+	switch node.Kind {
+	case yaml.MappingNode:
+		if v := mappingValue(node, "template"); v != nil {
+			includes = append(includes, ciInclude{Template: v.Value, Line: uint(v.Line)})
+		}
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			includes = append(includes, parseIncludes(n)...)
+		}
+	}
+	return includes
+}
+
+func parseRules(node *yaml.Node) []ciRule {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var rules []ciRule
+	for _, n := range node.Content {
+		rule := ciRule{Line: uint(n.Line)}
+		if v := mappingValue(n, "if"); v != nil {
+			rule.If = v.Value
+		}
+		if v := mappingValue(n, "when"); v != nil {
+			rule.When = v.Value
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// parseScript returns the lines of a script, which can be a string
+// or a list of strings and nested lists.
+func parseScript(node *yaml.Node) []ciScriptLine {
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		var lines []ciScriptLine
+		for i, l := range strings.Split(strings.TrimRight(node.Value, "\n"), "\n") {
+			line := uint(node.Line)
+			// Block scalars start on the line after their indicator.
+			if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+				line += uint(i) + 1
+			}
+			lines = append(lines, ciScriptLine{Value: l, Line: line})
+		}
+		return lines
+	case yaml.SequenceNode:
+		var lines []ciScriptLine
+		for _, n := range node.Content {
+			lines = append(lines, parseScript(n)...)
+		}
+		return lines
+	}
+	return nil
+}
+
+// runsForMergeRequests returns true if a job may run in merge request pipelines
+// of merge requests from forks.
+func (job *ciJob) runsForMergeRequests(workflowRules []ciRule) bool {
+	if job.Only != nil {
+		for _, ref := range job.Only {
+			if ref == "merge_requests" {
+				return true
+			}
+		}
+		return false
+	}
+	// Jobs without conditions run in merge request pipelines if the workflow creates them.
+	workflowCreatesMergeRequestPipelines := mentionsMergeRequests(workflowRules) &&
+		rulesMatchForkMergeRequests(workflowRules, true)
+	if job.Rules != nil {
+		return rulesMatchForkMergeRequests(job.Rules, workflowCreatesMergeRequestPipelines)
+	}
+	return workflowCreatesMergeRequestPipelines
+}
+
+// rulesMatchForkMergeRequests follows the first match semantics of `rules`.
+// matchAll tells whether rules without a condition match merge request pipelines.
+func rulesMatchForkMergeRequests(rules []ciRule, matchAll bool) bool {
+	for _, rule := range rules {
+		if rule.When == "never" {
+			// A rule that excludes forks protects the rules that follow.
+			if forkProjectCondition.MatchString(rule.If) {
+				return false
+			}
+			continue
+		}
+		if sameProjectCondition.MatchString(rule.If) {
+			continue
+		}
+		if rule.If == "" {
+			return matchAll
+		}
+		if isMergeRequestCondition(rule.If) {
+			return true
+		}
+	}
+	return false
+}
+
+func mentionsMergeRequests(rules []ciRule) bool {
+	for _, rule := range rules {
+		if rule.When != "never" && isMergeRequestCondition(rule.If) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMergeRequestCondition(condition string) bool {
+	return mergeRequestCondition.MatchString(condition)
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+var (
+	// Predefined variables whose value may be controlled by the author of a merge request.
+	// See https://docs.gitlab.com/ee/ci/variables/predefined_variables.html.
+	untrustedVariable = regexp.MustCompile(
+		`\$\{?(CI_MERGE_REQUEST_(TITLE|DESCRIPTION|SOURCE_BRANCH_NAME|LABELS)|` +
+			`CI_COMMIT_(MESSAGE|TITLE|DESCRIPTION|BRANCH|REF_NAME|AUTHOR|TAG_MESSAGE)|` +
+			`CI_EXTERNAL_PULL_REQUEST_SOURCE_BRANCH_NAME)\b`)
+	// Commands that interpret their argument as code.
+	evalCommand = regexp.MustCompile(
+		`(^|[;&|(\s])(eval|(ba|z)?sh\s+-c|python3?\s+-c|node\s+-e|ruby\s+-e|perl\s+-e|` +
+			`(pwsh|powershell)\s+-c(ommand)?|Invoke-Expression|iex)(\s|$)`)
+	// User-defined variables that are likely to contain secrets.
+	secretVariable = regexp.MustCompile(
+		`\$\{?([A-Za-z0-9_]*(TOKEN|PASSWORD|PASSWD|SECRET|API_KEY|PRIVATE_KEY|ACCESS_KEY|CREDENTIALS)[A-Za-z0-9_]*)\b`)
+)
+
+// DangerousWorkflow retrieves the raw data for the Dangerous-Workflow check
+// from the GitLab CI configuration.
+func DangerousWorkflow(c *checker.CheckRequest) (checker.DangerousWorkflowData, error) {
+	var data checker.DangerousWorkflowData
+	matchedFiles, err := c.RepoClient.ListFiles(fileparser.IsGitlabWorkflowFile)
+	if err != nil {
+		return data, fmt.Errorf("RepoClient.ListFiles: %w", err)
+	}
+
+	for _, fp := range matchedFiles {
+		fc, err := c.RepoClient.GetFileContent(fp)
+		if err != nil {
+			return data, fmt.Errorf("RepoClient.GetFileContent: %w", err)
+		}
+		config, err := parseCIConfig(fc)
+		if err != nil {
+			return data, err
+		}
+		if len(config.Jobs) == 0 {
+			continue
+		}
+		data.NumWorkflows++
+		for i := range config.Jobs {
+			validateJob(&config.Jobs[i], config, fp, &data)
+		}
+	}
+
+	return data, nil
+}
+
+func validateJob(job *ciJob, config *ciConfig, path string, data *checker.DangerousWorkflowData) {
+	add := func(t checker.DangerousWorkflowType, line uint, snippet string) {
+		data.Workflows = append(data.Workflows, checker.DangerousWorkflow{
+			Type: t,
+			File: checker.File{
+				Path:    path,
+				Type:    finding.FileTypeSource,
+				Offset:  line,
+				Snippet: snippet,
+			},
+			Job: &checker.WorkflowJob{
+				Name: StringPointer(job.Name),
+				ID:   StringPointer(job.Name),
+			},
+		})
+	}
+
+	// Variables defined in the configuration are expanded by GitLab,
+	// so they are untrusted if their value is.
+	untrusted := untrustedVariables(config.Variables, job.Variables)
+
+	// 1. Secrets exposed to the code of merge requests from forks.
+	if job.runsForMergeRequests(config.WorkflowRules) {
+		if secret := exposedSecret(job); secret != "" {
+			add(checker.DangerousWorkflowSecretExposure, job.Line, secret)
+		}
+	}
+
+	for _, line := range job.Scripts {
+		refs := untrustedReferences(line.Value, untrusted)
+		if len(refs) == 0 {
+			continue
+		}
+		// 2. Untrusted input interpreted as code.
+		if evalCommand.MatchString(line.Value) {
+			add(checker.DangerousWorkflowScriptInjection, line.Line, refs[0])
+		}
+		// 3. Untrusted input exported to later jobs through dotenv reports.
+		for _, dotenv := range job.Dotenv {
+			if strings.Contains(line.Value, ">") && strings.Contains(line.Value, dotenv) {
+				add(checker.DangerousWorkflowEnvInjection, line.Line, strings.TrimSpace(line.Value))
+				break
+			}
+		}
+	}
+}
+
+// exposedSecret returns the first secret a job has access to, or an empty string.
+func exposedSecret(job *ciJob) string {
+	if len(job.Secrets) > 0 {
+		return job.Secrets[0]
+	}
+	// Protected environments can define their own variables.
+	if job.Environment != "" {
+		return "environment " + job.Environment
+	}
+	for _, line := range job.Scripts {
+		for _, m := range secretVariable.FindAllStringSubmatch(line.Value, -1) {
+			// Predefined variables, e.g., CI_JOB_TOKEN, are available to all pipelines.
+			if !strings.HasPrefix(m[1], "CI_") {
+				return "$" + m[1]
+			}
+		}
+	}
+	return ""
+}
+
+func untrustedVariables(scopes ...map[string]string) map[string]bool {
+	ret := map[string]bool{}
+	for _, variables := range scopes {
+		for name, value := range variables {
+			ret[name] = untrustedVariable.MatchString(value)
+		}
+	}
+	return ret
+}
+
+// untrustedReferences returns the untrusted variables referenced in a script line.
+func untrustedReferences(line string, untrusted map[string]bool) []string {
+	refs := untrustedVariable.FindAllString(line, -1)
+	var names []string
+	for name, isUntrusted := range untrusted {
+		if isUntrusted {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, ref := range []string{"$" + name, "${" + name} {
+			i := strings.Index(line, ref)
+			end := i + len(ref)
+			if i != -1 && (end == len(line) || !isVariableChar(line[end])) {
+				refs = append(refs, ref)
+				break
+			}
+		}
+	}
+	return refs
+}
+
+func isVariableChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
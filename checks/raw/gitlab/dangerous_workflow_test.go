@@ -0,0 +1,193 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+type dangerousWorkflowResult struct {
+	Type    checker.DangerousWorkflowType
+	Job     string
+	Snippet string
+	Line    uint
+}
+
+func TestGitlabDangerousWorkflow(t *testing.T) {
+	t.Parallel()
+
+	//nolint:govet
+	tests := []struct {
+		name         string
+		filename     string
+		numWorkflows int
+		want         []dangerousWorkflowResult
+	}{
+		{
+			name:         "dangerous patterns",
+			filename:     "./testdata/dangerous-workflow.yaml",
+			numWorkflows: 1,
+			want: []dangerousWorkflowResult{
+				{Type: checker.DangerousWorkflowSecretExposure, Job: "test", Snippet: "$NPM_TOKEN", Line: 10},
+				{Type: checker.DangerousWorkflowSecretExposure, Job: "deploy", Snippet: "environment review/$CI_COMMIT_REF_SLUG", Line: 23},
+				{Type: checker.DangerousWorkflowScriptInjection, Job: "title", Snippet: "$MR_TITLE", Line: 37},
+				{
+					Type:    checker.DangerousWorkflowEnvInjection,
+					Job:     "title",
+					Snippet: `echo "TITLE=$CI_COMMIT_TITLE" >> build.env`,
+					Line:    38,
+				},
+			},
+		},
+		{
+			name:         "scripts inherited through extends",
+			filename:     "./testdata/extends.yaml",
+			numWorkflows: 1,
+			want: []dangerousWorkflowResult{
+				{Type: checker.DangerousWorkflowSecretExposure, Job: "publish", Snippet: "$NPM_TOKEN", Line: 15},
+				{Type: checker.DangerousWorkflowScriptInjection, Job: "title", Snippet: "$CI_MERGE_REQUEST_TITLE", Line: 13},
+			},
+		},
+		{
+			name:         "safe patterns",
+			filename:     "./testdata/safe-workflow.yaml",
+			numWorkflows: 1,
+		},
+		{
+			name:     "no jobs",
+			filename: "./testdata/empty.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return([]string{tt.filename}, nil)
+			mockRepoClient.EXPECT().GetFileContent(tt.filename).DoAndReturn(os.ReadFile)
+
+			data, err := DangerousWorkflow(&checker.CheckRequest{RepoClient: mockRepoClient})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if data.NumWorkflows != tt.numWorkflows {
+				t.Errorf("NumWorkflows = %d, want %d", data.NumWorkflows, tt.numWorkflows)
+			}
+			var got []dangerousWorkflowResult
+			for _, w := range data.Workflows {
+				got = append(got, dangerousWorkflowResult{
+					Type:    w.Type,
+					Job:     *w.Job.Name,
+					Snippet: w.File.Snippet,
+					Line:    w.File.Offset,
+				})
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunsForMergeRequests(t *testing.T) {
+	t.Parallel()
+	mergeRequests := []ciRule{{If: `$CI_PIPELINE_SOURCE == "merge_request_event"`}}
+	//nolint:govet
+	tests := []struct {
+		name          string
+		job           ciJob
+		workflowRules []ciRule
+		want          bool
+	}{
+		{
+			name: "default",
+			want: false,
+		},
+		{
+			name:          "default with merge request pipelines",
+			workflowRules: mergeRequests,
+			want:          true,
+		},
+		{
+			name: "only merge requests",
+			job:  ciJob{Only: []string{"merge_requests"}},
+			want: true,
+		},
+		{
+			name: "only branches",
+			job:  ciJob{Only: []string{"branches"}},
+			want: false,
+		},
+		{
+			name: "rules merge requests",
+			job:  ciJob{Rules: mergeRequests},
+			want: true,
+		},
+		{
+			name: "rules not merge requests",
+			job:  ciJob{Rules: []ciRule{{If: `$CI_PIPELINE_SOURCE != "merge_request_event"`}}},
+			want: false,
+		},
+		{
+			name: "rules merge request iid",
+			job:  ciJob{Rules: []ciRule{{If: `$CI_MERGE_REQUEST_IID`}}},
+			want: true,
+		},
+		{
+			name: "rules same project",
+			job: ciJob{Rules: []ciRule{
+				{If: `$CI_MERGE_REQUEST_SOURCE_PROJECT_ID == $CI_PROJECT_ID`},
+			}},
+			want: false,
+		},
+		{
+			name: "rules without condition",
+			job:  ciJob{Rules: []ciRule{{When: "manual"}}},
+			want: false,
+		},
+		{
+			name:          "rules without condition with merge request pipelines",
+			job:           ciJob{Rules: []ciRule{{When: "manual"}}},
+			workflowRules: mergeRequests,
+			want:          true,
+		},
+		{
+			name: "rules excluding forks",
+			job: ciJob{Rules: []ciRule{
+				{If: `$CI_MERGE_REQUEST_SOURCE_PROJECT_PATH != $CI_PROJECT_PATH`, When: "never"},
+				{If: `$CI_PIPELINE_SOURCE == "merge_request_event"`},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.job.runsForMergeRequests(tt.workflowRules); got != tt.want {
+				t.Errorf("runsForMergeRequests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
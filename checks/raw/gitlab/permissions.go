@@ -0,0 +1,161 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// jobTokenScoper is implemented by the GitLab client.
+type jobTokenScoper interface {
+	JobTokenScopeEnabled() (bool, error)
+}
+
+var jobToken = regexp.MustCompile(`\$\{?CI_JOB_TOKEN\b`)
+
+// Commands that use the CI_JOB_TOKEN to write to the project, by permission.
+var jobTokenWrites = []struct {
+	command    *regexp.Regexp
+	permission string
+}{
+	{regexp.MustCompile(`\bgit\s+push\b`), "contents"},
+	{regexp.MustCompile(`\brelease-cli\b`), "contents"},
+	{regexp.MustCompile(`\b(docker|podman|buildah)\s+push\b|\b(crane|skopeo)\s+(push|copy)\b`), "packages"},
+	{regexp.MustCompile(`\b(npm|yarn|twine|gem)\s+(publish|upload|push)\b|\bmvn\b.*\bdeploy\b`), "packages"},
+	{regexp.MustCompile(`(-X\s*|--request\s+)(PUT|POST).*/packages/|--upload-file\b`), "packages"},
+}
+
+// TokenPermissions retrieves the raw data for the Token-Permissions check
+// from the GitLab CI configuration.
+func TokenPermissions(c *checker.CheckRequest) (checker.TokenPermissionsData, error) {
+	var data checker.TokenPermissionsData
+	matchedFiles, err := c.RepoClient.ListFiles(fileparser.IsGitlabWorkflowFile)
+	if err != nil {
+		return data, fmt.Errorf("RepoClient.ListFiles: %w", err)
+	}
+
+	for _, fp := range matchedFiles {
+		fc, err := c.RepoClient.GetFileContent(fp)
+		if err != nil {
+			return data, fmt.Errorf("RepoClient.GetFileContent: %w", err)
+		}
+		config, err := parseCIConfig(fc)
+		if err != nil {
+			return data, err
+		}
+		if len(config.Jobs) == 0 {
+			continue
+		}
+		data.NumTokens++
+
+		// 1. The scope of the CI_JOB_TOKEN is a project setting that applies to all jobs.
+		data.TokenPermissions = append(data.TokenPermissions, jobTokenScopePermission(c, fp))
+
+		// 2. Jobs that use the CI_JOB_TOKEN to write to the project.
+		for i := range config.Jobs {
+			data.TokenPermissions = append(data.TokenPermissions, jobTokenWritePermissions(&config.Jobs[i], fp)...)
+		}
+	}
+
+	return data, nil
+}
+
+func jobTokenScopePermission(c *checker.CheckRequest, path string) checker.TokenPermission {
+	locationType := checker.PermissionLocationTop
+	p := checker.TokenPermission{
+		LocationType: &locationType,
+		File: &checker.File{
+			Path:   path,
+			Type:   finding.FileTypeSource,
+			Offset: checker.OffsetDefault,
+		},
+	}
+
+	scoper, ok := c.RepoClient.(jobTokenScoper)
+	if !ok {
+		p.Type = checker.PermissionLevelUnknown
+		p.Msg = StringPointer("CI_JOB_TOKEN scope cannot be determined")
+		return p
+	}
+	enabled, err := scoper.JobTokenScopeEnabled()
+	switch {
+	case err != nil:
+		p.Type = checker.PermissionLevelUnknown
+		p.Msg = StringPointer(fmt.Sprintf("CI_JOB_TOKEN scope cannot be determined: %v", err))
+	case enabled:
+		p.Type = checker.PermissionLevelRead
+		p.Msg = StringPointer("CI_JOB_TOKEN access is limited to allowlisted projects")
+	default:
+		// Like a workflow without top-level permissions on GitHub.
+		p.Type = checker.PermissionLevelUndeclared
+		p.Msg = StringPointer("CI_JOB_TOKEN access is not limited to allowlisted projects")
+	}
+	return p
+}
+
+func jobTokenWritePermissions(job *ciJob, path string) []checker.TokenPermission {
+	var ret []checker.TokenPermission
+	seen := map[string]bool{}
+	add := func(permission string, line uint, snippet string) {
+		if seen[permission] {
+			return
+		}
+		seen[permission] = true
+		locationType := checker.PermissionLocationJob
+		ret = append(ret, checker.TokenPermission{
+			LocationType: &locationType,
+			Name:         StringPointer(permission),
+			Value:        StringPointer("write"),
+			Type:         checker.PermissionLevelWrite,
+			File: &checker.File{
+				Path:    path,
+				Type:    finding.FileTypeSource,
+				Offset:  line,
+				Snippet: snippet,
+			},
+			Job: &checker.WorkflowJob{
+				Name: StringPointer(job.Name),
+				ID:   StringPointer(job.Name),
+			},
+		})
+	}
+
+	// The release keyword uses the CI_JOB_TOKEN to create a release.
+	if job.Release {
+		add("contents", job.Line, "release")
+	}
+	usesToken := false
+	for _, line := range job.Scripts {
+		if jobToken.MatchString(line.Value) {
+			usesToken = true
+		}
+		// Commands that write often use credentials set up on a previous line,
+		// e.g., `docker login -u gitlab-ci-token -p $CI_JOB_TOKEN`.
+		if !usesToken {
+			continue
+		}
+		for _, w := range jobTokenWrites {
+			if w.command.MatchString(line.Value) {
+				add(w.permission, line.Line, line.Value)
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+var errProjectSettings = errors.New("project settings unavailable")
+
+// scopedRepoClient is a RepoClient that reports the scope of the CI_JOB_TOKEN.
+type scopedRepoClient struct {
+	clients.RepoClient
+	err     error
+	enabled bool
+}
+
+func (c *scopedRepoClient) JobTokenScopeEnabled() (bool, error) {
+	return c.enabled, c.err
+}
+
+type tokenPermissionResult struct {
+	Name     string
+	Job      string
+	Location checker.PermissionLocation
+	Type     checker.PermissionLevel
+	Line     uint
+}
+
+func TestGitlabTokenPermissions(t *testing.T) {
+	t.Parallel()
+
+	//nolint:govet
+	tests := []struct {
+		name      string
+		filename  string
+		scoped    bool
+		noScope   bool
+		scopeErr  error
+		numTokens int
+		want      []tokenPermissionResult
+	}{
+		{
+			name:      "unscoped token used for writes",
+			filename:  "./testdata/job-token.yaml",
+			numTokens: 1,
+			want: []tokenPermissionResult{
+				{Location: checker.PermissionLocationTop, Type: checker.PermissionLevelUndeclared},
+				{
+					Name:     "packages",
+					Job:      "publish",
+					Location: checker.PermissionLocationJob,
+					Type:     checker.PermissionLevelWrite,
+					Line:     8,
+				},
+				{
+					Name:     "contents",
+					Job:      "release",
+					Location: checker.PermissionLocationJob,
+					Type:     checker.PermissionLevelWrite,
+					Line:     11,
+				},
+			},
+		},
+		{
+			name:      "scoped token",
+			filename:  "./testdata/safe-workflow.yaml",
+			scoped:    true,
+			numTokens: 1,
+			want: []tokenPermissionResult{
+				{Location: checker.PermissionLocationTop, Type: checker.PermissionLevelRead},
+			},
+		},
+		{
+			name:      "unknown scope",
+			filename:  "./testdata/safe-workflow.yaml",
+			scopeErr:  errProjectSettings,
+			numTokens: 1,
+			want: []tokenPermissionResult{
+				{Location: checker.PermissionLocationTop, Type: checker.PermissionLevelUnknown},
+			},
+		},
+		{
+			name:      "client without scope",
+			filename:  "./testdata/safe-workflow.yaml",
+			noScope:   true,
+			numTokens: 1,
+			want: []tokenPermissionResult{
+				{Location: checker.PermissionLocationTop, Type: checker.PermissionLevelUnknown},
+			},
+		},
+		{
+			name:     "no jobs",
+			filename: "./testdata/empty.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return([]string{tt.filename}, nil)
+			mockRepoClient.EXPECT().GetFileContent(tt.filename).DoAndReturn(os.ReadFile)
+
+			var repoClient clients.RepoClient = &scopedRepoClient{
+				RepoClient: mockRepoClient,
+				enabled:    tt.scoped,
+				err:        tt.scopeErr,
+			}
+			if tt.noScope {
+				repoClient = mockRepoClient
+			}
+
+			data, err := TokenPermissions(&checker.CheckRequest{RepoClient: repoClient})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if data.NumTokens != tt.numTokens {
+				t.Errorf("NumTokens = %d, want %d", data.NumTokens, tt.numTokens)
+			}
+			var got []tokenPermissionResult
+			for _, p := range data.TokenPermissions {
+				r := tokenPermissionResult{
+					Location: *p.LocationType,
+					Type:     p.Type,
+				}
+				if p.Name != nil {
+					r.Name = *p.Name
+				}
+				if p.Job != nil {
+					r.Job = *p.Job.Name
+				}
+				if *p.LocationType == checker.PermissionLocationJob {
+					r.Line = p.File.Offset
+				}
+				got = append(got, r)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
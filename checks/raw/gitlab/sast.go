@@ -0,0 +1,113 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Templates that define SAST jobs.
+// See https://docs.gitlab.com/ee/user/application_security/sast/#configure-sast-in-your-cicd-yaml.
+var sastTemplate = regexp.MustCompile(`(^|/)(SAST(\.latest)?|Auto-DevOps)\.gitlab-ci\.yml$`)
+
+var (
+	branchCondition   = regexp.MustCompile(`\$CI_COMMIT_BRANCH\b|CI_PIPELINE_SOURCE\s*==\s*["']push["']`)
+	scheduleCondition = regexp.MustCompile(`CI_PIPELINE_SOURCE\s*==\s*["']schedule["']`)
+)
+
+// SASTJob is a job of a GitLab CI configuration that runs a SAST analyzer,
+// or an included template that defines such jobs.
+type SASTJob struct {
+	// Name is the name of the job, or the path of the template.
+	Name string
+	Line uint
+	// Template is true if the job is an included template.
+	Template bool
+	// MergeRequests, Branches and Schedules tell which pipelines run the job.
+	MergeRequests bool
+	Branches      bool
+	Schedules     bool
+}
+
+// SASTJobs returns the SAST jobs of a GitLab CI configuration.
+func SASTJobs(content []byte) ([]SASTJob, error) {
+	config, err := parseCIConfig(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []SASTJob
+	for _, include := range config.Includes {
+		if !sastTemplate.MatchString(include.Template) {
+			continue
+		}
+		// The analyzers of the template run in branch and merge request pipelines.
+		jobs = append(jobs, SASTJob{
+			Name:          include.Template,
+			Line:          include.Line,
+			Template:      true,
+			MergeRequests: true,
+			Branches:      true,
+		})
+	}
+	for i := range config.Jobs {
+		job := &config.Jobs[i]
+		if !job.SASTReport && job.Name != "sast" && !strings.HasSuffix(job.Name, "-sast") {
+			continue
+		}
+		sastJob := SASTJob{
+			Name: job.Name,
+			Line: job.Line,
+		}
+		sastJob.MergeRequests, sastJob.Branches, sastJob.Schedules = job.pipelines(config.WorkflowRules)
+		jobs = append(jobs, sastJob)
+	}
+	return jobs, nil
+}
+
+// pipelines tells whether a job runs in merge request, branch and scheduled pipelines.
+func (job *ciJob) pipelines(workflowRules []ciRule) (mergeRequests, branches, schedules bool) {
+	if job.Only != nil {
+		for _, ref := range job.Only {
+			switch ref {
+			case "merge_requests":
+				mergeRequests = true
+			case "schedules":
+				schedules = true
+			case "tags", "api", "external", "pipelines", "pushes", "triggers", "web":
+			default:
+				branches = true
+			}
+		}
+		return mergeRequests, branches, schedules
+	}
+	if job.Rules == nil {
+		// Jobs without conditions run in all the pipelines the workflow creates.
+		return mentionsMergeRequests(workflowRules), true, true
+	}
+	for _, rule := range job.Rules {
+		if rule.When == "never" {
+			continue
+		}
+		if rule.If == "" {
+			return true, true, true
+		}
+		mergeRequests = mergeRequests || isMergeRequestCondition(rule.If)
+		branches = branches || branchCondition.MatchString(rule.If)
+		schedules = schedules || scheduleCondition.MatchString(rule.If)
+	}
+	return mergeRequests, branches, schedules
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSASTJobs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		filename string
+		want     []SASTJob
+	}{
+		{
+			name:     "sast jobs",
+			filename: "./testdata/sast.yaml",
+			want: []SASTJob{
+				{Name: "Jobs/SAST.gitlab-ci.yml", Line: 2, Template: true, MergeRequests: true, Branches: true},
+				{Name: "gosec-sast", Line: 7, Branches: true, Schedules: true},
+				{Name: "nightly-scan", Line: 14, Schedules: true},
+			},
+		},
+		{
+			name:     "no sast jobs",
+			filename: "./testdata/dangerous-workflow.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile(tt.filename)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			got, err := SASTJobs(content)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
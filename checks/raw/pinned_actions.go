@@ -0,0 +1,234 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/rhysd/actionlint"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+)
+
+// localActionsPattern matches the conventional location of local actions.
+const localActionsPattern = ".github/actions/*/action.y*ml"
+
+func isActionMetadataFile(pathfn string) (bool, error) {
+	if strings.HasPrefix(pathfn, "node_modules/") || strings.Contains(pathfn, "/node_modules/") {
+		return false, nil
+	}
+	base := path.Base(pathfn)
+	return base == "action.yml" || base == "action.yaml", nil
+}
+
+// collectGitHubCompositeActionPinning checks the pinning of actions and container images
+// used by local actions. It analyzes the actions in .github/actions and the local actions
+// used by workflows, and recursively the local actions they use.
+func collectGitHubCompositeActionPinning(c *checker.CheckRequest, r *checker.PinningDependenciesData) error {
+	files, err := c.RepoClient.ListFiles(isActionMetadataFile)
+	if err != nil {
+		return fmt.Errorf("RepoClient.ListFiles: %w", err)
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	sort.Strings(files)
+	actions := make(map[string]bool, len(files))
+	var queue []string
+	for _, f := range files {
+		actions[f] = true
+		if match, _ := path.Match(localActionsPattern, f); match {
+			queue = append(queue, f)
+		}
+	}
+
+	var refs []string
+	if err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+		Pattern:       ".github/workflows/*",
+		CaseSensitive: true,
+	}, collectLocalActionReferences, &refs); err != nil {
+		return err
+	}
+	queue = append(queue, resolveLocalActions(refs, actions)...)
+
+	visited := make(map[string]bool)
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		if visited[f] {
+			continue
+		}
+		visited[f] = true
+
+		content, err := c.RepoClient.GetFileContent(f)
+		if err != nil {
+			return fmt.Errorf("RepoClient.GetFileContent: %w", err)
+		}
+		queue = append(queue, resolveLocalActions(validateActionMetadata(f, content, r), actions)...)
+	}
+	return nil
+}
+
+// collectLocalActionReferences collects the local actions, e.g., ./.github/actions/setup,
+// used by the steps of a workflow.
+var collectLocalActionReferences fileparser.DoWhileTrueOnFileContent = func(
+	pathfn string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if !fileparser.IsWorkflowFile(pathfn) {
+		return true, nil
+	}
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"collectLocalActionReferences requires exactly 1 arguments: got %v: %w", len(args), errInvalidArgLength)
+	}
+	refs, ok := args[0].(*[]string)
+	if !ok {
+		return false, fmt.Errorf("collectLocalActionReferences expects arg of type *[]string: %w", errInvalidArgType)
+	}
+
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 && workflow == nil {
+		// Malformed workflows are reported by validateGitHubActionWorkflow.
+		return true, nil
+	}
+	for _, job := range workflow.Jobs {
+		if job == nil {
+			continue
+		}
+		for _, step := range job.Steps {
+			execAction, ok := step.Exec.(*actionlint.ExecAction)
+			if !ok || execAction == nil || execAction.Uses == nil {
+				continue
+			}
+			if strings.HasPrefix(execAction.Uses.Value, "./") {
+				*refs = append(*refs, execAction.Uses.Value)
+			}
+		}
+	}
+	return true, nil
+}
+
+// resolveLocalActions returns the metadata files of local actions. Local actions are
+// referenced relative to the root of the repository.
+func resolveLocalActions(refs []string, actions map[string]bool) []string {
+	var files []string
+	for _, ref := range refs {
+		dir := path.Clean(strings.TrimPrefix(ref, "./"))
+		for _, name := range []string{"action.yml", "action.yaml"} {
+			if f := path.Join(dir, name); actions[f] {
+				files = append(files, f)
+				break
+			}
+		}
+	}
+	return files
+}
+
+// validateActionMetadata checks the pinning of the actions used by the steps of a composite action,
+// the downloads and installs of their scripts, and the image of a Docker container action.
+// It returns the local actions used by the steps.
+func validateActionMetadata(pathfn string, content []byte, pdata *checker.PinningDependenciesData) []string {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		pdata.Dependencies = append(pdata.Dependencies, checker.Dependency{
+			Msg: asPointer(fmt.Sprintf("%s: %v", pathfn, err)),
+		})
+		return nil
+	}
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	runs := yamlMappingValue(root.Content[0], "runs")
+	using := yamlMappingValue(runs, "using")
+	if using == nil {
+		return nil
+	}
+
+	var refs []string
+	switch using.Value {
+	case "composite":
+		steps := yamlMappingValue(runs, "steps")
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			return nil
+		}
+		taintedFiles := make(map[string]bool)
+		for _, step := range steps.Content {
+			validateActionRunStep(pathfn, step, taintedFiles, pdata)
+			uses := yamlMappingValue(step, "uses")
+			if uses == nil || uses.Kind != yaml.ScalarNode {
+				continue
+			}
+			if strings.HasPrefix(uses.Value, "./") {
+				refs = append(refs, uses.Value)
+				continue
+			}
+			addActionDependency(pathfn, uses.Value, uses.Line, pdata)
+		}
+	case "docker":
+		// Images may also be a Dockerfile in the action's directory, which is analyzed separately.
+		image := yamlMappingValue(runs, "image")
+		if image != nil && strings.HasPrefix(image.Value, dockerActionPrefix) {
+			addContainerImageDependency(pathfn, strings.TrimPrefix(image.Value, dockerActionPrefix), image.Line, pdata)
+		}
+	}
+	return refs
+}
+
+// validateActionRunStep checks the script of a `run` step of a composite action.
+// Unlike in workflows, composite actions must set the shell of their `run` steps.
+func validateActionRunStep(pathfn string, step *yaml.Node, taintedFiles map[string]bool,
+	pdata *checker.PinningDependenciesData,
+) {
+	run := yamlMappingValue(step, "run")
+	shell := yamlMappingValue(step, "shell")
+	if run == nil || run.Kind != yaml.ScalarNode || shell == nil || shell.Kind != yaml.ScalarNode {
+		return
+	}
+
+	script := redactGitHubExpressions(run.Value)
+	if ws, ok := windowsShellForName(shell.Value); ok {
+		validateWindowsScript(pathfn, uint(run.Line), script, ws, taintedFiles, pdata)
+		return
+	}
+	if !isSupportedShell(shell.Value) {
+		return
+	}
+	if err := validateShellFile(pathfn, uint(run.Line), uint(run.Line),
+		script, taintedFiles, pdata); err != nil {
+		pdata.Dependencies = append(pdata.Dependencies, checker.Dependency{
+			Msg: asPointer(err.Error()),
+		})
+	}
+}
+
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
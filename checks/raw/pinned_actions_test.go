@@ -0,0 +1,204 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func TestCollectGitHubCompositeActionPinning(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		files    map[string]string
+		unpinned []string
+		debug    int
+	}{
+		{
+			name: "composite action",
+			files: map[string]string{
+				".github/actions/setup/action.yml": `name: Setup
+runs:
+  using: composite
+  steps:
+    - uses: actions/setup-go@v4
+    - uses: actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab
+    - uses: docker://alpine:3.8
+    - run: echo hello
+      shell: bash
+`,
+			},
+			unpinned: []string{
+				".github/actions/setup/action.yml:5:GitHubAction:actions/setup-go@v4",
+				".github/actions/setup/action.yml:7:containerImage:alpine@3.8",
+			},
+		},
+		{
+			name: "composite action scripts",
+			files: map[string]string{
+				".github/actions/install/action.yml": `name: Install
+runs:
+  using: composite
+  steps:
+    - run: |
+        pip install requests
+        curl -sSL https://example.com/install.sh | bash
+      shell: bash
+    - run: Install-Module -Name PSReadLine
+      shell: pwsh
+    - run: npm install ${{ inputs.package }}
+    - run: print("hello")
+      shell: python
+`,
+			},
+			unpinned: []string{
+				".github/actions/install/action.yml:6:pipCommand",
+				".github/actions/install/action.yml:7:downloadThenRun",
+				".github/actions/install/action.yml:10:powershellCommand",
+			},
+		},
+		{
+			name: "docker action",
+			files: map[string]string{
+				".github/actions/lint/action.yaml": `name: Lint
+runs:
+  using: docker
+  image: docker://ghcr.io/owner/linter:latest
+`,
+				".github/actions/build/action.yml": `name: Build
+runs:
+  using: docker
+  image: Dockerfile
+`,
+			},
+			unpinned: []string{
+				".github/actions/lint/action.yaml:4:containerImage:ghcr.io/owner/linter@latest",
+			},
+		},
+		{
+			name: "local actions used by workflows and actions",
+			files: map[string]string{
+				".github/workflows/ci.yml": `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: ./tools/actions/outer
+`,
+				"tools/actions/outer/action.yml": `runs:
+  using: composite
+  steps:
+    - uses: ./tools/actions/inner
+    - uses: ./tools/actions/outer
+`,
+				"tools/actions/inner/action.yml": `runs:
+  using: composite
+  steps:
+    - uses: actions/cache@v3
+`,
+				"tools/actions/unused/action.yml": `runs:
+  using: composite
+  steps:
+    - uses: actions/cache@v3
+`,
+			},
+			unpinned: []string{
+				"tools/actions/inner/action.yml:4:GitHubAction:actions/cache@v3",
+			},
+		},
+		{
+			name: "malformed action",
+			files: map[string]string{
+				".github/actions/broken/action.yml": "runs: [",
+			},
+			debug: 1,
+		},
+		{
+			name: "javascript action",
+			files: map[string]string{
+				"action.yml": `runs:
+  using: node16
+  main: index.js
+`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var files []string
+					for f := range tt.files {
+						match, err := predicate(f)
+						if err != nil {
+							return nil, err
+						}
+						if match {
+							files = append(files, f)
+						}
+					}
+					return files, nil
+				}).AnyTimes()
+			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(
+				func(f string) ([]byte, error) {
+					return []byte(tt.files[f]), nil
+				}).AnyTimes()
+
+			c := checker.CheckRequest{RepoClient: mockRepoClient}
+			var r checker.PinningDependenciesData
+			if err := collectGitHubCompositeActionPinning(&c, &r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			unpinned := []string{}
+			debug := 0
+			for _, d := range r.Dependencies {
+				if d.Location == nil {
+					debug++
+					continue
+				}
+				s := fmt.Sprintf("%s:%d:%s", d.Location.Path, d.Location.Offset, d.Type)
+				if d.Name != nil {
+					s += ":" + *d.Name
+				}
+				if d.PinnedAt != nil {
+					s += "@" + *d.PinnedAt
+				}
+				unpinned = append(unpinned, s)
+			}
+			if tt.unpinned == nil {
+				tt.unpinned = []string{}
+			}
+			if diff := cmp.Diff(tt.unpinned, unpinned); diff != "" {
+				t.Errorf("unexpected unpinned dependencies (-want +got):\n%s", diff)
+			}
+			if debug != tt.debug {
+				t.Errorf("expected %d debug messages, got %d", tt.debug, debug)
+			}
+		})
+	}
+}
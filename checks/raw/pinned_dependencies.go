@@ -16,8 +16,10 @@ package raw
 
 import (
 	"fmt"
+	"os"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
@@ -38,6 +40,11 @@ func PinningDependencies(c *checker.CheckRequest) (checker.PinningDependenciesDa
 		return checker.PinningDependenciesData{}, err
 	}
 
+	// Local actions.
+	if err := collectGitHubCompositeActionPinning(c, &results); err != nil {
+		return checker.PinningDependenciesData{}, err
+	}
+
 	// // Docker files.
 	if err := collectDockerfilePinning(c, &results); err != nil {
 		return checker.PinningDependenciesData{}, err
@@ -58,6 +65,11 @@ func PinningDependencies(c *checker.CheckRequest) (checker.PinningDependenciesDa
 		return checker.PinningDependenciesData{}, err
 	}
 
+	// Package manifests.
+	if err := collectManifestPinning(c, &results); err != nil {
+		return checker.PinningDependenciesData{}, err
+	}
+
 	return results, nil
 }
 
@@ -90,6 +102,16 @@ var validateShellScriptIsFreeOfInsecureDownloads fileparser.DoWhileTrueOnFileCon
 
 	pdata := dataAsPinnedDependenciesPointer(args[0])
 
+	// PowerShell and batch scripts.
+	switch {
+	case isPowerShellScriptFile(pathfn):
+		validateWindowsScript(pathfn, 0, content, shellPowerShell, map[string]bool{}, pdata)
+		return true, nil
+	case isBatchScriptFile(pathfn):
+		validateWindowsScript(pathfn, 0, content, shellCmd, map[string]bool{}, pdata)
+		return true, nil
+	}
+
 	// Validate the file type.
 	if !isSupportedShellScriptFile(pathfn, content) {
 		return true, nil
@@ -109,6 +131,9 @@ func collectDockerfileInsecureDownloads(c *checker.CheckRequest, r *checker.Pinn
 	}, validateDockerfileInsecureDownloads, r)
 }
 
+// dockerHeredocMarker matches the heredoc redirections of a RUN instruction, e.g., <<EOF, <<-"EOT".
+var dockerHeredocMarker = regexp.MustCompile(`\d*<<-?("[^"]*"|'[^']*'|\S+)`)
+
 var validateDockerfileInsecureDownloads fileparser.DoWhileTrueOnFileContent = func(
 	pathfn string,
 	content []byte,
@@ -161,6 +186,27 @@ var validateDockerfileInsecureDownloads fileparser.DoWhileTrueOnFileContent = fu
 
 		// Build a file content.
 		cmd := strings.Join(valueList, " ")
+		if len(child.Heredocs) > 0 {
+			// RUN <<EOF or RUN bash <<EOF: the heredocs are the scripts.
+			cmd = strings.TrimSpace(dockerHeredocMarker.ReplaceAllString(cmd, ""))
+			if fields := strings.Fields(cmd); len(fields) == 0 || isSupportedShell(fields[0]) {
+				for _, heredoc := range child.Heredocs {
+					script := heredoc.Content
+					if heredoc.Chomp {
+						script = parser.ChompHeredocContent(script)
+					}
+					// The script starts on the line following the RUN instruction.
+					if err := validateShellFile(pathfn, uint(child.StartLine), uint(child.StartLine),
+						[]byte(script), taintedFiles, pdata); err != nil {
+						return false, err
+					}
+				}
+				cmd = ""
+			}
+		}
+		if cmd == "" {
+			continue
+		}
 		bytes = append(bytes, cmd...)
 		if err := validateShellFile(pathfn, uint(child.StartLine)-1, uint(child.EndLine)-1,
 			bytes, taintedFiles, pdata); err != nil {
@@ -171,6 +217,84 @@ var validateDockerfileInsecureDownloads fileparser.DoWhileTrueOnFileContent = fu
 	return true, nil
 }
 
+// The dependency must be pinned by sha256 hash, e.g.,
+// FROM something@sha256:${ARG},
+// FROM something:@sha256:45b23dee08af5e43a7fea6c4cf9c25ccf269ee113168c19722f87876677c5cb2.
+var dockerImagePinned = regexp.MustCompile(`.*@sha256:([a-f\d]{64}|\${.*})`)
+
+// addDockerImageDependency records an image used by a FROM or COPY --from instruction,
+// unless it is pinned by hash or is a build stage.
+func addDockerImageDependency(pathfn string, child *parser.Node, name string,
+	args map[string]string, stages map[string]bool, pdata *checker.PinningDependenciesData,
+) {
+	// scratch is no-op.
+	if strings.EqualFold(name, "scratch") || dockerImagePinned.MatchString(name) {
+		return
+	}
+	image := expandDockerArgs(name, args)
+	// Stages may also be referred to by their index, e.g., COPY --from=0.
+	if stages[strings.ToLower(image)] || dockerImagePinned.MatchString(image) {
+		return
+	}
+	if _, err := strconv.Atoi(image); err == nil {
+		return
+	}
+
+	dep := checker.Dependency{
+		Location: &checker.File{
+			Path:      pathfn,
+			Type:      finding.FileTypeSource,
+			Offset:    uint(child.StartLine),
+			EndOffset: uint(child.EndLine),
+			Snippet:   child.Original,
+		},
+		Name: asPointer(image),
+		Type: checker.DependencyUseTypeDockerfileContainerImage,
+	}
+	// The tag follows the last colon after the registry host, e.g., localhost:5000/image:tag.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		dep.Name = asPointer(image[:i])
+		dep.PinnedAt = asPointer(image[i+1:])
+	}
+	pdata.Dependencies = append(pdata.Dependencies, dep)
+}
+
+// expandDockerArgs replaces the ARGs with a default value, e.g., ${BASE}, in s.
+// ARGs without a default value are left as is.
+func expandDockerArgs(s string, args map[string]string) string {
+	return os.Expand(s, func(name string) string {
+		// ${NAME:-default} and ${NAME:+alternative}.
+		if i := strings.Index(name, ":"); i >= 0 {
+			value, ok := args[name[:i]]
+			switch {
+			case strings.HasPrefix(name[i:], ":-") && (!ok || value == ""):
+				return name[i+2:]
+			case strings.HasPrefix(name[i:], ":+"):
+				if ok && value != "" {
+					return name[i+2:]
+				}
+				return ""
+			case ok:
+				return value
+			}
+			return "${" + name + "}"
+		}
+		if value, ok := args[name]; ok {
+			return value
+		}
+		return "${" + name + "}"
+	})
+}
+
+// dockerFlagValue returns the value of a flag, e.g., --from=image.
+func dockerFlagValue(flag, name string) (string, bool) {
+	prefix := "--" + name + "="
+	if !strings.HasPrefix(flag, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(flag, prefix), true
+}
+
 func isDockerfile(pathfn string, content []byte) bool {
 	if strings.HasSuffix(pathfn, ".go") ||
 		strings.HasSuffix(pathfn, ".c") ||
@@ -223,90 +347,51 @@ var validateDockerfilesPinning fileparser.DoWhileTrueOnFileContent = func(
 	// We have what looks like a docker file.
 	// Let's interpret the content as utf8-encoded strings.
 	contentReader := strings.NewReader(string(content))
-	// The dependency must be pinned by sha256 hash, e.g.,
-	// FROM something@sha256:${ARG},
-	// FROM something:@sha256:45b23dee08af5e43a7fea6c4cf9c25ccf269ee113168c19722f87876677c5cb2
-	regex := regexp.MustCompile(`.*@sha256:([a-f\d]{64}|\${.*})`)
 
-	pinnedAsNames := make(map[string]bool)
 	res, err := parser.Parse(contentReader)
 	if err != nil {
 		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInternalInvalidDockerFile, err))
 	}
 
+	// Global ARGs, declared before the first FROM, may be used in FROM instructions.
+	dockerArgs := make(map[string]string)
+	// Names of the build stages, which are not dependencies.
+	stages := make(map[string]bool)
+	seenFrom := false
 	for _, child := range res.AST.Children {
-		cmdType := child.Value
-		if cmdType != "FROM" {
-			continue
-		}
-
 		var valueList []string
 		for n := child.Next; n != nil; n = n.Next {
 			valueList = append(valueList, n.Value)
 		}
 
 		switch {
-		// scratch is no-op.
-		case len(valueList) > 0 && strings.EqualFold(valueList[0], "scratch"):
-			continue
+		case strings.EqualFold(child.Value, "ARG") && !seenFrom:
+			for _, arg := range valueList {
+				if name, value, ok := strings.Cut(arg, "="); ok {
+					dockerArgs[name] = strings.Trim(value, `"'`)
+				}
+			}
 
-		// FROM name AS newname.
-		case len(valueList) == 3 && strings.EqualFold(valueList[1], "as"):
-			name := valueList[0]
-			asName := valueList[2]
-			// Check if the name is pinned.
-			// (1): name = <>@sha245:hash
-			// (2): name = XXX where XXX was pinned
-			pinned := pinnedAsNames[name]
-			if pinned || regex.MatchString(name) {
-				// Record the asName.
-				pinnedAsNames[asName] = true
-				continue
+		case strings.EqualFold(child.Value, "FROM"):
+			seenFrom = true
+			if len(valueList) == 0 ||
+				(len(valueList) > 1 && (len(valueList) != 3 || !strings.EqualFold(valueList[1], "as"))) {
+				// That should not happen.
+				return false, sce.WithMessage(sce.ErrScorecardInternal, errInternalInvalidDockerFile.Error())
+			}
+			addDockerImageDependency(pathfn, child, valueList[0], dockerArgs, stages, pdata)
+			// FROM name AS newname.
+			if len(valueList) == 3 {
+				stages[strings.ToLower(valueList[2])] = true
 			}
 
-			pdata.Dependencies = append(pdata.Dependencies,
-				checker.Dependency{
-					Location: &checker.File{
-						Path:      pathfn,
-						Type:      finding.FileTypeSource,
-						Offset:    uint(child.StartLine),
-						EndOffset: uint(child.EndLine),
-						Snippet:   child.Original,
-					},
-					Name:     asPointer(name),
-					PinnedAt: asPointer(asName),
-					Type:     checker.DependencyUseTypeDockerfileContainerImage,
-				},
-			)
-
-		// FROM name.
-		case len(valueList) == 1:
-			name := valueList[0]
-			pinned := pinnedAsNames[name]
-			if !pinned && !regex.MatchString(name) {
-				dep := checker.Dependency{
-					Location: &checker.File{
-						Path:      pathfn,
-						Type:      finding.FileTypeSource,
-						Offset:    uint(child.StartLine),
-						EndOffset: uint(child.EndLine),
-						Snippet:   child.Original,
-					},
-					Type: checker.DependencyUseTypeDockerfileContainerImage,
+		// COPY --from=image.
+		case strings.EqualFold(child.Value, "COPY"):
+			for _, flag := range child.Flags {
+				if from, ok := dockerFlagValue(flag, "from"); ok {
+					addDockerImageDependency(pathfn, child, from, dockerArgs, stages, pdata)
 				}
-				parts := strings.SplitN(name, ":", 2)
-				if len(parts) > 0 {
-					dep.Name = asPointer(parts[0])
-					if len(parts) > 1 {
-						dep.PinnedAt = asPointer(parts[1])
-					}
-				}
-				pdata.Dependencies = append(pdata.Dependencies, dep)
 			}
-
-		default:
-			// That should not happen.
-			return false, sce.WithMessage(sce.ErrScorecardInternal, errInternalInvalidDockerFile.Error())
 		}
 	}
 
@@ -353,7 +438,6 @@ var validateGitHubWorkflowIsFreeOfInsecureDownloads fileparser.DoWhileTrueOnFile
 		return false, fileparser.FormatActionlintError(errs)
 	}
 
-	githubVarRegex := regexp.MustCompile(`{{[^{}]*}}`)
 	for jobName, job := range workflow.Jobs {
 		jobName := jobName
 		job := job
@@ -385,13 +469,18 @@ var validateGitHubWorkflowIsFreeOfInsecureDownloads fileparser.DoWhileTrueOnFile
 			if err != nil {
 				return false, err
 			}
-			// Skip unsupported shells. We don't support Windows shells or some Unix shells.
-			if !isSupportedShell(shell) {
+			script := redactGitHubExpressions(run)
+
+			// Windows shells.
+			if ws, ok := windowsShellForName(shell); ok {
+				validateWindowsScript(pathfn, uint(execRun.Run.Pos.Line), script, ws, taintedFiles, pdata)
 				continue
 			}
 
-			// We replace the `${{ github.variable }}` to avoid shell parsing failures.
-			script := githubVarRegex.ReplaceAll([]byte(run), []byte("GITHUB_REDACTED_VAR"))
+			// Skip unsupported shells. We don't support some Unix shells.
+			if !isSupportedShell(shell) {
+				continue
+			}
 			if err := validateShellFile(pathfn, uint(execRun.Run.Pos.Line), uint(execRun.Run.Pos.Line),
 				script, taintedFiles, pdata); err != nil {
 				pdata.Dependencies = append(pdata.Dependencies, checker.Dependency{
@@ -404,6 +493,13 @@ var validateGitHubWorkflowIsFreeOfInsecureDownloads fileparser.DoWhileTrueOnFile
 	return true, nil
 }
 
+var githubVarRegex = regexp.MustCompile(`{{[^{}]*}}`)
+
+// redactGitHubExpressions replaces the `${{ github.variable }}` to avoid shell parsing failures.
+func redactGitHubExpressions(run string) []byte {
+	return githubVarRegex.ReplaceAll([]byte(run), []byte("GITHUB_REDACTED_VAR"))
+}
+
 // Check pinning of github actions in workflows.
 func collectGitHubActionsWorkflowPinning(c *checker.CheckRequest, r *checker.PinningDependenciesData) error {
 	return fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
@@ -446,6 +542,24 @@ var validateGitHubActionWorkflow fileparser.DoWhileTrueOnFileContent = func(
 		if len(fileparser.GetJobName(job)) > 0 {
 			jobName = fileparser.GetJobName(job)
 		}
+
+		// Reusable workflows called by the job,
+		// https://docs.github.com/en/actions/using-workflows/reusing-workflows#calling-a-reusable-workflow.
+		if job.WorkflowCall != nil && job.WorkflowCall.Uses != nil {
+			addActionDependency(pathfn, job.WorkflowCall.Uses.Value, job.WorkflowCall.Uses.Pos.Line, pdata)
+		}
+
+		// Containers the job and its services run in.
+		if job.Container != nil && job.Container.Image != nil {
+			addContainerImageDependency(pathfn, job.Container.Image.Value, job.Container.Image.Pos.Line, pdata)
+		}
+		for _, service := range job.Services {
+			if service == nil || service.Container == nil || service.Container.Image == nil {
+				continue
+			}
+			addContainerImageDependency(pathfn, service.Container.Image.Value, service.Container.Image.Pos.Line, pdata)
+		}
+
 		for _, step := range job.Steps {
 			if !fileparser.IsStepExecKind(step, actionlint.ExecKindAction) {
 				continue
@@ -463,39 +577,84 @@ var validateGitHubActionWorkflow fileparser.DoWhileTrueOnFileContent = func(
 				continue
 			}
 
-			//nolint:lll
-			// Check whether this is an action defined in the same repo,
-			// https://docs.github.com/en/actions/learn-github-actions/finding-and-customizing-actions#referencing-an-action-in-the-same-repository-where-a-workflow-file-uses-the-action.
-			if strings.HasPrefix(execAction.Uses.Value, "./") {
-				continue
-			}
-
-			if !isActionDependencyPinned(execAction.Uses.Value) {
-				dep := checker.Dependency{
-					Location: &checker.File{
-						Path:      pathfn,
-						Type:      finding.FileTypeSource,
-						Offset:    uint(execAction.Uses.Pos.Line),
-						EndOffset: uint(execAction.Uses.Pos.Line), // `Uses` always span a single line.
-						Snippet:   execAction.Uses.Value,
-					},
-					Type: checker.DependencyUseTypeGHAction,
-				}
-				parts := strings.SplitN(execAction.Uses.Value, "@", 2)
-				if len(parts) > 0 {
-					dep.Name = asPointer(parts[0])
-					if len(parts) > 1 {
-						dep.PinnedAt = asPointer(parts[1])
-					}
-				}
-				pdata.Dependencies = append(pdata.Dependencies, dep)
-			}
+			addActionDependency(pathfn, execAction.Uses.Value, execAction.Uses.Pos.Line, pdata)
 		}
 	}
 
 	return true, nil
 }
 
+// addActionDependency records the action or reusable workflow referenced by `uses`
+// if it is not pinned by hash.
+func addActionDependency(pathfn, uses string, line int, pdata *checker.PinningDependenciesData) {
+	//nolint:lll
+	// Check whether this is an action defined in the same repo,
+	// https://docs.github.com/en/actions/learn-github-actions/finding-and-customizing-actions#referencing-an-action-in-the-same-repository-where-a-workflow-file-uses-the-action.
+	if strings.HasPrefix(uses, "./") {
+		return
+	}
+
+	// Docker container actions, e.g., docker://alpine:3.8.
+	if strings.HasPrefix(uses, dockerActionPrefix) {
+		addContainerImageDependency(pathfn, strings.TrimPrefix(uses, dockerActionPrefix), line, pdata)
+		return
+	}
+
+	if isActionDependencyPinned(uses) {
+		return
+	}
+
+	dep := checker.Dependency{
+		Location: &checker.File{
+			Path:      pathfn,
+			Type:      finding.FileTypeSource,
+			Offset:    uint(line),
+			EndOffset: uint(line), // `Uses` always span a single line.
+			Snippet:   uses,
+		},
+		Type: checker.DependencyUseTypeGHAction,
+	}
+	parts := strings.SplitN(uses, "@", 2)
+	if len(parts) > 0 {
+		dep.Name = asPointer(parts[0])
+		if len(parts) > 1 {
+			dep.PinnedAt = asPointer(parts[1])
+		}
+	}
+	pdata.Dependencies = append(pdata.Dependencies, dep)
+}
+
+const dockerActionPrefix = "docker://"
+
+var containerImageDigest = regexp.MustCompile(`@sha256:[a-fA-F\d]{64}$`)
+
+// addContainerImageDependency records a container image used by a workflow or an action
+// if it is not pinned by hash.
+func addContainerImageDependency(pathfn, image string, line int, pdata *checker.PinningDependenciesData) {
+	// Images set by expressions, e.g., ${{ matrix.image }}, cannot be checked.
+	if image == "" || strings.Contains(image, "${{") || containerImageDigest.MatchString(image) {
+		return
+	}
+
+	dep := checker.Dependency{
+		Location: &checker.File{
+			Path:      pathfn,
+			Type:      finding.FileTypeSource,
+			Offset:    uint(line),
+			EndOffset: uint(line),
+			Snippet:   image,
+		},
+		Name: asPointer(image),
+		Type: checker.DependencyUseTypeDockerfileContainerImage,
+	}
+	// The tag follows the last colon after the registry host, e.g., ghcr.io:443/owner/image:tag.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		dep.Name = asPointer(image[:i])
+		dep.PinnedAt = asPointer(image[i+1:])
+	}
+	pdata.Dependencies = append(pdata.Dependencies, dep)
+}
+
 func isActionDependencyPinned(actionUses string) bool {
 	localActionRegex := regexp.MustCompile(`^\..+[^/]`)
 	if localActionRegex.MatchString(actionUses) {
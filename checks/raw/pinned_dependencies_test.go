@@ -213,6 +213,11 @@ func TestNonGithubWorkflowPinning(t *testing.T) {
 			filename: "./testdata/.github/workflows/workflow-mix-pinned-and-non-pinned-non-github.yaml",
 			warns:    1,
 		},
+		{
+			name:     "Reusable workflows, job containers, services and Docker actions",
+			filename: "./testdata/.github/workflows/workflow-containers-and-reusable-workflows.yaml",
+			warns:    4,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -332,13 +337,23 @@ func TestDockerfilePinning(t *testing.T) {
 		{
 			name:     "Non-pinned dockerfile as",
 			filename: "./testdata/Dockerfile-not-pinned-as",
-			warns:    2,
+			warns:    1,
 		},
 		{
 			name:     "Non-pinned dockerfile",
 			filename: "./testdata/Dockerfile-not-pinned",
 			warns:    1,
 		},
+		{
+			name:     "dockerfile ARG in FROM",
+			filename: "./testdata/Dockerfile-args-from",
+			warns:    2,
+		},
+		{
+			name:     "dockerfile COPY --from",
+			filename: "./testdata/Dockerfile-copy-from",
+			warns:    2,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -396,11 +411,6 @@ func TestDockerfilePinningFromLineNumber(t *testing.T) {
 					startLine: 17,
 					endLine:   17,
 				},
-				{
-					snippet:   "FROM build",
-					startLine: 23,
-					endLine:   23,
-				},
 			},
 		},
 		{
@@ -418,6 +428,46 @@ func TestDockerfilePinningFromLineNumber(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "dockerfile ARG in FROM",
+			filename: "./testdata/Dockerfile-args-from",
+			expected: []struct {
+				snippet   string
+				startLine uint
+				endLine   uint
+			}{
+				{
+					snippet:   "FROM ${BASE} AS build",
+					startLine: 20,
+					endLine:   20,
+				},
+				{
+					snippet:   "FROM ${REGISTRY}/golang:${VERSION:-1.21}",
+					startLine: 24,
+					endLine:   24,
+				},
+			},
+		},
+		{
+			name:     "dockerfile COPY --from",
+			filename: "./testdata/Dockerfile-copy-from",
+			expected: []struct {
+				snippet   string
+				startLine uint
+				endLine   uint
+			}{
+				{
+					snippet:   "COPY --from=golang:1.21 /usr/local/go /usr/local/go",
+					startLine: 21,
+					endLine:   21,
+				},
+				{
+					snippet:   "COPY --chown=root --from=busybox /bin/sh /bin/sh",
+					startLine: 23,
+					endLine:   23,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -451,6 +501,60 @@ func TestDockerfilePinningFromLineNumber(t *testing.T) {
 	}
 }
 
+func TestDockerfilePinningImageName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		filename string
+		images   []string
+		tags     []string
+	}{
+		{
+			name:     "ARG in FROM",
+			filename: "./testdata/Dockerfile-args-from",
+			images:   []string{"python", "localhost:5000/golang"},
+			tags:     []string{"3.7", "1.21"},
+		},
+		{
+			name:     "COPY --from",
+			filename: "./testdata/Dockerfile-copy-from",
+			images:   []string{"golang", "busybox"},
+			tags:     []string{"1.21", ""},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile(tt.filename)
+			if err != nil {
+				t.Fatalf("cannot read file: %v", err)
+			}
+
+			var r checker.PinningDependenciesData
+			if _, err := validateDockerfilesPinning(tt.filename, content, &r); err != nil {
+				t.Fatalf("error during validateDockerfilesPinning: %v", err)
+			}
+
+			var images, tags []string
+			for _, dep := range r.Dependencies {
+				images = append(images, *dep.Name)
+				if dep.PinnedAt == nil {
+					tags = append(tags, "")
+				} else {
+					tags = append(tags, *dep.PinnedAt)
+				}
+			}
+			if diff := cmp.Diff(tt.images, images); diff != "" {
+				t.Errorf("unexpected images (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tt.tags, tags); diff != "" {
+				t.Errorf("unexpected tags (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestDockerfileInvalidFiles(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -655,6 +759,30 @@ func TestDockerfileInsecureDownloadsLineNumber(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "dockerfile heredoc",
+			filename: "./testdata/Dockerfile-heredoc",
+			//nolint
+			expected: []struct {
+				snippet   string
+				startLine uint
+				endLine   uint
+				t         checker.DependencyUseType
+			}{
+				{
+					snippet:   "curl bla | bash",
+					startLine: 19,
+					endLine:   19,
+					t:         checker.DependencyUseTypeDownloadThenRun,
+				},
+				{
+					snippet:   "pip install -r requirements.txt",
+					startLine: 23,
+					endLine:   23,
+					t:         checker.DependencyUseTypePipCommand,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -885,7 +1013,7 @@ func TestDockerfilePinningWihoutHash(t *testing.T) {
 		{
 			name:     "Pinned dockerfile as no hash",
 			filename: "./testdata/Dockerfile-pinned-as-without-hash",
-			warns:    4,
+			warns:    2,
 		},
 		{
 			name:     "Dockerfile with args",
@@ -996,6 +1124,11 @@ func TestDockerfileScriptDownload(t *testing.T) {
 			filename: "./testdata/Dockerfile-some-python",
 			warns:    1,
 		},
+		{
+			name:     "heredoc",
+			filename: "./testdata/Dockerfile-heredoc",
+			warns:    2,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -1223,6 +1356,11 @@ func TestGitHubWorflowRunDownload(t *testing.T) {
 			filename: "./testdata/.github/workflows/github-workflow-wget-across-steps.yaml",
 			warns:    2,
 		},
+		{
+			name:     "windows shells",
+			filename: "./testdata/.github/workflows/github-workflow-windows-downloads.yaml",
+			warns:    4,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -0,0 +1,619 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// manifestDependency is a dependency declared in a manifest.
+type manifestDependency struct {
+	name    string
+	version string
+	snippet string
+	line    uint
+	// pinned is true if the manifest itself pins the dependency,
+	// e.g., by hash, regardless of lockfiles.
+	pinned bool
+}
+
+// manifestParser returns the dependencies declared in a manifest.
+// It returns an error if the manifest is malformed.
+type manifestParser func(content []byte) ([]manifestDependency, error)
+
+// workspaceMemberFunc returns true if the manifest in the directory member belongs to the
+// workspace declared in the directory root, e.g., by the `workspaces` of a package.json.
+type workspaceMemberFunc func(readFile func(string) ([]byte, error), root, member string) bool
+
+// manifestRule describes a kind of manifest and the lockfiles that pin its dependencies.
+type manifestRule struct {
+	ecosystem string
+	parse     manifestParser
+	// lockfiles lists the names of the lockfiles of the manifest, in the same directory.
+	lockfiles []string
+	// lockInParents is true if lockfiles in parent directories also pin the manifest,
+	// e.g., for npm and Cargo workspaces.
+	lockInParents bool
+	// isWorkspaceMember, if set, restricts lockInParents to the members of the workspace
+	// declared in the directory of the lockfile.
+	isWorkspaceMember workspaceMemberFunc
+}
+
+var manifestRules = map[string]manifestRule{
+	"package.json": {
+		ecosystem:         "npm",
+		parse:             parsePackageJSON,
+		lockfiles:         []string{"package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml"},
+		lockInParents:     true,
+		isWorkspaceMember: isNpmWorkspaceMember,
+	},
+	"go.mod": {
+		ecosystem: "go",
+		parse:     parseGoMod,
+		lockfiles: []string{"go.sum"},
+	},
+	"Cargo.toml": {
+		ecosystem:     "cargo",
+		parse:         parseCargoToml,
+		lockfiles:     []string{"Cargo.lock"},
+		lockInParents: true,
+	},
+	"Gemfile": {
+		ecosystem: "rubygems",
+		parse:     parseGemfile,
+		lockfiles: []string{"Gemfile.lock"},
+	},
+	"pom.xml": {
+		ecosystem: "maven",
+		parse:     parsePomXML,
+	},
+}
+
+var requirementsRule = manifestRule{
+	ecosystem: "pip",
+	parse:     parseRequirements,
+}
+
+// requirements.txt, requirements-dev.txt, dev-requirements.txt, etc.
+var requirementsFile = regexp.MustCompile(`(?i)^([\w.-]*[-_.])?requirements([-_.][\w.-]*)?\.txt$`)
+
+func manifestRuleFor(pathfn string) (manifestRule, bool) {
+	base := path.Base(pathfn)
+	if requirementsFile.MatchString(base) {
+		return requirementsRule, true
+	}
+	rule, ok := manifestRules[base]
+	return rule, ok
+}
+
+func isManifestOrLockfile(pathfn string) (bool, error) {
+	// Skip installed and vendored dependencies.
+	for _, dir := range []string{"node_modules", "vendor"} {
+		if strings.HasPrefix(pathfn, dir+"/") || strings.Contains(pathfn, "/"+dir+"/") {
+			return false, nil
+		}
+	}
+	if _, ok := manifestRuleFor(pathfn); ok {
+		return true, nil
+	}
+	base := path.Base(pathfn)
+	if base == pnpmWorkspaceFile {
+		return true, nil
+	}
+	for _, rule := range manifestRules {
+		for _, lockfile := range rule.lockfiles {
+			if base == lockfile {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// collectManifestPinning collects the dependencies declared in package manifests
+// that are pinned neither by a committed lockfile nor by a hash.
+func collectManifestPinning(c *checker.CheckRequest, r *checker.PinningDependenciesData) error {
+	files, err := c.RepoClient.ListFiles(isManifestOrLockfile)
+	if err != nil {
+		return fmt.Errorf("RepoClient.ListFiles: %w", err)
+	}
+	sort.Strings(files)
+	committed := make(map[string]bool, len(files))
+	for _, f := range files {
+		committed[f] = true
+	}
+	readCommitted := func(f string) ([]byte, error) {
+		if !committed[f] {
+			return nil, errManifestNotCommitted
+		}
+		//nolint:wrapcheck // The error is not reported.
+		return c.RepoClient.GetFileContent(f)
+	}
+
+	for _, f := range files {
+		rule, ok := manifestRuleFor(f)
+		if !ok {
+			continue
+		}
+		content, err := c.RepoClient.GetFileContent(f)
+		if err != nil {
+			return fmt.Errorf("RepoClient.GetFileContent: %w", err)
+		}
+		deps, err := rule.parse(content)
+		if err != nil {
+			// Malformed manifests, e.g., test fixtures, are not used to install dependencies.
+			continue
+		}
+
+		manifest := checker.DependencyManifest{
+			File: checker.File{
+				Path:   f,
+				Type:   finding.FileTypeSource,
+				Offset: checker.OffsetDefault,
+			},
+			Ecosystem: rule.ecosystem,
+			Lockfile:  findLockfile(f, &rule, committed, readCommitted),
+		}
+		r.Manifests = append(r.Manifests, manifest)
+		if manifest.Lockfile != nil {
+			continue
+		}
+
+		for i := range deps {
+			dep := &deps[i]
+			if dep.pinned {
+				continue
+			}
+			d := checker.Dependency{
+				Location: &checker.File{
+					Path:      f,
+					Type:      finding.FileTypeSource,
+					Offset:    dep.line,
+					EndOffset: dep.line,
+					Snippet:   dep.snippet,
+				},
+				Name: asPointer(dep.name),
+				Type: checker.DependencyUseTypeManifest,
+			}
+			if dep.version != "" {
+				d.PinnedAt = asPointer(dep.version)
+			}
+			r.Dependencies = append(r.Dependencies, d)
+		}
+	}
+	return nil
+}
+
+// findLockfile returns the committed lockfile of a manifest, if any.
+func findLockfile(manifest string, rule *manifestRule, committed map[string]bool,
+	readFile func(string) ([]byte, error),
+) *checker.File {
+	memberDir := path.Dir(manifest)
+	dir := memberDir
+	for {
+		member := dir == memberDir || rule.isWorkspaceMember == nil ||
+			rule.isWorkspaceMember(readFile, dir, memberDir)
+		for _, name := range rule.lockfiles {
+			p := path.Join(dir, name)
+			if member && committed[p] {
+				return &checker.File{
+					Path:   p,
+					Type:   finding.FileTypeSource,
+					Offset: checker.OffsetDefault,
+				}
+			}
+		}
+		if !rule.lockInParents || dir == "." || dir == "/" {
+			return nil
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+const pnpmWorkspaceFile = "pnpm-workspace.yaml"
+
+var errManifestNotCommitted = errors.New("file not committed")
+
+// isNpmWorkspaceMember returns true if the `workspaces` of the package.json in root,
+// or the `packages` of its pnpm-workspace.yaml, include the directory member.
+func isNpmWorkspaceMember(readFile func(string) ([]byte, error), root, member string) bool {
+	rel := strings.TrimPrefix(member, root+"/")
+	if root == "." {
+		rel = member
+	}
+
+	var globs []string
+	if content, err := readFile(path.Join(root, "package.json")); err == nil {
+		var manifest struct {
+			Workspaces json.RawMessage `json:"workspaces"`
+		}
+		if err := json.Unmarshal(content, &manifest); err == nil && manifest.Workspaces != nil {
+			// Yarn also accepts an object, e.g., {"packages": [...], "nohoist": [...]}.
+			var packages struct {
+				Packages []string `json:"packages"`
+			}
+			if err := json.Unmarshal(manifest.Workspaces, &globs); err != nil &&
+				json.Unmarshal(manifest.Workspaces, &packages) == nil {
+				globs = packages.Packages
+			}
+		}
+	}
+	if content, err := readFile(path.Join(root, pnpmWorkspaceFile)); err == nil {
+		var workspace struct {
+			Packages []string `yaml:"packages"`
+		}
+		if err := yaml.Unmarshal(content, &workspace); err == nil {
+			globs = append(globs, workspace.Packages...)
+		}
+	}
+
+	included := false
+	for _, glob := range globs {
+		if negated := strings.HasPrefix(glob, "!"); negated {
+			if matchesWorkspaceGlob(strings.TrimPrefix(glob, "!"), rel) {
+				return false
+			}
+			continue
+		}
+		included = included || matchesWorkspaceGlob(glob, rel)
+	}
+	return included
+}
+
+// matchesWorkspaceGlob matches the directory of a workspace member, relative to the
+// root of the workspace, against a glob such as `packages/*` or `apps/**`.
+func matchesWorkspaceGlob(glob, dir string) bool {
+	glob = strings.TrimSuffix(strings.TrimPrefix(glob, "./"), "/")
+	if prefix := strings.TrimSuffix(glob, "/**"); prefix != glob {
+		return strings.HasPrefix(dir, prefix+"/")
+	}
+	match, err := path.Match(glob, dir)
+	return err == nil && match
+}
+
+// lineNumber returns the 1-based line of the first line matching re, at or after the 0-based line start.
+func lineNumber(lines []string, start int, re *regexp.Regexp) (uint, string) {
+	for i := start; i < len(lines); i++ {
+		if re.MatchString(lines[i]) {
+			return uint(i + 1), strings.TrimSpace(lines[i])
+		}
+	}
+	return checker.OffsetDefault, ""
+}
+
+var npmDependencySections = []string{"dependencies", "devDependencies", "optionalDependencies"}
+
+func parsePackageJSON(content []byte) ([]manifestDependency, error) {
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	var deps []manifestDependency
+	for _, section := range npmDependencySections {
+		raw, ok := manifest[section]
+		if !ok {
+			continue
+		}
+		var versions map[string]string
+		if err := json.Unmarshal(raw, &versions); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+		names := make([]string, 0, len(versions))
+		for name := range versions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		sectionLine, _ := lineNumber(lines, 0, regexp.MustCompile(`"`+regexp.QuoteMeta(section)+`"\s*:`))
+		start := 0
+		if sectionLine != checker.OffsetDefault {
+			start = int(sectionLine) - 1
+		}
+		for _, name := range names {
+			line, snippet := lineNumber(lines, start, regexp.MustCompile(`"`+regexp.QuoteMeta(name)+`"\s*:`))
+			deps = append(deps, manifestDependency{
+				name:    name,
+				version: versions[name],
+				line:    line,
+				snippet: snippet,
+			})
+		}
+	}
+	return deps, nil
+}
+
+var (
+	requirementName    = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)`)
+	requirementHash    = regexp.MustCompile(`--hash[=\s]`)
+	requirementVersion = regexp.MustCompile(`(===?|~=|!=|<=?|>=?)\s*[^\s;,]+`)
+)
+
+func parseRequirements(content []byte) ([]manifestDependency, error) {
+	var deps []manifestDependency
+	lines := strings.Split(string(content), "\n")
+	for i := 0; i < len(lines); i++ {
+		start := i
+		// Join continuation lines.
+		line := lines[i]
+		for strings.HasSuffix(strings.TrimSpace(line), `\`) && i+1 < len(lines) {
+			line = strings.TrimSuffix(strings.TrimSpace(line), `\`) + " " + lines[i+1]
+			i++
+		}
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var name string
+		switch {
+		case strings.HasPrefix(line, "-e ") || strings.HasPrefix(line, "--editable"):
+			// Editable installs are never pinned.
+			fields := strings.Fields(strings.TrimPrefix(strings.TrimPrefix(line, "--editable"), "-e"))
+			if len(fields) == 0 {
+				continue
+			}
+			name = strings.TrimPrefix(fields[0], "=")
+		case strings.HasPrefix(line, "-"):
+			// Options, e.g., -r other.txt or --index-url.
+			continue
+		default:
+			if m := requirementName.FindStringSubmatch(line); m != nil && !strings.Contains(strings.Fields(line)[0], "://") {
+				name = m[1]
+			} else {
+				name = strings.Fields(line)[0]
+			}
+		}
+
+		deps = append(deps, manifestDependency{
+			name:    name,
+			version: requirementVersion.FindString(line),
+			line:    uint(start + 1),
+			snippet: strings.TrimSpace(lines[start]),
+			pinned:  requirementHash.MatchString(line),
+		})
+	}
+	return deps, nil
+}
+
+var (
+	goRequireLine  = regexp.MustCompile(`^require\s+(\S+)\s+(\S+)`)
+	goRequireBlock = regexp.MustCompile(`^require\s*\($`)
+	goBlockEntry   = regexp.MustCompile(`^(\S+)\s+(v\S+)`)
+)
+
+func parseGoMod(content []byte) ([]manifestDependency, error) {
+	var deps []manifestDependency
+	inBlock := false
+	for i, l := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(l)
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		var m []string
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			m = goBlockEntry.FindStringSubmatch(line)
+		case goRequireBlock.MatchString(line):
+			inBlock = true
+			continue
+		default:
+			m = goRequireLine.FindStringSubmatch(line)
+		}
+		if m == nil {
+			continue
+		}
+		deps = append(deps, manifestDependency{
+			name:    m[1],
+			version: m[2],
+			line:    uint(i + 1),
+			snippet: strings.TrimSpace(l),
+		})
+	}
+	return deps, nil
+}
+
+var (
+	tomlTable      = regexp.MustCompile(`^\[\s*([^\[\]]+?)\s*\]$`)
+	tomlKey        = regexp.MustCompile(`^("[^"]+"|[A-Za-z0-9_-]+)\s*=\s*(.*)$`)
+	cargoVersion   = regexp.MustCompile(`version\s*=\s*"([^"]*)"`)
+	cargoLocalOnly = regexp.MustCompile(`\bpath\s*=`)
+	cargoGit       = regexp.MustCompile(`\bgit\s*=`)
+	cargoWorkspace = regexp.MustCompile(`\bworkspace\s*=\s*true\b`)
+)
+
+// isCargoDependencyTable returns true for tables like [dependencies],
+// [dev-dependencies] and [target.'cfg(unix)'.build-dependencies].
+func isCargoDependencyTable(table string) bool {
+	parts := strings.Split(table, ".")
+	return strings.HasSuffix(parts[len(parts)-1], "dependencies")
+}
+
+func parseCargoToml(content []byte) ([]manifestDependency, error) {
+	var deps []manifestDependency
+	lines := strings.Split(string(content), "\n")
+	inDeps := false
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := tomlTable.FindStringSubmatch(line); m != nil {
+			table := m[1]
+			inDeps = isCargoDependencyTable(table)
+			if inDeps {
+				continue
+			}
+			// [dependencies.name] tables.
+			idx := strings.LastIndex(table, ".")
+			if idx < 0 || !isCargoDependencyTable(table[:idx]) {
+				continue
+			}
+			body := ""
+			for j := i + 1; j < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[j]), "["); j++ {
+				body += lines[j] + "\n"
+			}
+			if dep, ok := cargoDependency(strings.Trim(table[idx+1:], `"`), body, uint(i+1), line); ok {
+				deps = append(deps, dep)
+			}
+			continue
+		}
+		if !inDeps {
+			continue
+		}
+		m := tomlKey.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		value := m[2]
+		// Multi-line inline tables.
+		for strings.Count(value, "{") > strings.Count(value, "}") && i+1 < len(lines) {
+			i++
+			value += " " + strings.TrimSpace(lines[i])
+		}
+		if dep, ok := cargoDependency(strings.Trim(m[1], `"`), value, uint(i+1), line); ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps, nil
+}
+
+func cargoDependency(name, value string, line uint, snippet string) (manifestDependency, bool) {
+	// Dependencies inherited from the workspace are declared, and locked, there.
+	if cargoWorkspace.MatchString(value) {
+		return manifestDependency{}, false
+	}
+	version := ""
+	if strings.HasPrefix(strings.TrimSpace(value), `"`) {
+		version = strings.Trim(strings.TrimSpace(value), `"`)
+	} else if m := cargoVersion.FindStringSubmatch(value); m != nil {
+		version = m[1]
+	}
+	// Local crates are not downloaded.
+	if version == "" && cargoLocalOnly.MatchString(value) && !cargoGit.MatchString(value) {
+		return manifestDependency{}, false
+	}
+	return manifestDependency{
+		name:    name,
+		version: version,
+		line:    line,
+		snippet: snippet,
+	}, true
+}
+
+var gemDeclaration = regexp.MustCompile(`^\s*gem\s+["']([^"']+)["']\s*(?:,\s*["']([^"']+)["'])?`)
+
+func parseGemfile(content []byte) ([]manifestDependency, error) {
+	var deps []manifestDependency
+	for i, line := range strings.Split(string(content), "\n") {
+		m := gemDeclaration.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		deps = append(deps, manifestDependency{
+			name:    m[1],
+			version: m[2],
+			line:    uint(i + 1),
+			snippet: strings.TrimSpace(line),
+		})
+	}
+	return deps, nil
+}
+
+var errInvalidPom = errors.New("invalid pom.xml")
+
+// isMavenVersionRange returns true for version ranges, e.g., [1.0,2.0),
+// and for the LATEST and RELEASE meta-versions.
+// See https://maven.apache.org/pom.html#dependency-version-requirement-specification.
+func isMavenVersionRange(version string) bool {
+	return strings.HasPrefix(version, "[") || strings.HasPrefix(version, "(") ||
+		strings.Contains(version, ",") || version == "LATEST" || version == "RELEASE"
+}
+
+// parsePomXML returns the dependencies of a pom.xml. Maven has no lockfile,
+// so only dependencies with an exact version are pinned.
+func parsePomXML(content []byte) ([]manifestDependency, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	lines := strings.Split(string(content), "\n")
+	var deps []manifestDependency
+	var elements []string
+	var current *manifestDependency
+	var groupID, artifactID string
+	for {
+		tok, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidPom, err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			elements = append(elements, t.Name.Local)
+			if t.Name.Local == "dependency" && len(elements) > 1 && elements[len(elements)-2] == "dependencies" {
+				line := uint(bytes.Count(content[:decoder.InputOffset()], []byte("\n")) + 1)
+				current = &manifestDependency{line: line, snippet: strings.TrimSpace(lines[line-1])}
+				groupID, artifactID = "", ""
+			}
+		case xml.EndElement:
+			if len(elements) == 0 {
+				return nil, errInvalidPom
+			}
+			elements = elements[:len(elements)-1]
+			if t.Name.Local == "dependency" && current != nil {
+				current.name = groupID + ":" + artifactID
+				// Versions set by properties or inherited from a parent cannot be checked.
+				current.pinned = strings.Contains(current.version, "${") || !isMavenVersionRange(current.version)
+				deps = append(deps, *current)
+				current = nil
+			}
+		case xml.CharData:
+			if current == nil || len(elements) < 2 || elements[len(elements)-2] != "dependency" {
+				continue
+			}
+			value := strings.TrimSpace(string(t))
+			switch elements[len(elements)-1] {
+			case "groupId":
+				groupID = value
+			case "artifactId":
+				artifactID = value
+			case "version":
+				current.version = value
+			}
+		}
+	}
+	return deps, nil
+}
@@ -0,0 +1,351 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func TestCollectManifestPinning(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		files     map[string]string
+		unpinned  []string
+		manifests map[string]string
+	}{
+		{
+			name: "package.json without lockfile",
+			files: map[string]string{
+				"package.json": `{
+  "name": "foo",
+  "dependencies": {
+    "left-pad": "^1.3.0",
+    "express": "4.18.2"
+  },
+  "devDependencies": {
+    "jest": "~29.0.0"
+  }
+}`,
+			},
+			unpinned: []string{
+				"package.json:5:express@4.18.2",
+				"package.json:4:left-pad@^1.3.0",
+				"package.json:8:jest@~29.0.0",
+			},
+			manifests: map[string]string{"package.json": ""},
+		},
+		{
+			name: "package.json with lockfile",
+			files: map[string]string{
+				"web/package.json":                   `{"dependencies": {"left-pad": "^1.3.0"}}`,
+				"web/yarn.lock":                      "",
+				"node_modules/left-pad/package.json": `{"dependencies": {"foo": "*"}}`,
+			},
+			manifests: map[string]string{"web/package.json": "web/yarn.lock"},
+		},
+		{
+			name: "npm workspace",
+			files: map[string]string{
+				"package.json":            `{"workspaces": ["packages/*"], "devDependencies": {"lerna": "^7.0.0"}}`,
+				"package-lock.json":       "",
+				"packages/a/package.json": `{"dependencies": {"left-pad": "^1.3.0"}}`,
+			},
+			manifests: map[string]string{
+				"package.json":            "package-lock.json",
+				"packages/a/package.json": "package-lock.json",
+			},
+		},
+		{
+			name: "npm package outside the workspace",
+			files: map[string]string{
+				"package.json":                   `{"workspaces": {"packages": ["packages/**", "!packages/internal"]}}`,
+				"package-lock.json":              "",
+				"packages/a/b/package.json":      `{}`,
+				"packages/internal/package.json": `{}`,
+				"examples/foo/package.json":      `{}`,
+			},
+			manifests: map[string]string{
+				"package.json":                   "package-lock.json",
+				"packages/a/b/package.json":      "package-lock.json",
+				"packages/internal/package.json": "",
+				"examples/foo/package.json":      "",
+			},
+		},
+		{
+			name: "pnpm workspace",
+			files: map[string]string{
+				"package.json":           `{}`,
+				"pnpm-workspace.yaml":    "packages:\n  - 'apps/*'\n",
+				"pnpm-lock.yaml":         "",
+				"apps/web/package.json":  `{}`,
+				"tools/cli/package.json": `{}`,
+			},
+			manifests: map[string]string{
+				"package.json":           "pnpm-lock.yaml",
+				"apps/web/package.json":  "pnpm-lock.yaml",
+				"tools/cli/package.json": "",
+			},
+		},
+		{
+			name: "malformed package.json",
+			files: map[string]string{
+				"testdata/package.json": `{"dependencies": `,
+			},
+		},
+		{
+			name: "requirements without hashes",
+			files: map[string]string{
+				"requirements.txt": `# comment
+-r base.txt
+--index-url https://example.com
+requests==2.31.0 \
+    --hash=sha256:58cd2187c01e70e6e26505bca751777aa9f2ee0b7f4300988b709f44e013003f
+flask>=2.0
+-e git+https://github.com/foo/bar.git#egg=bar
+django==4.2 # pinned by version only
+`,
+				"docs/dev-requirements.txt": "sphinx\n",
+			},
+			unpinned: []string{
+				"docs/dev-requirements.txt:1:sphinx",
+				"requirements.txt:6:flask@>=2.0",
+				"requirements.txt:7:git+https://github.com/foo/bar.git#egg=bar",
+				"requirements.txt:8:django@==4.2",
+			},
+			manifests: map[string]string{
+				"docs/dev-requirements.txt": "",
+				"requirements.txt":          "",
+			},
+		},
+		{
+			name: "go.mod without go.sum",
+			files: map[string]string{
+				"go.mod": `module example.com/foo
+
+go 1.19
+
+require github.com/google/go-cmp v0.5.9
+
+require (
+	golang.org/x/mod v0.12.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1
+)
+`,
+				"tools/go.mod": "module example.com/tools\n\nrequire golang.org/x/tools v0.1.0\n",
+				"tools/go.sum": "",
+			},
+			unpinned: []string{
+				"go.mod:5:github.com/google/go-cmp@v0.5.9",
+				"go.mod:8:golang.org/x/mod@v0.12.0",
+				"go.mod:9:gopkg.in/yaml.v3@v3.0.1",
+			},
+			manifests: map[string]string{
+				"go.mod":       "",
+				"tools/go.mod": "tools/go.sum",
+			},
+		},
+		{
+			name: "Cargo workspace",
+			files: map[string]string{
+				"Cargo.lock": "",
+				"crates/a/Cargo.toml": `[package]
+name = "a"
+
+[dependencies]
+serde = "1"
+`,
+				"tools/Cargo.toml": `[package]
+name = "tools"
+version = "0.1.0"
+
+[dependencies]
+serde = { version = "1.0", features = [
+    "derive",
+] }
+local = { path = "../local" }
+common = { workspace = true }
+
+[target.'cfg(unix)'.dev-dependencies]
+nix = "0.26"
+
+[dependencies.rand]
+version = "0.8"
+`,
+			},
+			unpinned: []string{},
+			manifests: map[string]string{
+				"crates/a/Cargo.toml": "Cargo.lock",
+				"tools/Cargo.toml":    "Cargo.lock",
+			},
+		},
+		{
+			name: "Cargo.toml without lockfile",
+			files: map[string]string{
+				"Cargo.toml": `[package]
+name = "tools"
+version = "0.1.0"
+
+[dependencies]
+serde = { version = "1.0", features = [
+    "derive",
+] }
+local = { path = "../local" }
+common = { workspace = true }
+
+[target.'cfg(unix)'.dev-dependencies]
+nix = "0.26"
+
+[dependencies.rand]
+version = "0.8"
+`,
+			},
+			unpinned: []string{
+				"Cargo.toml:8:serde@1.0",
+				"Cargo.toml:13:nix@0.26",
+				"Cargo.toml:15:rand@0.8",
+			},
+			manifests: map[string]string{"Cargo.toml": ""},
+		},
+		{
+			name: "Gemfile",
+			files: map[string]string{
+				"Gemfile": `source "https://rubygems.org"
+
+gem "rails", "~> 7.0"
+gem 'rake'
+`,
+				"site/Gemfile":      "gem 'jekyll'\n",
+				"site/Gemfile.lock": "",
+			},
+			unpinned: []string{
+				"Gemfile:3:rails@~> 7.0",
+				"Gemfile:4:rake",
+			},
+			manifests: map[string]string{
+				"Gemfile":      "",
+				"site/Gemfile": "site/Gemfile.lock",
+			},
+		},
+		{
+			name: "pom.xml version ranges",
+			files: map[string]string{
+				"pom.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <dependencies>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>[4.0,5.0)</version>
+    </dependency>
+    <dependency>
+      <groupId>com.google.guava</groupId>
+      <artifactId>guava</artifactId>
+      <version>32.1.2-jre</version>
+    </dependency>
+    <dependency>
+      <groupId>org.example</groupId>
+      <artifactId>latest</artifactId>
+      <version>LATEST</version>
+    </dependency>
+    <dependency>
+      <groupId>org.example</groupId>
+      <artifactId>property</artifactId>
+      <version>${example.version}</version>
+    </dependency>
+  </dependencies>
+</project>
+`,
+			},
+			unpinned: []string{
+				"pom.xml:4:junit:junit@[4.0,5.0)",
+				"pom.xml:14:org.example:latest@LATEST",
+			},
+			manifests: map[string]string{"pom.xml": ""},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var files []string
+					for f := range tt.files {
+						match, err := predicate(f)
+						if err != nil {
+							return nil, err
+						}
+						if match {
+							files = append(files, f)
+						}
+					}
+					return files, nil
+				})
+			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(
+				func(f string) ([]byte, error) {
+					return []byte(tt.files[f]), nil
+				}).AnyTimes()
+
+			c := checker.CheckRequest{RepoClient: mockRepoClient}
+			var r checker.PinningDependenciesData
+			if err := collectManifestPinning(&c, &r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			unpinned := []string{}
+			for _, d := range r.Dependencies {
+				if d.Type != checker.DependencyUseTypeManifest {
+					t.Errorf("unexpected dependency type: %v", d.Type)
+				}
+				s := fmt.Sprintf("%s:%d:%s", d.Location.Path, d.Location.Offset, *d.Name)
+				if d.PinnedAt != nil {
+					s += "@" + *d.PinnedAt
+				}
+				unpinned = append(unpinned, s)
+			}
+			if tt.unpinned == nil {
+				tt.unpinned = []string{}
+			}
+			if diff := cmp.Diff(tt.unpinned, unpinned); diff != "" {
+				t.Errorf("unexpected unpinned dependencies (-want +got):\n%s", diff)
+			}
+
+			var manifests map[string]string
+			for _, m := range r.Manifests {
+				if manifests == nil {
+					manifests = make(map[string]string)
+				}
+				manifests[m.File.Path] = ""
+				if m.Lockfile != nil {
+					manifests[m.File.Path] = m.Lockfile.Path
+				}
+			}
+			if diff := cmp.Diff(tt.manifests, manifests); diff != "" {
+				t.Errorf("unexpected manifests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
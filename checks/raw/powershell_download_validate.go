@@ -0,0 +1,548 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// windowsShell is a shell that cannot be parsed by mvdan.cc/sh/v3/syntax.
+type windowsShell int
+
+const (
+	// shellPowerShell is Windows PowerShell or PowerShell Core.
+	shellPowerShell windowsShell = iota
+	// shellCmd is the Windows command prompt.
+	shellCmd
+)
+
+var (
+	powershellNames = []string{"pwsh", "powershell", "pwsh.exe", "powershell.exe"}
+	cmdNames        = []string{"cmd", "cmd.exe"}
+
+	// powershellDownload matches the cmdlets, aliases and methods which return downloaded content.
+	powershellDownload = regexp.MustCompile(
+		`(?i)((^|[\s(|;&{=])(invoke-webrequest|iwr|invoke-restmethod|irm|curl|wget)(\.exe)?(\s|$))|\.download(string|data)(async)?\s*\(`)
+	// powershellExecute matches the cmdlets and methods which execute a script from a string.
+	powershellExecute = regexp.MustCompile(
+		`(?i)((^|[\s(|;&{])(invoke-expression|iex)(\s|$|\())|\[scriptblock\]::create\s*\(|\|\s*&?\s*(pwsh|powershell)(\.exe)?(\s|$)`)
+	// powershellDownloadFile matches the WebClient.DownloadFile(url, path) method.
+	powershellDownloadFile = regexp.MustCompile(`(?i)\.downloadfile(async)?\s*\(\s*[^,]+,\s*([^)]+)\)`)
+	// powershellAssignment matches the variable assigned by a statement, e.g., $script = irm $url.
+	powershellAssignment = regexp.MustCompile(`^\$([\w:]+)\s*=`)
+	powershellVariable   = regexp.MustCompile(`\$\{?([\w:]+)`)
+
+	powershellDownloadCommands = []string{
+		"invoke-webrequest", "iwr", "invoke-restmethod", "irm", "curl", "curl.exe", "wget", "wget.exe",
+	}
+	// powershellModuleCommands install PowerShell modules and scripts from a gallery.
+	powershellModuleCommands = []string{
+		"install-module", "install-script", "install-package", "install-psresource",
+		"save-module", "save-script", "save-psresource", "update-module",
+	}
+)
+
+// windowsStatement is a single command of a PowerShell or batch script.
+type windowsStatement struct {
+	text      string
+	startLine uint
+	endLine   uint
+}
+
+// isPowerShellScriptFile returns true if the file is a PowerShell script.
+func isPowerShellScriptFile(pathfn string) bool {
+	return strings.EqualFold(path.Ext(pathfn), ".ps1")
+}
+
+// isBatchScriptFile returns true if the file is a Windows batch script.
+func isBatchScriptFile(pathfn string) bool {
+	ext := path.Ext(pathfn)
+	return strings.EqualFold(ext, ".bat") || strings.EqualFold(ext, ".cmd")
+}
+
+// windowsShellForName returns the Windows shell for a GitHub workflow `shell`, e.g., `pwsh` or `cmd`.
+func windowsShellForName(name string) (windowsShell, bool) {
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	switch {
+	case isAnyBinaryName(powershellNames, fields[0]):
+		return shellPowerShell, true
+	case isAnyBinaryName(cmdNames, fields[0]):
+		return shellCmd, true
+	}
+	return 0, false
+}
+
+func isAnyBinaryName(expected []string, name string) bool {
+	// Windows paths use backslashes.
+	name = strings.ReplaceAll(name, `\`, "/")
+	for _, e := range expected {
+		if isBinaryName(e, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWindowsScript records the insecure downloads and unpinned installs of a PowerShell or batch script.
+// Line numbers are relative to startLine.
+func validateWindowsScript(pathfn string, startLine uint, content []byte, shell windowsShell,
+	taintedFiles map[string]bool, r *checker.PinningDependenciesData,
+) {
+	for _, stmt := range splitWindowsStatements(string(content), shell) {
+		stmt.startLine += startLine
+		stmt.endLine += startLine
+		validateWindowsStatement(pathfn, stmt, shell, taintedFiles, r)
+	}
+}
+
+func validateWindowsStatement(pathfn string, stmt windowsStatement, shell windowsShell,
+	taintedFiles map[string]bool, r *checker.PinningDependenciesData,
+) {
+	cmd := windowsFields(stmt.text, shell)
+	if len(cmd) == 0 {
+		return
+	}
+
+	// Scripts passed to another shell, e.g., powershell -Command "iwr ... | iex".
+	if script, nested, ok := getWindowsNestedScript(cmd); ok {
+		validateWindowsStatements(pathfn, stmt, script, nested, taintedFiles, r)
+		return
+	}
+
+	if shell == shellPowerShell {
+		download := powershellDownload.MatchString(stmt.text)
+		execute := powershellExecute.MatchString(stmt.text)
+		// iwr https://example.com/install.ps1 | iex.
+		if download && execute {
+			recordWindowsDependency(pathfn, stmt, checker.DependencyUseTypeDownloadThenRun, r)
+			return
+		}
+		// $script = irm https://example.com/install.ps1.
+		if m := powershellAssignment.FindStringSubmatch(stmt.text); download && m != nil {
+			taintedFiles["$"+strings.ToLower(m[1])] = true
+			return
+		}
+		// iex $script.
+		if execute {
+			for _, m := range powershellVariable.FindAllStringSubmatch(stmt.text, -1) {
+				if taintedFiles["$"+strings.ToLower(m[1])] {
+					recordWindowsDependency(pathfn, stmt, checker.DependencyUseTypeDownloadThenRun, r)
+					return
+				}
+			}
+		}
+	}
+
+	if fn, ok := getWindowsDownloadedFile(stmt.text, cmd, shell); ok {
+		taintedFiles[normalizeWindowsPath(fn)] = true
+		return
+	}
+
+	if fn, ok := getWindowsExecutedFile(cmd); ok && taintedFiles[normalizeWindowsPath(fn)] {
+		recordWindowsDependency(pathfn, stmt, checker.DependencyUseTypeDownloadThenRun, r)
+		return
+	}
+
+	if t, ok := getWindowsUnpinnedInstall(cmd); ok {
+		recordWindowsDependency(pathfn, stmt, t, r)
+	}
+}
+
+// validateWindowsStatements validates a script nested in a statement, e.g., the -Command of powershell.
+func validateWindowsStatements(pathfn string, stmt windowsStatement, script string, shell windowsShell,
+	taintedFiles map[string]bool, r *checker.PinningDependenciesData,
+) {
+	for _, nested := range splitWindowsStatements(script, shell) {
+		// Report the location of the outer statement.
+		nested.startLine = stmt.startLine
+		nested.endLine = stmt.endLine
+		validateWindowsStatement(pathfn, nested, shell, taintedFiles, r)
+	}
+}
+
+func recordWindowsDependency(pathfn string, stmt windowsStatement, t checker.DependencyUseType,
+	r *checker.PinningDependenciesData,
+) {
+	r.Dependencies = append(r.Dependencies,
+		checker.Dependency{
+			Location: &checker.File{
+				Path:      pathfn,
+				Type:      finding.FileTypeSource,
+				Offset:    stmt.startLine,
+				EndOffset: stmt.endLine,
+				Snippet:   stmt.text,
+			},
+			Type: t,
+		},
+	)
+}
+
+// splitWindowsStatements splits a script in statements, joining continued lines and skipping comments.
+// Line numbers start at 1.
+func splitWindowsStatements(content string, shell windowsShell) []windowsStatement {
+	var statements []windowsStatement
+	var current strings.Builder
+	var startLine uint
+	inBlockComment := false
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lineNumber := uint(i + 1)
+
+		// PowerShell block comments: <# ... #>.
+		if shell == shellPowerShell {
+			if inBlockComment {
+				end := strings.Index(line, "#>")
+				if end < 0 {
+					continue
+				}
+				line = line[end+2:]
+				inBlockComment = false
+			}
+			if start := strings.Index(line, "<#"); start >= 0 {
+				if end := strings.Index(line[start:], "#>"); end >= 0 {
+					line = line[:start] + line[start+end+2:]
+				} else {
+					line = line[:start]
+					inBlockComment = true
+				}
+			}
+		}
+
+		line = stripWindowsComment(line, shell)
+		trimmed := strings.TrimSpace(line)
+		if current.Len() == 0 {
+			if trimmed == "" {
+				continue
+			}
+			startLine = lineNumber
+		}
+
+		// Continued lines: a trailing backtick or pipe in PowerShell, a trailing caret in batch files.
+		continued := false
+		switch {
+		case shell == shellPowerShell && strings.HasSuffix(trimmed, "`"):
+			trimmed = strings.TrimSuffix(trimmed, "`")
+			continued = true
+		case shell == shellPowerShell && strings.HasSuffix(trimmed, "|"):
+			continued = true
+		case shell == shellCmd && strings.HasSuffix(trimmed, "^"):
+			trimmed = strings.TrimSuffix(trimmed, "^")
+			continued = true
+		}
+		if current.Len() > 0 {
+			current.WriteString(" ")
+		}
+		current.WriteString(trimmed)
+		if continued && i < len(lines)-1 {
+			continue
+		}
+
+		for _, text := range splitWindowsCommands(current.String(), shell) {
+			statements = append(statements, windowsStatement{
+				text:      text,
+				startLine: startLine,
+				endLine:   lineNumber,
+			})
+		}
+		current.Reset()
+	}
+	return statements
+}
+
+// stripWindowsComment removes a comment at the end of the line.
+func stripWindowsComment(line string, shell windowsShell) string {
+	if shell == shellCmd {
+		trimmed := strings.ToLower(strings.TrimLeft(strings.TrimSpace(line), "@"))
+		if strings.HasPrefix(trimmed, "::") || trimmed == "rem" || strings.HasPrefix(trimmed, "rem ") {
+			return ""
+		}
+		return line
+	}
+
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#':
+			// # is only a comment at the start of a token, e.g., not in $a#b.
+			if i == 0 || strings.ContainsRune(" \t;|{}(", rune(line[i-1])) {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// splitWindowsCommands splits a line on command separators which are not quoted.
+func splitWindowsCommands(line string, shell windowsShell) []string {
+	var commands []string
+	var quote rune
+	start := 0
+	add := func(end int) {
+		if text := strings.TrimSpace(line[start:end]); text != "" {
+			commands = append(commands, text)
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		c := rune(line[i])
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || (c == '\'' && shell == shellPowerShell):
+			quote = c
+		case c == '^' && shell == shellCmd:
+			// Escaped character.
+			i++
+		case strings.HasPrefix(line[i:], "&&") || strings.HasPrefix(line[i:], "||"):
+			add(i)
+			i++
+			start = i + 1
+		// & is the call operator in PowerShell.
+		case c == '&' && shell == shellCmd,
+			(c == ';' || c == '{' || c == '}') && shell == shellPowerShell:
+			add(i)
+			start = i + 1
+		}
+	}
+	add(len(line))
+	return commands
+}
+
+// windowsFields splits a command in unquoted arguments.
+func windowsFields(text string, shell windowsShell) []string {
+	var fields []string
+	var field strings.Builder
+	var quote rune
+	inField := false
+	for _, c := range text {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+				continue
+			}
+			field.WriteRune(c)
+		case c == '"' || (c == '\'' && shell == shellPowerShell):
+			quote = c
+			inField = true
+		case c == ' ' || c == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(c)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// getWindowsNestedScript returns the script run by `powershell -Command ...` or `cmd /c ...`.
+func getWindowsNestedScript(cmd []string) (string, windowsShell, bool) {
+	switch {
+	case isAnyBinaryName(powershellNames, cmd[0]):
+		for i := 1; i < len(cmd)-1; i++ {
+			switch strings.ToLower(cmd[i]) {
+			case "-c", "-command":
+				return strings.Join(cmd[i+1:], " "), shellPowerShell, true
+			case "-f", "-file":
+				return "", 0, false
+			}
+		}
+	case isAnyBinaryName(cmdNames, cmd[0]):
+		for i := 1; i < len(cmd)-1; i++ {
+			if strings.EqualFold(cmd[i], "/c") || strings.EqualFold(cmd[i], "/k") {
+				return strings.Join(cmd[i+1:], " "), shellCmd, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+// getWindowsDownloadedFile returns the file written by a download command.
+func getWindowsDownloadedFile(text string, cmd []string, shell windowsShell) (string, bool) {
+	// (New-Object Net.WebClient).DownloadFile($url, $path).
+	if shell == shellPowerShell {
+		if m := powershellDownloadFile.FindStringSubmatch(text); m != nil {
+			return strings.Trim(strings.TrimSpace(m[2]), `'"`), true
+		}
+	}
+
+	name := strings.ToLower(strings.ReplaceAll(cmd[0], `\`, "/"))
+	name = path.Base(name)
+	switch {
+	// Invoke-WebRequest $url -OutFile $path, curl.exe -o $path $url.
+	case slices.Contains(powershellDownloadCommands, name):
+		for i := 1; i < len(cmd)-1; i++ {
+			switch strings.ToLower(cmd[i]) {
+			case "-outfile", "-o", "--output":
+				return cmd[i+1], true
+			}
+		}
+	// Start-BitsTransfer -Source $url -Destination $path.
+	case name == "start-bitstransfer":
+		for i := 1; i < len(cmd)-1; i++ {
+			if strings.EqualFold(cmd[i], "-destination") {
+				return cmd[i+1], true
+			}
+		}
+		if len(cmd) == 3 && !strings.HasPrefix(cmd[1], "-") && !strings.HasPrefix(cmd[2], "-") {
+			return cmd[2], true
+		}
+	// certutil -urlcache -split -f $url $path.
+	case name == "certutil" || name == "certutil.exe":
+		for _, arg := range cmd[1:] {
+			if strings.EqualFold(arg, "-urlcache") && len(cmd) > 2 {
+				return cmd[len(cmd)-1], true
+			}
+		}
+	// bitsadmin /transfer $name $url $path.
+	case name == "bitsadmin" || name == "bitsadmin.exe":
+		for _, arg := range cmd[1:] {
+			if strings.EqualFold(arg, "/transfer") && len(cmd) > 3 {
+				return cmd[len(cmd)-1], true
+			}
+		}
+	}
+	return "", false
+}
+
+// getWindowsExecutedFile returns the script or binary executed by a command.
+func getWindowsExecutedFile(cmd []string) (string, bool) {
+	name := strings.ToLower(cmd[0])
+	switch {
+	// & $path, . $path, call $path.
+	case name == "&" || name == "." || name == "call":
+		if len(cmd) > 1 {
+			return cmd[1], true
+		}
+	// pwsh -File $path, powershell -ExecutionPolicy Bypass $path.
+	case isAnyBinaryName(powershellNames, name):
+		for i := 1; i < len(cmd)-1; i++ {
+			if strings.EqualFold(cmd[i], "-file") || strings.EqualFold(cmd[i], "-f") {
+				return cmd[i+1], true
+			}
+		}
+		for _, arg := range cmd[1:] {
+			if isPowerShellScriptFile(strings.ToLower(arg)) {
+				return arg, true
+			}
+		}
+	// Start-Process -FilePath $path, start $path.
+	case name == "start-process" || name == "saps" || name == "start":
+		for i := 1; i < len(cmd); i++ {
+			if strings.EqualFold(cmd[i], "-filepath") && i+1 < len(cmd) {
+				return cmd[i+1], true
+			}
+			if !strings.HasPrefix(cmd[i], "-") {
+				return cmd[i], true
+			}
+		}
+	// msiexec /i $path.
+	case name == "msiexec" || name == "msiexec.exe":
+		for i := 1; i < len(cmd)-1; i++ {
+			if strings.EqualFold(cmd[i], "/i") || strings.EqualFold(cmd[i], "/package") {
+				return cmd[i+1], true
+			}
+		}
+	default:
+		// & is optional for paths which are not quoted, e.g., .\install.ps1.
+		return cmd[0], true
+	}
+	return "", false
+}
+
+// normalizeWindowsPath makes paths comparable, e.g., .\install.ps1 and install.ps1.
+func normalizeWindowsPath(fn string) string {
+	fn = strings.ToLower(strings.ReplaceAll(fn, `\`, "/"))
+	fn = strings.Trim(fn, `'"()`)
+	return strings.TrimPrefix(path.Clean(fn), "./")
+}
+
+// getWindowsUnpinnedInstall returns the type of an install command which does not pin a version.
+func getWindowsUnpinnedInstall(cmd []string) (checker.DependencyUseType, bool) {
+	switch {
+	case isPowerShellModuleUnpinnedInstall(cmd):
+		return checker.DependencyUseTypePowerShellCommand, true
+	case isWingetUnpinnedInstall(cmd):
+		return checker.DependencyUseTypeWingetCommand, true
+	case isChocoUnpinnedDownload(cmd):
+		return checker.DependencyUseTypeChocoCommand, true
+	case isNugetUnpinnedDownload(cmd):
+		return checker.DependencyUseTypeNugetCommand, true
+	case isPipUnpinnedDownload(cmd):
+		return checker.DependencyUseTypePipCommand, true
+	case isNpmUnpinnedDownload(cmd):
+		return checker.DependencyUseTypeNpmCommand, true
+	case isGoUnpinnedDownload(cmd):
+		return checker.DependencyUseTypeGoCommand, true
+	}
+	return "", false
+}
+
+func isPowerShellModuleUnpinnedInstall(cmd []string) bool {
+	// Install-Module -Name Pester -RequiredVersion 5.5.0.
+	if !slices.Contains(powershellModuleCommands, strings.ToLower(cmd[0])) {
+		return false
+	}
+	for i := 1; i < len(cmd)-1; i++ {
+		if strings.EqualFold(cmd[i], "-requiredversion") || strings.EqualFold(cmd[i], "-version") {
+			// Version ranges, e.g., -Version '[1.0,2.0)', are not pinned.
+			return strings.ContainsAny(cmd[i+1], "[](),*")
+		}
+	}
+	return true
+}
+
+func isWingetUnpinnedInstall(cmd []string) bool {
+	// winget install --id Git.Git --version 2.42.0 --exact.
+	if len(cmd) < 2 || !isAnyBinaryName([]string{"winget", "winget.exe"}, cmd[0]) {
+		return false
+	}
+	if !strings.EqualFold(cmd[1], "install") && !strings.EqualFold(cmd[1], "add") {
+		return false
+	}
+	for _, arg := range cmd[2:] {
+		switch strings.ToLower(arg) {
+		// A local manifest includes the hash of the installer.
+		case "-v", "--version", "-m", "--manifest":
+			return false
+		}
+	}
+	return true
+}
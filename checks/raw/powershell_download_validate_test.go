@@ -0,0 +1,132 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func TestWindowsScriptInsecureDownloads(t *testing.T) {
+	t.Parallel()
+	type dependency struct {
+		t         checker.DependencyUseType
+		startLine uint
+		endLine   uint
+	}
+	tests := []struct {
+		name     string
+		filename string
+		expected []dependency
+	}{
+		{
+			name:     "powershell",
+			filename: "./testdata/script-powershell.ps1",
+			expected: []dependency{
+				{t: checker.DependencyUseTypeDownloadThenRun, startLine: 21, endLine: 21},
+				{t: checker.DependencyUseTypeDownloadThenRun, startLine: 22, endLine: 22},
+				{t: checker.DependencyUseTypeDownloadThenRun, startLine: 23, endLine: 23},
+				{t: checker.DependencyUseTypeDownloadThenRun, startLine: 27, endLine: 27},
+				{t: checker.DependencyUseTypeDownloadThenRun, startLine: 30, endLine: 30},
+				{t: checker.DependencyUseTypeDownloadThenRun, startLine: 33, endLine: 33},
+				{t: checker.DependencyUseTypePowerShellCommand, startLine: 35, endLine: 35},
+				{t: checker.DependencyUseTypePowerShellCommand, startLine: 37, endLine: 37},
+				{t: checker.DependencyUseTypeChocoCommand, startLine: 38, endLine: 38},
+				{t: checker.DependencyUseTypeWingetCommand, startLine: 40, endLine: 40},
+				{t: checker.DependencyUseTypePipCommand, startLine: 42, endLine: 42},
+			},
+		},
+		{
+			name:     "batch",
+			filename: "./testdata/script-batch.cmd",
+			expected: []dependency{
+				{t: checker.DependencyUseTypeDownloadThenRun, startLine: 18, endLine: 18},
+				{t: checker.DependencyUseTypeDownloadThenRun, startLine: 20, endLine: 20},
+				{t: checker.DependencyUseTypeDownloadThenRun, startLine: 22, endLine: 22},
+				{t: checker.DependencyUseTypeChocoCommand, startLine: 24, endLine: 25},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile(tt.filename)
+			if err != nil {
+				t.Fatalf("cannot read file: %v", err)
+			}
+
+			var r checker.PinningDependenciesData
+			if _, err := validateShellScriptIsFreeOfInsecureDownloads(tt.filename, content, &r); err != nil {
+				t.Fatalf("error during validateShellScriptIsFreeOfInsecureDownloads: %v", err)
+			}
+
+			var got []dependency
+			for _, dep := range r.Dependencies {
+				got = append(got, dependency{
+					t:         dep.Type,
+					startLine: dep.Location.Offset,
+					endLine:   dep.Location.EndOffset,
+				})
+			}
+			if diff := cmp.Diff(tt.expected, got, cmp.AllowUnexported(dependency{})); diff != "" {
+				t.Errorf("unexpected dependencies (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSplitWindowsCommands(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		line     string
+		shell    windowsShell
+		expected []string
+	}{
+		{
+			name:     "powershell separators",
+			line:     `iwr $url -OutFile a.ps1; & ./a.ps1 && echo "a; b"`,
+			shell:    shellPowerShell,
+			expected: []string{"iwr $url -OutFile a.ps1", "& ./a.ps1", `echo "a; b"`},
+		},
+		{
+			name:     "powershell script block",
+			line:     `Invoke-Command -ScriptBlock { irm $url | iex }`,
+			shell:    shellPowerShell,
+			expected: []string{"Invoke-Command -ScriptBlock", "irm $url | iex"},
+		},
+		{
+			name:     "cmd separators",
+			line:     `curl -o a.bat %URL% & a.bat || echo "a & b" ^& c`,
+			shell:    shellCmd,
+			expected: []string{"curl -o a.bat %URL%", "a.bat", `echo "a & b" ^& c`},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := splitWindowsCommands(tt.line, tt.shell)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("unexpected commands (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// RepositorySettings retrieves the raw data for the Repository-Settings check.
+// Settings the client cannot read, e.g., because the token lacks the scope, are left unset.
+func RepositorySettings(c clients.RepoClient) (checker.RepositorySettingsData, error) {
+	settings, err := c.GetSecuritySettings()
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return checker.RepositorySettingsData{}, nil
+	}
+	if err != nil {
+		return checker.RepositorySettingsData{}, fmt.Errorf("Client.GetSecuritySettings: %w", err)
+	}
+	return checker.RepositorySettingsData{
+		Settings: *settings,
+	}, nil
+}
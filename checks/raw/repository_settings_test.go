@@ -0,0 +1,75 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func TestRepositorySettings(t *testing.T) {
+	t.Parallel()
+	enabled := true
+	tests := []struct {
+		name     string
+		settings *clients.SecuritySettings
+		err      error
+		want     checker.RepositorySettingsData
+		wantErr  bool
+	}{
+		{
+			name:     "settings",
+			settings: &clients.SecuritySettings{SecretScanning: &enabled},
+			want: checker.RepositorySettingsData{
+				Settings: clients.SecuritySettings{SecretScanning: &enabled},
+			},
+		},
+		{
+			name: "token lacks the scope",
+			err:  fmt.Errorf("%w: admin access is required", clients.ErrUnsupportedFeature),
+			want: checker.RepositorySettingsData{},
+		},
+		{
+			name:    "error",
+			err:     errors.New("connection reset"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().GetSecuritySettings().Return(tt.settings, tt.err)
+
+			got, err := RepositorySettings(mockRepoClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RepositorySettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("RepositorySettings() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
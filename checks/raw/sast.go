@@ -0,0 +1,597 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/rhysd/actionlint"
+	"golang.org/x/exp/slices"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/checks/raw/gitlab"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// SAST tools that report check runs, by app slug.
+var sastTools = map[string]string{
+	"github-code-scanning": "GitHub code scanning",
+	"lgtm-com":             "LGTM",
+	"sonarcloud":           "SonarCloud",
+	"semgrep-app":          "Semgrep",
+}
+
+var allowedConclusions = map[string]bool{"success": true, "neutral": true}
+
+var sonarHostURL = regexp.MustCompile(`<sonar\.host\.url>\s*(\S+)\s*<\/sonar\.host\.url>`)
+
+// sastTool describes how to detect a SAST tool in workflows.
+type sastTool struct {
+	name string
+	// action matches the GitHub Actions that run the tool.
+	action *regexp.Regexp
+	// actionCommand, if set, must match the `command` input of the action.
+	actionCommand *regexp.Regexp
+	// command matches the commands and container images that run the tool.
+	command   *regexp.Regexp
+	languages []clients.LanguageName
+}
+
+var sastWorkflowTools = []sastTool{
+	{
+		name:    "CodeQL",
+		action:  regexp.MustCompile(`^github/codeql-action/analyze$`),
+		command: regexp.MustCompile(`\bcodeql\s+database\s+analyze\b`),
+		// Refined using the languages input of github/codeql-action/init.
+		languages: codeQLLanguages["all"],
+	},
+	{
+		name:    "Semgrep",
+		action:  regexp.MustCompile(`^(returntocorp|semgrep)/semgrep-action$`),
+		command: regexp.MustCompile(`\bsemgrep\s+(ci|scan|--config)\b|^(docker\.io/)?(returntocorp|semgrep)/semgrep(:|@|$)`),
+	},
+	{
+		name:          "Snyk Code",
+		action:        regexp.MustCompile(`^snyk/actions/[\w-]+$`),
+		actionCommand: regexp.MustCompile(`^\s*code\s+test\b`),
+		command:       regexp.MustCompile(`\bsnyk\s+code\s+test\b`),
+	},
+	{
+		name:      "gosec",
+		action:    regexp.MustCompile(`^securego/gosec$`),
+		command:   regexp.MustCompile(`(^|[\s/])gosec\s|^(docker\.io/)?securego/gosec(:|@|$)`),
+		languages: []clients.LanguageName{clients.Go},
+	},
+	{
+		name:      "Bandit",
+		action:    regexp.MustCompile(`(?i)/[\w-]*bandit[\w-]*$`),
+		command:   regexp.MustCompile(`(^|[\s/])bandit\s`),
+		languages: []clients.LanguageName{clients.Python},
+	},
+	{
+		name:      "Brakeman",
+		action:    regexp.MustCompile(`(?i)/[\w-]*brakeman[\w-]*$`),
+		command:   regexp.MustCompile(`(^|[\s/])brakeman(\s|$)`),
+		languages: []clients.LanguageName{clients.Ruby},
+	},
+	{
+		name:      "SpotBugs",
+		action:    regexp.MustCompile(`(?i)/[\w-]*spotbugs[\w-]*$`),
+		command:   regexp.MustCompile(`(?i)\bspotbugs`),
+		languages: []clients.LanguageName{clients.Java, clients.Kotlin, clients.Scala},
+	},
+	{
+		name: "ESLint security plugins",
+		command: regexp.MustCompile(`eslint-plugin-(security|no-unsanitized)\b|@microsoft/eslint-plugin-sdl\b|` +
+			`\beslint\b.*--plugin[= ]+(security|no-unsanitized|@microsoft/sdl)\b`),
+		languages: []clients.LanguageName{clients.JavaScript, clients.TypeScript},
+	},
+	{
+		name:   "Sonar",
+		action: regexp.MustCompile(`^SonarSource/(sonarcloud|sonarqube)-scan-action$`),
+	},
+}
+
+// Languages analyzed by CodeQL, by the names used in the languages input of github/codeql-action/init.
+// See https://codeql.github.com/docs/codeql-overview/supported-languages-and-frameworks/.
+var codeQLLanguages = map[string][]clients.LanguageName{
+	"all": {
+		clients.C, clients.Cpp, clients.CSharp, clients.Go, clients.Java, clients.Kotlin,
+		clients.JavaScript, clients.TypeScript, clients.Python, clients.Ruby, clients.Swift,
+	},
+	"c":                     {clients.C, clients.Cpp},
+	"cpp":                   {clients.C, clients.Cpp},
+	"c-cpp":                 {clients.C, clients.Cpp},
+	"csharp":                {clients.CSharp},
+	"go":                    {clients.Go},
+	"java":                  {clients.Java, clients.Kotlin},
+	"kotlin":                {clients.Java, clients.Kotlin},
+	"java-kotlin":           {clients.Java, clients.Kotlin},
+	"javascript":            {clients.JavaScript, clients.TypeScript},
+	"typescript":            {clients.JavaScript, clients.TypeScript},
+	"javascript-typescript": {clients.JavaScript, clients.TypeScript},
+	"python":                {clients.Python},
+	"ruby":                  {clients.Ruby},
+	"swift":                 {clients.Swift},
+}
+
+// GitLab SAST analyzers, by job name.
+// See https://docs.gitlab.com/ee/user/application_security/sast/analyzers.html.
+var gitlabSASTAnalyzers = map[string]sastTool{
+	"semgrep-sast":  {name: "Semgrep"},
+	"gosec-sast":    {name: "gosec", languages: []clients.LanguageName{clients.Go}},
+	"bandit-sast":   {name: "Bandit", languages: []clients.LanguageName{clients.Python}},
+	"brakeman-sast": {name: "Brakeman", languages: []clients.LanguageName{clients.Ruby}},
+	"spotbugs-sast": {
+		name:      "SpotBugs",
+		languages: []clients.LanguageName{clients.Java, clients.Kotlin, clients.Scala},
+	},
+	"eslint-sast": {
+		name:      "ESLint security plugins",
+		languages: []clients.LanguageName{clients.JavaScript, clients.TypeScript},
+	},
+	"nodejs-scan-sast": {
+		name:      "NodeJsScan",
+		languages: []clients.LanguageName{clients.JavaScript, clients.TypeScript},
+	},
+	"flawfinder-sast":           {name: "Flawfinder", languages: []clients.LanguageName{clients.C, clients.Cpp}},
+	"phpcs-security-audit-sast": {name: "phpcs-security-audit", languages: []clients.LanguageName{clients.PHP}},
+	"security-code-scan-sast":   {name: "Security Code Scan", languages: []clients.LanguageName{clients.CSharp}},
+}
+
+// SAST retrieves the raw data for the SAST check.
+func SAST(c *checker.CheckRequest) (checker.SASTData, error) {
+	data := checker.SASTData{
+		Workflows: []checker.SASTWorkflow{},
+	}
+
+	commits, err := sastToolInCheckRuns(c)
+	if err != nil {
+		return data, err
+	}
+	data.Commits = commits
+
+	err = fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+		Pattern:       ".github/workflows/*",
+		CaseSensitive: false,
+	}, searchGitHubActionWorkflowSAST, &data.Workflows)
+	if err != nil {
+		return data, err
+	}
+	// The GitLab client flattens the CI configuration, including .gitlab-ci.yml, into one file.
+	err = fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+		Pattern:       "gitlabscorecard_flattened_ci.yaml",
+		CaseSensitive: true,
+	}, searchGitLabCISAST, &data.Workflows, c.Dlogger)
+	if err != nil {
+		return data, err
+	}
+	err = fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+		Pattern:       "*",
+		CaseSensitive: false,
+	}, validateSonarConfig, &data.Workflows)
+	if err != nil {
+		return data, err
+	}
+
+	if len(data.Workflows) > 0 {
+		data.Languages, err = sastLanguages(c.RepoClient)
+		if err != nil {
+			return data, err
+		}
+	}
+	return data, nil
+}
+
+func sastToolInCheckRuns(c *checker.CheckRequest) ([]checker.SASTCommit, error) {
+	commits, err := c.RepoClient.ListCommits()
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("RepoClient.ListCommits: %v", err))
+	}
+
+	var ret []checker.SASTCommit
+	for i := range commits {
+		pr := commits[i].AssociatedMergeRequest
+		// TODO(#575): We ignore associated PRs if Scorecard is being run on a fork
+		// but the PR was created in the original repo.
+		if pr.MergedAt.IsZero() {
+			continue
+		}
+		commit := checker.SASTCommit{
+			HeadSHA:           pr.HeadSHA,
+			PullRequestNumber: pr.Number,
+		}
+		crs, err := c.RepoClient.ListCheckRunsForRef(pr.HeadSHA)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("Client.Checks.ListCheckRunsForRef: %v", err))
+		}
+		// Note: crs may be `nil`: in this case
+		// the loop below will be skipped.
+		for _, cr := range crs {
+			if cr.Status != "completed" {
+				continue
+			}
+			if !allowedConclusions[cr.Conclusion] {
+				continue
+			}
+			if tool, ok := sastTools[cr.App.Slug]; ok {
+				commit.Tool = tool
+				commit.URL = cr.URL
+				break
+			}
+		}
+		ret = append(ret, commit)
+	}
+	return ret, nil
+}
+
+// sastLanguages returns the languages of the repository that are analyzed
+// by a known SAST tool, or nil if they cannot be determined.
+func sastLanguages(client clients.RepoClient) ([]clients.LanguageName, error) {
+	languages, err := client.ListProgrammingLanguages()
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("RepoClient.ListProgrammingLanguages: %v", err))
+	}
+
+	ret := []clients.LanguageName{}
+	for _, l := range languages {
+		name := clients.LanguageName(strings.ToLower(string(l.Name)))
+		if isSASTLanguage(name) && !slices.Contains(ret, name) {
+			ret = append(ret, name)
+		}
+	}
+	return ret, nil
+}
+
+// isSASTLanguage returns true if one of the known SAST tools analyzes the language.
+func isSASTLanguage(language clients.LanguageName) bool {
+	for _, languages := range codeQLLanguages {
+		if slices.Contains(languages, language) {
+			return true
+		}
+	}
+	for i := range sastWorkflowTools {
+		if slices.Contains(sastWorkflowTools[i].languages, language) {
+			return true
+		}
+	}
+	for _, tool := range gitlabSASTAnalyzers {
+		if slices.Contains(tool.languages, language) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check file content.
+var searchGitHubActionWorkflowSAST fileparser.DoWhileTrueOnFileContent = func(path string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if !fileparser.IsWorkflowFile(path) {
+		return true, nil
+	}
+
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"searchGitHubActionWorkflowSAST requires exactly 1 arguments: %w", errInvalidArgLength)
+	}
+
+	// Verify the type of the data.
+	workflows, ok := args[0].(*[]checker.SASTWorkflow)
+	if !ok {
+		return false, fmt.Errorf(
+			"searchGitHubActionWorkflowSAST expects arg[0] of type *[]checker.SASTWorkflow: %w", errInvalidArgType)
+	}
+
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 && workflow == nil {
+		return false, fileparser.FormatActionlintError(errs)
+	}
+
+	triggers := workflowTriggers(workflow)
+	for _, job := range workflow.Jobs {
+		if job == nil {
+			continue
+		}
+		add := func(tool *sastTool, pos *actionlint.Pos) {
+			w := checker.SASTWorkflow{
+				Type: checker.SASTWorkflowCI,
+				Tool: tool.name,
+				File: checker.File{
+					Path:   path,
+					Type:   finding.FileTypeSource,
+					Offset: fileparser.GetLineNumber(pos),
+				},
+				Triggers:  triggers,
+				Languages: tool.languages,
+			}
+			if tool.name == "CodeQL" {
+				w.Languages = codeQLJobLanguages(job)
+			}
+			*workflows = append(*workflows, w)
+		}
+
+		if job.Container != nil && job.Container.Image != nil {
+			if tool := matchSASTCommand(job.Container.Image.Value); tool != nil {
+				add(tool, job.Container.Image.Pos)
+			}
+		}
+		for _, step := range job.Steps {
+			if step == nil {
+				continue
+			}
+			var tool *sastTool
+			switch e := step.Exec.(type) {
+			case *actionlint.ExecAction:
+				if e.Uses == nil {
+					continue
+				}
+				tool = matchSASTAction(e)
+			case *actionlint.ExecRun:
+				if e.Run == nil {
+					continue
+				}
+				for _, line := range strings.Split(e.Run.Value, "\n") {
+					if tool = matchSASTCommand(line); tool != nil {
+						break
+					}
+				}
+			}
+			if tool != nil {
+				add(tool, step.Pos)
+			}
+		}
+	}
+	return true, nil
+}
+
+func matchSASTAction(e *actionlint.ExecAction) *sastTool {
+	uses := strings.TrimPrefix(e.Uses.Value, "actions://")
+	if strings.HasPrefix(uses, "docker://") {
+		return matchSASTCommand(strings.TrimPrefix(uses, "docker://"))
+	}
+	action, _, _ := strings.Cut(uses, "@")
+	for i := range sastWorkflowTools {
+		tool := &sastWorkflowTools[i]
+		if tool.action == nil || !tool.action.MatchString(action) {
+			continue
+		}
+		if tool.actionCommand != nil {
+			command, ok := e.Inputs["command"]
+			if !ok || command == nil || command.Value == nil || !tool.actionCommand.MatchString(command.Value.Value) {
+				continue
+			}
+		}
+		return tool
+	}
+	return nil
+}
+
+func matchSASTCommand(command string) *sastTool {
+	command = strings.TrimSpace(command)
+	for i := range sastWorkflowTools {
+		if sastWorkflowTools[i].command != nil && sastWorkflowTools[i].command.MatchString(command) {
+			return &sastWorkflowTools[i]
+		}
+	}
+	return nil
+}
+
+// codeQLJobLanguages returns the languages analyzed by CodeQL in a job.
+func codeQLJobLanguages(job *actionlint.Job) []clients.LanguageName {
+	for _, step := range job.Steps {
+		e, ok := step.Exec.(*actionlint.ExecAction)
+		if !ok || e == nil || e.Uses == nil {
+			continue
+		}
+		action, _, _ := strings.Cut(strings.TrimPrefix(e.Uses.Value, "actions://"), "@")
+		if action != "github/codeql-action/init" {
+			continue
+		}
+		input, ok := e.Inputs["languages"]
+		if !ok || input == nil || input.Value == nil {
+			break
+		}
+		var languages []clients.LanguageName
+		for _, value := range fileparser.ExpandMatrixExpression(job, strings.TrimSpace(input.Value.Value)) {
+			for _, l := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+				for _, language := range codeQLLanguages[strings.ToLower(l)] {
+					if !slices.Contains(languages, language) {
+						languages = append(languages, language)
+					}
+				}
+			}
+		}
+		if len(languages) > 0 {
+			return languages
+		}
+		break
+	}
+	// CodeQL detects the languages of the repository.
+	return codeQLLanguages["all"]
+}
+
+func workflowTriggers(workflow *actionlint.Workflow) []checker.SASTTrigger {
+	var triggers []checker.SASTTrigger
+	add := func(trigger checker.SASTTrigger) {
+		if !slices.Contains(triggers, trigger) {
+			triggers = append(triggers, trigger)
+		}
+	}
+	for _, event := range workflow.On {
+		switch event.EventName() {
+		case "pull_request", "pull_request_target", "merge_group":
+			add(checker.SASTTriggerPullRequest)
+		case "push":
+			add(checker.SASTTriggerPush)
+		case "schedule":
+			add(checker.SASTTriggerSchedule)
+		}
+	}
+	return triggers
+}
+
+// Check file content.
+var searchGitLabCISAST fileparser.DoWhileTrueOnFileContent = func(path string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if isGitlabCI, _ := fileparser.IsGitlabWorkflowFile(path); !isGitlabCI {
+		return true, nil
+	}
+
+	if len(args) != 2 {
+		return false, fmt.Errorf(
+			"searchGitLabCISAST requires exactly 2 arguments: %w", errInvalidArgLength)
+	}
+
+	// Verify the type of the data.
+	workflows, ok := args[0].(*[]checker.SASTWorkflow)
+	if !ok {
+		return false, fmt.Errorf(
+			"searchGitLabCISAST expects arg[0] of type *[]checker.SASTWorkflow: %w", errInvalidArgType)
+	}
+	dl, ok := args[1].(checker.DetailLogger)
+	if !ok {
+		return false, fmt.Errorf(
+			"searchGitLabCISAST expects arg[1] of type checker.DetailLogger: %w", errInvalidArgType)
+	}
+
+	jobs, err := gitlab.SASTJobs(content)
+	if err != nil {
+		// A malformed configuration does not prevent analyzing the rest of the repository.
+		dl.Debug(&checker.LogMessage{
+			Path: path,
+			Type: finding.FileTypeSource,
+			Text: fmt.Sprintf("cannot parse GitLab CI configuration: %v", err),
+		})
+		return true, nil
+	}
+	for i := range jobs {
+		tool, ok := gitlabSASTAnalyzers[jobs[i].Name]
+		if !ok {
+			// Templates and custom jobs that produce SAST reports.
+			tool = sastTool{name: "GitLab SAST"}
+		}
+		w := checker.SASTWorkflow{
+			Type: checker.SASTWorkflowCI,
+			Tool: tool.name,
+			File: checker.File{
+				Path:   path,
+				Type:   finding.FileTypeSource,
+				Offset: jobs[i].Line,
+			},
+			Languages: tool.languages,
+		}
+		if jobs[i].MergeRequests {
+			w.Triggers = append(w.Triggers, checker.SASTTriggerPullRequest)
+		}
+		if jobs[i].Branches {
+			w.Triggers = append(w.Triggers, checker.SASTTriggerPush)
+		}
+		if jobs[i].Schedules {
+			w.Triggers = append(w.Triggers, checker.SASTTriggerSchedule)
+		}
+		*workflows = append(*workflows, w)
+	}
+	return true, nil
+}
+
+// Check file content.
+var validateSonarConfig fileparser.DoWhileTrueOnFileContent = func(pathfn string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if !strings.EqualFold(path.Base(pathfn), "pom.xml") {
+		return true, nil
+	}
+
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"validateSonarConfig requires exactly 1 argument: %w", errInvalidArgLength)
+	}
+
+	// Verify the type of the data.
+	pdata, ok := args[0].(*[]checker.SASTWorkflow)
+	if !ok {
+		return false, fmt.Errorf(
+			"validateSonarConfig expects arg[0] of type *[]checker.SASTWorkflow: %w", errInvalidArgType)
+	}
+
+	match := sonarHostURL.FindSubmatch(content)
+	if len(match) < 2 {
+		return true, nil
+	}
+
+	offset, err := findLine(content, []byte("<sonar.host.url>"))
+	if err != nil {
+		return false, err
+	}
+
+	endOffset, err := findLine(content, []byte("</sonar.host.url>"))
+	if err != nil {
+		return false, err
+	}
+
+	*pdata = append(*pdata, checker.SASTWorkflow{
+		Type: checker.SASTWorkflowSonarConfig,
+		Tool: "Sonar",
+		File: checker.File{
+			Path:      pathfn,
+			Type:      finding.FileTypeSource,
+			Offset:    offset,
+			EndOffset: endOffset,
+			Snippet:   string(match[1]),
+		},
+	})
+
+	return true, nil
+}
+
+func findLine(content, data []byte) (uint, error) {
+	r := bytes.NewReader(content)
+	scanner := bufio.NewScanner(r)
+
+	line := 0
+	// https://golang.org/pkg/bufio/#Scanner.Scan
+	for scanner.Scan() {
+		line++
+		if strings.Contains(scanner.Text(), string(data)) {
+			return uint(line), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("scanner.Err(): %w", err)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,298 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func Test_validateSonarConfig(t *testing.T) {
+	t.Parallel()
+
+	//nolint: govet
+	tests := []struct {
+		name      string
+		path      string
+		offset    uint
+		endOffset uint
+		url       string
+		score     int
+	}{
+		{
+			name:      "sonartype config 1 line",
+			path:      "../testdata/pom-1line.xml",
+			offset:    2,
+			endOffset: 2,
+			url:       "https://sonarqube.private.domain",
+		},
+		{
+			name:      "sonartype config 2 lines",
+			path:      "../testdata/pom-2lines.xml",
+			offset:    2,
+			endOffset: 4,
+			url:       "https://sonarqube.private.domain",
+		},
+		{
+			name: "wrong filename",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var config []checker.SASTWorkflow
+			var content []byte
+			var err error
+			var path string
+			if tt.path != "" {
+				content, err = os.ReadFile(tt.path)
+				if err != nil {
+					t.Errorf("ReadFile: %v", err)
+				}
+				path = "pom.xml"
+			}
+			_, err = validateSonarConfig(path, content, &config)
+			if err != nil {
+				t.Errorf("Caught error: %v", err)
+			}
+
+			if path == "" {
+				if len(config) != 0 {
+					t.Errorf("Expected no result, got %d for %v", len(config), tt.name)
+				}
+				return
+			}
+			if len(config) != 1 {
+				t.Errorf("Expected 1 result, got %d for %v", len(config), tt.name)
+			}
+
+			if config[0].File.Offset != tt.offset {
+				t.Errorf("Expected offset %d, got %d for %v", tt.offset,
+					config[0].File.Offset, tt.name)
+			}
+
+			if config[0].File.EndOffset != tt.endOffset {
+				t.Errorf("Expected offset %d, got %d for %v", tt.endOffset,
+					config[0].File.EndOffset, tt.name)
+			}
+
+			if config[0].File.Snippet != tt.url {
+				t.Errorf("Expected url %v, got %v for %v", tt.url,
+					config[0].File.Snippet, tt.name)
+			}
+		})
+	}
+}
+
+func Test_searchGitHubActionWorkflowSAST_invalid(t *testing.T) {
+	t.Parallel()
+
+	//nolint: govet
+	tests := []struct {
+		name string
+		path string
+		args []any
+	}{
+		{
+			name: "too few arguments",
+			path: ".github/workflows/github-workflow-sast-codeql.yaml",
+			args: []any{},
+		},
+		{
+			name: "wrong arguments",
+			path: ".github/workflows/github-workflow-sast-codeql.yaml",
+			args: []any{
+				&[]int{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var content []byte
+			var err error
+			if tt.path != "" {
+				content, err = os.ReadFile("../testdata/" + tt.path)
+				if err != nil {
+					t.Errorf("ReadFile: %v", err)
+				}
+			}
+			_, err = searchGitHubActionWorkflowSAST(tt.path, content, tt.args...)
+			if err == nil {
+				t.Errorf("Expected error but err was nil")
+			}
+		})
+	}
+}
+
+func Test_searchGitHubActionWorkflowSAST(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		tool      string
+		line      uint
+		languages []clients.LanguageName
+	}
+	want := []result{
+		{tool: "CodeQL", line: 30, languages: []clients.LanguageName{clients.Go, clients.Python}},
+		{tool: "Semgrep", line: 34},
+		{tool: "Semgrep", line: 36},
+		{tool: "Snyk Code", line: 43},
+		{tool: "gosec", line: 49, languages: []clients.LanguageName{clients.Go}},
+		{tool: "Bandit", line: 52, languages: []clients.LanguageName{clients.Python}},
+		{tool: "Brakeman", line: 53, languages: []clients.LanguageName{clients.Ruby}},
+		{tool: "SpotBugs", line: 54, languages: []clients.LanguageName{clients.Java, clients.Kotlin, clients.Scala}},
+		{
+			tool:      "ESLint security plugins",
+			line:      55,
+			languages: []clients.LanguageName{clients.JavaScript, clients.TypeScript},
+		},
+	}
+
+	path := ".github/workflows/github-workflow-sast-tools.yaml"
+	content, err := os.ReadFile("../testdata/" + path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var workflows []checker.SASTWorkflow
+	if _, err := searchGitHubActionWorkflowSAST(path, content, &workflows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []result
+	for i := range workflows {
+		if diff := cmp.Diff([]checker.SASTTrigger{checker.SASTTriggerPullRequest, checker.SASTTriggerSchedule}, workflows[i].Triggers); diff != "" {
+			t.Errorf("unexpected triggers for %s (-want +got):\n%s", workflows[i].Tool, diff)
+		}
+		got = append(got, result{
+			tool:      workflows[i].Tool,
+			line:      workflows[i].File.Offset,
+			languages: workflows[i].Languages,
+		})
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].line < got[j].line })
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(result{})); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_sastLanguages(t *testing.T) {
+	t.Parallel()
+
+	//nolint:govet
+	tests := []struct {
+		name      string
+		languages []clients.Language
+		err       error
+		want      []clients.LanguageName
+	}{
+		{
+			name: "sast languages",
+			languages: []clients.Language{
+				{Name: clients.Go}, {Name: clients.Python}, {Name: clients.Dockerfile}, {Name: "Java"},
+			},
+			want: []clients.LanguageName{clients.Go, clients.Python, clients.Java},
+		},
+		{
+			name:      "no sast languages",
+			languages: []clients.Language{{Name: clients.Dockerfile}},
+			want:      []clients.LanguageName{},
+		},
+		{
+			name: "unsupported",
+			err:  clients.ErrUnsupportedFeature,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListProgrammingLanguages().Return(tt.languages, tt.err)
+			got, err := sastLanguages(mockRepoClient)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_searchGitLabCISAST(t *testing.T) {
+	t.Parallel()
+	content := []byte(`include:
+  - template: Jobs/SAST.gitlab-ci.yml
+`)
+	tests := []struct {
+		name      string
+		path      string
+		content   []byte
+		workflows int
+		debug     int
+	}{
+		{
+			name:      "flattened configuration",
+			path:      "gitlabscorecard_flattened_ci.yaml",
+			content:   content,
+			workflows: 1,
+		},
+		{
+			// The jobs of .gitlab-ci.yml are part of the flattened configuration.
+			name:    ".gitlab-ci.yml",
+			path:    ".gitlab-ci.yml",
+			content: content,
+		},
+		{
+			name:    "malformed configuration",
+			path:    "gitlabscorecard_flattened_ci.yaml",
+			content: []byte("include: ["),
+			debug:   1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var workflows []checker.SASTWorkflow
+			dl := scut.TestDetailLogger{}
+			cont, err := searchGitLabCISAST(tt.path, tt.content, &workflows, &dl)
+			if err != nil || !cont {
+				t.Fatalf("searchGitLabCISAST() = %v, %v", cont, err)
+			}
+			if len(workflows) != tt.workflows {
+				t.Errorf("expected %d workflows, got %d", tt.workflows, len(workflows))
+			}
+			if n := len(dl.Flush()); n != tt.debug {
+				t.Errorf("expected %d debug messages, got %d", tt.debug, n)
+			}
+		})
+	}
+}
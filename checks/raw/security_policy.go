@@ -16,6 +16,7 @@ package raw
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"path"
@@ -35,51 +36,101 @@ type securityPolicyFilesWithURI struct {
 }
 
 // SecurityPolicy checks for presence of security policy
-// and applicable content discovered by checkSecurityPolicyFileContent().
+// and applicable content discovered by checkSecurityPolicyFileContent(),
+// and for the published advisories and private vulnerability reporting.
 func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error) {
+	files, err := securityPolicyFiles(c)
+	if err != nil {
+		return checker.SecurityPolicyData{}, err
+	}
+	data := checker.SecurityPolicyData{PolicyFiles: files}
+
+	// The advisories and the setting are not scored, so they are left unset, i.e., not available,
+	// when they cannot be read, rather than failing the check.
+	advisories, err := c.RepoClient.ListSecurityAdvisories()
+	switch {
+	case err == nil:
+		data.Advisories = advisories
+	case !errors.Is(err, clients.ErrUnsupportedFeature):
+		c.Dlogger.Debug(&checker.LogMessage{
+			Text: fmt.Sprintf("cannot list the security advisories: %v", err),
+		})
+	}
+
+	enabled, err := c.RepoClient.GetPrivateVulnerabilityReporting()
+	switch {
+	case err == nil:
+		data.PrivateVulnerabilityReporting = &enabled
+	case !errors.Is(err, clients.ErrUnsupportedFeature):
+		c.Dlogger.Debug(&checker.LogMessage{
+			Text: fmt.Sprintf("cannot read the private vulnerability reporting setting: %v", err),
+		})
+	}
+	return data, nil
+}
+
+// securityPolicyFiles returns the security policy files of the repository. The security
+// policy document of its parent organization is used if the repository has none.
+func securityPolicyFiles(c *checker.CheckRequest) ([]checker.SecurityPolicyFile, error) {
 	data := securityPolicyFilesWithURI{
 		uri: "", files: make([]checker.SecurityPolicyFile, 0),
 	}
 	err := fileparser.OnAllFilesDo(c.RepoClient, isSecurityPolicyFile, &data)
 	if err != nil {
-		return checker.SecurityPolicyData{}, err
+		return nil, err
 	}
-	// If we found files in the repo, return immediately.
-	if len(data.files) > 0 {
-		for idx := range data.files {
-			err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
-				Pattern:       data.files[idx].File.Path,
-				CaseSensitive: false,
-			}, checkSecurityPolicyFileContent, &data.files[idx].File, &data.files[idx].Information)
-			if err != nil {
-				return checker.SecurityPolicyData{}, err
-			}
+	hasPolicy := false
+	for idx := range data.files {
+		err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+			Pattern:       data.files[idx].File.Path,
+			CaseSensitive: false,
+		}, checkSecurityPolicyFileContent, &data.files[idx].File, &data.files[idx].Information)
+		if err != nil {
+			return nil, err
 		}
-		return checker.SecurityPolicyData{PolicyFiles: data.files}, nil
+		hasPolicy = hasPolicy || !data.files[idx].IsSecurityTxt()
+	}
+	// If we found a security policy document in the repo, return immediately.
+	// A security.txt file does not replace the policy of the parent org.
+	if hasPolicy {
+		return data.files, nil
 	}
 
 	// Check if present in parent org.
 	// https#://docs.github.com/en/github/building-a-strong-community/creating-a-default-community-health-file.
 	client, err := c.RepoClient.GetOrgRepoClient(c.Ctx)
 
+	orgData := securityPolicyFilesWithURI{
+		uri: "", files: make([]checker.SecurityPolicyFile, 0),
+	}
 	switch {
 	case err == nil:
 		defer client.Close()
-		data.uri = client.URI()
-		err = fileparser.OnAllFilesDo(client, isSecurityPolicyFile, &data)
+		orgData.uri = client.URI()
+		err = fileparser.OnAllFilesDo(client, isSecurityPolicyFile, &orgData)
 		if err != nil {
-			return checker.SecurityPolicyData{}, fmt.Errorf("unable to create github client: %w", err)
+			return nil, fmt.Errorf("unable to create github client: %w", err)
 		}
 
 	case errors.Is(err, sce.ErrRepoUnreachable), errors.Is(err, clients.ErrUnsupportedFeature):
 		break
 	default:
-		return checker.SecurityPolicyData{}, err
+		return nil, err
 	}
 
-	// Return raw results.
-	if len(data.files) > 0 {
-		for idx := range data.files {
+	// The security.txt file of the repo, if any, takes precedence over the org's.
+	var orgFiles []checker.SecurityPolicyFile
+	for idx := range orgData.files {
+		if len(data.files) == 0 || !orgData.files[idx].IsSecurityTxt() {
+			orgFiles = append(orgFiles, orgData.files[idx])
+		}
+	}
+
+	// Return raw results, with the security policy document first.
+	if len(orgFiles) > 0 {
+		data.uri = orgData.uri
+		data.files = append(orgFiles, data.files...)
+		for idx := range orgFiles {
 			filePattern := data.files[idx].File.Path
 			// undo path.Join in isSecurityPolicyFile just
 			// for this call to OnMatchingFileContentsDo
@@ -91,11 +142,11 @@ func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error)
 				CaseSensitive: false,
 			}, checkSecurityPolicyFileContent, &data.files[idx].File, &data.files[idx].Information)
 			if err != nil {
-				return checker.SecurityPolicyData{}, err
+				return nil, err
 			}
 		}
 	}
-	return checker.SecurityPolicyData{PolicyFiles: data.files}, nil
+	return data.files, nil
 }
 
 // Check repository for repository-specific policy.
@@ -108,32 +159,50 @@ var isSecurityPolicyFile fileparser.DoWhileTrueOnFilename = func(name string, ar
 	if !ok {
 		return false, fmt.Errorf("invalid arg type: %w", errInvalidArgType)
 	}
-	if isSecurityPolicyFilename(name) {
-		tempPath := name
-		tempType := finding.FileTypeText
-		if pdata.uri != "" {
-			// report complete path for org-based policy files
-			tempPath = path.Join(pdata.uri, tempPath)
-			// FileTypeURL is used in Security-Policy to
-			// only denote for the details report that the
-			// policy was found at the org level rather
-			// than the repo level
-			tempType = finding.FileTypeURL
-		}
-		pdata.files = append(pdata.files, checker.SecurityPolicyFile{
-			File: checker.File{
-				Path:     tempPath,
-				Type:     tempType,
-				Offset:   checker.OffsetDefault,
-				FileSize: checker.OffsetDefault,
-			},
-			Information: make([]checker.SecurityPolicyInformation, 0),
-		})
-		// TODO: change 'false' to 'true' when multiple security policy files are supported
-		// otherwise this check stops at the first security policy found
-		return false, nil
+	isSecurityTxt := isSecurityTxtFilename(name)
+	if !isSecurityTxt && !isSecurityPolicyFilename(name) {
+		return true, nil
 	}
-	return true, nil
+	// At most one security policy document and one security.txt file are reported.
+	hasPolicy, hasSecurityTxt := false, false
+	for i := range pdata.files {
+		if pdata.files[i].IsSecurityTxt() {
+			hasSecurityTxt = true
+		} else {
+			hasPolicy = true
+		}
+	}
+	if (isSecurityTxt && hasSecurityTxt) || (!isSecurityTxt && hasPolicy) {
+		return true, nil
+	}
+	tempPath := name
+	tempType := finding.FileTypeText
+	if pdata.uri != "" {
+		// report complete path for org-based policy files
+		tempPath = path.Join(pdata.uri, tempPath)
+		// FileTypeURL is used in Security-Policy to
+		// only denote for the details report that the
+		// policy was found at the org level rather
+		// than the repo level
+		tempType = finding.FileTypeURL
+	}
+	file := checker.SecurityPolicyFile{
+		File: checker.File{
+			Path:     tempPath,
+			Type:     tempType,
+			Offset:   checker.OffsetDefault,
+			FileSize: checker.OffsetDefault,
+		},
+		Information: make([]checker.SecurityPolicyInformation, 0),
+	}
+	// the security policy document, if any, is reported first.
+	if isSecurityTxt {
+		pdata.files = append(pdata.files, file)
+	} else {
+		pdata.files = append([]checker.SecurityPolicyFile{file}, pdata.files...)
+	}
+	// stop once both a security policy document and a security.txt file are found.
+	return !(hasPolicy || hasSecurityTxt), nil
 }
 
 func isSecurityPolicyFilename(name string) bool {
@@ -152,6 +221,14 @@ func isSecurityPolicyFilename(name string) bool {
 		strings.EqualFold(name, "docs/security.rst")
 }
 
+// isSecurityTxtFilename returns true for RFC 9116 security.txt files,
+// e.g., the `.well-known/security.txt` file of a website hosted in the repository.
+func isSecurityTxtFilename(name string) bool {
+	return strings.EqualFold(name, "security.txt") ||
+		strings.EqualFold(name, ".well-known/security.txt") ||
+		strings.HasSuffix(strings.ToLower(name), "/.well-known/security.txt")
+}
+
 var checkSecurityPolicyFileContent fileparser.DoWhileTrueOnFileContent = func(path string, content []byte,
 	args ...interface{},
 ) (bool, error) {
@@ -181,6 +258,11 @@ var checkSecurityPolicyFileContent fileparser.DoWhileTrueOnFileContent = func(pa
 		pfiles.Offset = checker.OffsetDefault
 		pfiles.FileSize = uint(len(content))
 		policyHits := collectPolicyHits(content)
+		if isSecurityTxtFilename(path) {
+			policyHits = append(policyHits, collectSecurityTxtFields(content)...)
+		} else {
+			policyHits = append(policyHits, collectPolicySections(content)...)
+		}
 		if len(policyHits) > 0 {
 			(*pinfo) = append((*pinfo), policyHits...)
 		}
@@ -252,3 +334,109 @@ func collectPolicyHits(policyContent []byte) []checker.SecurityPolicyInformation
 
 	return hits
 }
+
+var (
+	// security.txt fields, see https://www.rfc-editor.org/rfc/rfc9116#section-2.5.
+	reSecurityTxtField = regexp.MustCompile(`^([A-Za-z-]+):[ \t]*(\S.*?)\s*$`)
+	securityTxtFields  = map[string]checker.SecurityPolicyInformationType{
+		"contact":    checker.SecurityPolicyInformationTypeContact,
+		"expires":    checker.SecurityPolicyInformationTypeExpires,
+		"encryption": checker.SecurityPolicyInformationTypeEncryption,
+		"policy":     checker.SecurityPolicyInformationTypePolicy,
+	}
+)
+
+// collectSecurityTxtFields returns the Contact, Expires, Encryption and Policy
+// fields of a security.txt file, which may be signed with OpenPGP.
+func collectSecurityTxtFields(content []byte) []checker.SecurityPolicyInformation {
+	var hits []checker.SecurityPolicyInformation
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if strings.HasPrefix(line, "-----BEGIN PGP SIGNATURE-----") {
+			break
+		}
+		// lines of signed messages starting with a dash are escaped, see RFC 4880.
+		offset := 0
+		if strings.HasPrefix(line, "- ") {
+			offset = 2
+		}
+		m := reSecurityTxtField.FindStringSubmatchIndex(line[offset:])
+		if m == nil {
+			continue
+		}
+		infoType, ok := securityTxtFields[strings.ToLower(line[offset+m[2]:offset+m[3]])]
+		if !ok {
+			continue
+		}
+		hits = append(hits, checker.SecurityPolicyInformation{
+			InformationType: infoType,
+			InformationValue: checker.SecurityPolicyValueType{
+				Match:      line[offset+m[4] : offset+m[5]],
+				LineNumber: uint(lineNum),
+				Offset:     uint(offset + m[4]),
+			},
+		})
+	}
+	return hits
+}
+
+var (
+	// rows of supported versions tables, e.g., `| 5.1.x   | :white_check_mark: |`.
+	reSupportedVersion = regexp.MustCompile(`^\s*\|\s*([<>=~^v]*\s*[0-9]+(?:\.(?:[0-9]+|x|X|\*))*[^|]*?)\s*\|`)
+	reSupportStatus    = regexp.MustCompile(`(?i)(✅|❌|✔|✖|✗|:white_check_mark:|:heavy_check_mark:|:x:|:no_entry:|` +
+		`\byes\b|\bno\b|\bsupported\b|\bunsupported\b|\bend[- ]of[- ]life\b|\beol\b)`)
+	// response times, e.g., "We will acknowledge your report within 48 hours".
+	reResponseTime = regexp.MustCompile(`(?i)\b(?:within|in|under|up to|no later than|at most)\s+` +
+		`(?:[0-9]+|one|two|three|four|five|six|seven|ten|fourteen|thirty|ninety)` +
+		`(?:\s*(?:-|to)\s*[0-9]+)?\s*(?:business\s+|working\s+|calendar\s+)?(?:hours?|days?|weeks?|months?)\b`)
+	reResponseContext = regexp.MustCompile(`(?i)respon|acknowledg|repl(?:y|ies)|triage|get back|fix|patch|disclos`)
+	// private reporting channels: GitHub private vulnerability reporting, GitLab confidential
+	// issues, bug bounty platforms and dedicated security email addresses.
+	rePrivateReporting = regexp.MustCompile(`(?i)(/security/advisories/new\b|` +
+		`\bprivate vulnerability report(?:ing|s)?\b|\bconfidential issues?\b|` +
+		`\b(?:hackerone\.com|bugcrowd\.com|intigriti\.com|yeswehack\.com|huntr\.(?:dev|com))(?:/[\w/-]*)?)`)
+	// the local part must start with the keyword: oss-security@ is a public mailing list.
+	reSecurityEmail = regexp.MustCompile(
+		`(?i)(?:^|[^\w.+-])((?:security|secure|psirt|vuln[\w-]*|disclosure|cert)@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+)
+
+// collectPolicySections returns the supported versions, response times
+// and private reporting channels described in a security policy document.
+func collectPolicySections(content []byte) []checker.SecurityPolicyInformation {
+	var hits []checker.SecurityPolicyInformation
+	newHit := func(infoType checker.SecurityPolicyInformationType, line string, lineNum, start, end int) {
+		hits = append(hits, checker.SecurityPolicyInformation{
+			InformationType: infoType,
+			InformationValue: checker.SecurityPolicyValueType{
+				Match:      line[start:end],
+				LineNumber: uint(lineNum),
+				Offset:     uint(start),
+			},
+		})
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if m := reSupportedVersion.FindStringSubmatchIndex(line); m != nil && reSupportStatus.MatchString(line[m[1]:]) {
+			newHit(checker.SecurityPolicyInformationTypeSupportedVersions, line, lineNum, m[2], m[3])
+		}
+		if reResponseContext.MatchString(line) {
+			for _, m := range reResponseTime.FindAllStringIndex(line, -1) {
+				newHit(checker.SecurityPolicyInformationTypeResponseTime, line, lineNum, m[0], m[1])
+			}
+		}
+		for _, m := range rePrivateReporting.FindAllStringIndex(line, -1) {
+			newHit(checker.SecurityPolicyInformationTypePrivateReporting, line, lineNum, m[0], m[1])
+		}
+		for _, m := range reSecurityEmail.FindAllStringSubmatchIndex(line, -1) {
+			newHit(checker.SecurityPolicyInformationTypePrivateReporting, line, lineNum, m[2], m[3])
+		}
+	}
+	return hits
+}
@@ -15,13 +15,17 @@
 package raw
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
 	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
 	scut "github.com/ossf/scorecard/v4/utests"
 )
@@ -48,6 +52,11 @@ func Test_isSecurityPolicyFilename(t *testing.T) {
 			filename: "doc/security.rst",
 			expected: true,
 		},
+		{
+			name:     "security.txt",
+			filename: ".well-known/security.txt",
+			expected: false,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -60,17 +69,135 @@ func Test_isSecurityPolicyFilename(t *testing.T) {
 	}
 }
 
+func Test_isSecurityTxtFilename(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		filename string
+		expected bool
+	}{
+		{filename: "security.txt", expected: true},
+		{filename: ".well-known/security.txt", expected: true},
+		{filename: "website/static/.well-known/security.txt", expected: true},
+		{filename: "docs/security.txt", expected: false},
+		{filename: "SECURITY.md", expected: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.filename, func(t *testing.T) {
+			t.Parallel()
+			if got := isSecurityTxtFilename(tt.filename); got != tt.expected {
+				t.Errorf("isSecurityTxtFilename() = %v, want %v for %v", got, tt.expected, tt.filename)
+			}
+		})
+	}
+}
+
+func Test_collectSecurityTxtFields(t *testing.T) {
+	t.Parallel()
+	content := `-----BEGIN PGP SIGNED MESSAGE-----
+Hash: SHA256
+
+# Our security policy
+Contact: mailto:security@example.com
+contact: https://example.com/security/report
+Expires: 2030-12-31T23:00:00.000Z
+Encryption: https://example.com/pgp-key.txt
+Policy: https://example.com/security-policy.html
+Preferred-Languages: en, fr
+- Acknowledgments: https://example.com/hall-of-fame.html
+-----BEGIN PGP SIGNATURE-----
+Policy: not a field
+-----END PGP SIGNATURE-----
+`
+	want := []checker.SecurityPolicyInformation{
+		{
+			InformationType:  checker.SecurityPolicyInformationTypeContact,
+			InformationValue: checker.SecurityPolicyValueType{Match: "mailto:security@example.com", LineNumber: 5, Offset: 9},
+		},
+		{
+			InformationType:  checker.SecurityPolicyInformationTypeContact,
+			InformationValue: checker.SecurityPolicyValueType{Match: "https://example.com/security/report", LineNumber: 6, Offset: 9},
+		},
+		{
+			InformationType:  checker.SecurityPolicyInformationTypeExpires,
+			InformationValue: checker.SecurityPolicyValueType{Match: "2030-12-31T23:00:00.000Z", LineNumber: 7, Offset: 9},
+		},
+		{
+			InformationType:  checker.SecurityPolicyInformationTypeEncryption,
+			InformationValue: checker.SecurityPolicyValueType{Match: "https://example.com/pgp-key.txt", LineNumber: 8, Offset: 12},
+		},
+		{
+			InformationType:  checker.SecurityPolicyInformationTypePolicy,
+			InformationValue: checker.SecurityPolicyValueType{Match: "https://example.com/security-policy.html", LineNumber: 9, Offset: 8},
+		},
+	}
+	if diff := cmp.Diff(want, collectSecurityTxtFields([]byte(content))); diff != "" {
+		t.Errorf("collectSecurityTxtFields() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_collectPolicySections(t *testing.T) {
+	t.Parallel()
+	content := `# Security Policy
+
+## Supported Versions
+
+| Version | Supported          |
+| ------- | ------------------ |
+| 5.1.x   | :white_check_mark: |
+| < 5.0   | :x:                |
+
+## Reporting a Vulnerability
+
+Report vulnerabilities at https://github.com/org/repo/security/advisories/new or to security@example.com.
+Do not report them to oss-security@lists.openwall.com.
+We will acknowledge your report within 48 hours, and release a fix in 2-4 weeks.
+Releases happen in 2 weeks cycles.
+`
+	want := []checker.SecurityPolicyInformation{
+		{
+			InformationType:  checker.SecurityPolicyInformationTypeSupportedVersions,
+			InformationValue: checker.SecurityPolicyValueType{Match: "5.1.x", LineNumber: 7, Offset: 2},
+		},
+		{
+			InformationType:  checker.SecurityPolicyInformationTypeSupportedVersions,
+			InformationValue: checker.SecurityPolicyValueType{Match: "< 5.0", LineNumber: 8, Offset: 2},
+		},
+		{
+			InformationType:  checker.SecurityPolicyInformationTypePrivateReporting,
+			InformationValue: checker.SecurityPolicyValueType{Match: "/security/advisories/new", LineNumber: 12, Offset: 53},
+		},
+		{
+			InformationType:  checker.SecurityPolicyInformationTypePrivateReporting,
+			InformationValue: checker.SecurityPolicyValueType{Match: "security@example.com", LineNumber: 12, Offset: 84},
+		},
+		{
+			InformationType:  checker.SecurityPolicyInformationTypeResponseTime,
+			InformationValue: checker.SecurityPolicyValueType{Match: "within 48 hours", LineNumber: 14, Offset: 32},
+		},
+		{
+			InformationType:  checker.SecurityPolicyInformationTypeResponseTime,
+			InformationValue: checker.SecurityPolicyValueType{Match: "in 2-4 weeks", LineNumber: 14, Offset: 67},
+		},
+	}
+	if diff := cmp.Diff(want, collectPolicySections([]byte(content))); diff != "" {
+		t.Errorf("collectPolicySections() mismatch (-want +got):\n%s", diff)
+	}
+}
+
 // TestSecurityPolicy tests the security policy.
 func TestSecurityPolicy(t *testing.T) {
 	t.Parallel()
 	//nolint
 	tests := []struct {
-		name    string
-		files   []string
-		path    string
-		result  checker.SecurityPolicyData
-		wantErr bool
-		want    scut.TestReturn
+		name      string
+		files     []string
+		orgFiles  []string
+		path      string
+		wantPaths []string
+		result    checker.SecurityPolicyData
+		wantErr   bool
+		want      scut.TestReturn
 	}{
 		{
 			name: "security.md",
@@ -128,6 +255,29 @@ func TestSecurityPolicy(t *testing.T) {
 			},
 			path: "",
 		},
+		{
+			name: "security.txt and security.md",
+			files: []string{
+				".well-known/security.txt",
+				"security.md",
+			},
+			path: "",
+			// the security policy document is reported first.
+			wantPaths: []string{"security.md", ".well-known/security.txt"},
+		},
+		{
+			name: "security.txt and org security.md",
+			files: []string{
+				".well-known/security.txt",
+			},
+			orgFiles: []string{
+				"security.txt",
+				"SECURITY.md",
+			},
+			path: "",
+			// the security.txt file of the repo takes precedence over the org's.
+			wantPaths: []string{"github.com/org/.github/SECURITY.md", ".well-known/security.txt"},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -138,6 +288,8 @@ func TestSecurityPolicy(t *testing.T) {
 			mockRepo := mockrepo.NewMockRepo(ctrl)
 
 			mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return(tt.files, nil).AnyTimes()
+			mockRepoClient.EXPECT().ListSecurityAdvisories().Return(nil, clients.ErrUnsupportedFeature).AnyTimes()
+			mockRepoClient.EXPECT().GetPrivateVulnerabilityReporting().Return(false, clients.ErrUnsupportedFeature).AnyTimes()
 			// the revised Security Policy will immediate go for the
 			// file contents once found. This test will return that
 			// mock file, but this specific unit test is not testing
@@ -156,6 +308,19 @@ func TestSecurityPolicy(t *testing.T) {
 				return content, nil
 			}).AnyTimes()
 
+			mockRepoClient.EXPECT().GetOrgRepoClient(gomock.Any()).DoAndReturn(
+				func(context.Context) (clients.RepoClient, error) {
+					if tt.orgFiles == nil {
+						return nil, clients.ErrUnsupportedFeature
+					}
+					orgClient := mockrepo.NewMockRepoClient(ctrl)
+					orgClient.EXPECT().URI().Return("github.com/org/.github").AnyTimes()
+					orgClient.EXPECT().ListFiles(gomock.Any()).Return(tt.orgFiles, nil).AnyTimes()
+					orgClient.EXPECT().GetFileContent(gomock.Any()).Return(nil, nil).AnyTimes()
+					orgClient.EXPECT().Close().Return(nil).AnyTimes()
+					return orgClient, nil
+				}).AnyTimes()
+
 			dl := scut.TestDetailLogger{}
 			c := checker.CheckRequest{
 				RepoClient: mockRepoClient,
@@ -174,9 +339,100 @@ func TestSecurityPolicy(t *testing.T) {
 				return
 			}
 
-			if (res.PolicyFiles[0].File.Path) != (tt.files[0]) {
+			if tt.wantPaths != nil {
+				var paths []string
+				for i := range res.PolicyFiles {
+					paths = append(paths, res.PolicyFiles[i].File.Path)
+				}
+				if diff := cmp.Diff(tt.wantPaths, paths); diff != "" {
+					t.Errorf("test failed: the files returned are not correct (-want +got):\n%s", diff)
+				}
+			} else if (res.PolicyFiles[0].File.Path) != (tt.files[0]) {
 				t.Errorf("test failed: the file returned is not correct: %+v", res)
 			}
 		})
 	}
 }
+
+func TestSecurityPolicyVulnerabilityHandling(t *testing.T) {
+	t.Parallel()
+	enabled := true
+	advisories := []clients.SecurityAdvisory{
+		{ID: "GHSA-xxxx-xxxx-xxxx", CVE: "CVE-2023-0001"},
+	}
+	tests := []struct {
+		name           string
+		advisories     []clients.SecurityAdvisory
+		advisoriesErr  error
+		reporting      bool
+		reportingErr   error
+		wantAdvisories []clients.SecurityAdvisory
+		wantPVR        *bool
+		wantDebug      int
+	}{
+		{
+			name:           "advisories and settings",
+			advisories:     advisories,
+			reporting:      true,
+			wantAdvisories: advisories,
+			wantPVR:        &enabled,
+		},
+		{
+			name:           "no advisory",
+			advisories:     []clients.SecurityAdvisory{},
+			reportingErr:   clients.ErrUnsupportedFeature,
+			wantAdvisories: []clients.SecurityAdvisory{},
+		},
+		{
+			name:          "unsupported",
+			advisoriesErr: clients.ErrUnsupportedFeature,
+			reportingErr:  clients.ErrUnsupportedFeature,
+		},
+		{
+			name:          "advisories error",
+			advisoriesErr: errors.New("connection reset"),
+			reporting:     true,
+			wantPVR:       &enabled,
+			wantDebug:     1,
+		},
+		{
+			name:           "settings error",
+			advisories:     advisories,
+			reportingErr:   errors.New("rate limit exceeded"),
+			wantAdvisories: advisories,
+			wantDebug:      1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return([]string{"SECURITY.md"}, nil).AnyTimes()
+			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).Return(nil, nil).AnyTimes()
+			mockRepoClient.EXPECT().ListSecurityAdvisories().Return(tt.advisories, tt.advisoriesErr).AnyTimes()
+			mockRepoClient.EXPECT().GetPrivateVulnerabilityReporting().Return(tt.reporting, tt.reportingErr).AnyTimes()
+			mockRepoClient.EXPECT().GetSecuritySettings().Times(0)
+
+			dl := scut.TestDetailLogger{}
+			c := checker.CheckRequest{
+				RepoClient: mockRepoClient,
+				Dlogger:    &dl,
+			}
+			got, err := SecurityPolicy(&c)
+			if err != nil {
+				t.Fatalf("SecurityPolicy() error = %v", err)
+			}
+			if debug := len(dl.Flush()); debug != tt.wantDebug {
+				t.Errorf("SecurityPolicy() logged %d debug messages, want %d", debug, tt.wantDebug)
+			}
+			if diff := cmp.Diff(tt.wantAdvisories, got.Advisories); diff != "" {
+				t.Errorf("SecurityPolicy() advisories mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantPVR, got.PrivateVulnerabilityReporting); diff != "" {
+				t.Errorf("SecurityPolicy() private vulnerability reporting mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
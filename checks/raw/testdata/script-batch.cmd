@@ -0,0 +1,26 @@
+:: Copyright 2023 OpenSSF Scorecard Authors
+::
+:: Licensed under the Apache License, Version 2.0 (the "License");
+:: you may not use this file except in compliance with the License.
+:: You may obtain a copy of the License at
+::
+::      http://www.apache.org/licenses/LICENSE-2.0
+::
+:: Unless required by applicable law or agreed to in writing, software
+:: distributed under the License is distributed on an "AS IS" BASIS,
+:: WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+:: See the License for the specific language governing permissions and
+:: limitations under the License.
+@echo off
+rem curl -o commented.bat https://example.com/install.bat && commented.bat
+
+curl -sSL -o install.bat https://example.com/install.bat
+call install.bat
+
+certutil -urlcache -split -f https://example.com/tool.exe tool.exe & tool.exe /quiet
+
+powershell -NoProfile -ExecutionPolicy Bypass -Command "iex ((New-Object System.Net.WebClient).DownloadString('https://example.com/install.ps1'))"
+
+choco install nodejs ^
+  -y
+winget install --manifest manifests\tool.yaml
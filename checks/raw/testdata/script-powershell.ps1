@@ -0,0 +1,45 @@
+# Copyright 2023 OpenSSF Scorecard Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+<#
+  iwr https://example.com/in-block-comment.ps1 | iex
+#>
+# iwr https://example.com/commented.ps1 | iex
+Set-StrictMode -Version Latest
+
+iwr https://example.com/install.ps1 -UseBasicParsing | iex
+Invoke-Expression ((New-Object System.Net.WebClient).DownloadString('https://example.com/install.ps1'))
+& ([scriptblock]::Create((Invoke-RestMethod https://example.com/install.ps1)))
+
+Invoke-WebRequest -Uri https://example.com/setup.ps1 `
+  -OutFile "$env:TEMP\setup.ps1"
+& "$env:TEMP\setup.ps1"
+
+(New-Object Net.WebClient).DownloadFile('https://example.com/tool.exe', 'tool.exe')
+Start-Process -FilePath .\tool.exe -Wait
+
+$script = Invoke-RestMethod https://example.com/install.ps1
+Invoke-Expression $script
+
+Install-Module -Name Pester -Force
+Install-Module -Name Pester -RequiredVersion 5.5.0 -Force
+Install-PSResource -Name PSScriptAnalyzer -Version '[1.0,2.0)'
+choco install git -y
+choco install git -y --requirechecksums
+winget install --id Git.Git --exact
+winget install --id Git.Git --version 2.42.0 --exact
+pip install requests
+
+Invoke-WebRequest https://example.com/data.json -OutFile data.json
+Get-Content data.json
@@ -21,6 +21,12 @@ type BranchRef struct {
 	BranchProtectionRule BranchProtectionRule
 }
 
+// BranchHead represents a branch and the commit it points to.
+type BranchHead struct {
+	Name string
+	SHA  string
+}
+
 // BranchProtectionRule captures the settings enabled on a branch for security.
 type BranchProtectionRule struct {
 	RequiredPullRequestReviews PullRequestReviewRule
@@ -19,7 +19,9 @@ type CheckRun struct {
 	Status     string
 	Conclusion string
 	URL        string
-	App        CheckRunApp
+	// Name is the name of the check run, or of the workflow for GitHub Actions check suites.
+	Name string
+	App  CheckRunApp
 }
 
 // CheckRunApp is the app running the Check.
@@ -27,6 +27,7 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	cp "github.com/otiai10/copy"
 
 	"github.com/ossf/scorecard/v4/clients"
@@ -75,7 +76,7 @@ func (c *Client) InitRepo(uri, commitSHA string, commitDepth int) error {
 	} else {
 		c.gitRepo, err = git.PlainClone(tempDir, false /*isBare*/, &git.CloneOptions{
 			URL:      uri,
-			Progress: os.Stdout,
+			Progress: os.Stderr,
 		})
 		if err != nil {
 			return fmt.Errorf("git.PlainClone: %w", err)
@@ -89,8 +90,12 @@ func (c *Client) InitRepo(uri, commitSHA string, commitDepth int) error {
 
 	// git checkout
 	if commitSHA != clients.HeadSHA {
+		hash, err := c.resolveRevision(commitSHA)
+		if err != nil {
+			return err
+		}
 		if err := c.worktree.Checkout(&git.CheckoutOptions{
-			Hash:  plumbing.NewHash(commitSHA),
+			Hash:  *hash,
 			Force: true, // throw away any unsaved changes.
 		}); err != nil {
 			return fmt.Errorf("git.Worktree: %w", err)
@@ -100,6 +105,20 @@ func (c *Client) InitRepo(uri, commitSHA string, commitDepth int) error {
 	return nil
 }
 
+// resolveRevision resolves a commit SHA, which may be abbreviated, a branch or a tag to a commit.
+// Branches other than the default one are only cloned as remote branches, e.g., origin/main.
+func (c *Client) resolveRevision(rev string) (*plumbing.Hash, error) {
+	hash, err := c.gitRepo.ResolveRevision(plumbing.Revision(rev))
+	if err == nil {
+		return hash, nil
+	}
+	remoteHash, remoteErr := c.gitRepo.ResolveRevision(plumbing.Revision("origin/"+rev))
+	if remoteErr == nil {
+		return remoteHash, nil
+	}
+	return nil, fmt.Errorf("git.ResolveRevision %s: %w", rev, err)
+}
+
 func (c *Client) ListCommits() ([]clients.Commit, error) {
 	c.listCommits.Do(func() {
 		commitIter, err := c.gitRepo.Log(&git.LogOptions{
@@ -193,12 +212,60 @@ func (c *Client) SearchCommits(request clients.SearchCommitsOptions) ([]clients.
 	return nil, nil
 }
 
+// ListFiles lists the files of the checked out commit for which predicate returns true.
 func (c *Client) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
-	return nil, nil
+	tree, err := c.headTree()
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	err = tree.Files().ForEach(func(f *object.File) error {
+		matches, err := predicate(f.Name)
+		if err != nil {
+			return err
+		}
+		if matches {
+			files = append(files, f.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tree.Files: %w", err)
+	}
+	return files, nil
 }
 
+// GetFileContent returns the content of a file of the checked out commit.
 func (c *Client) GetFileContent(filename string) ([]byte, error) {
-	return nil, nil
+	tree, err := c.headTree()
+	if err != nil {
+		return nil, err
+	}
+	f, err := tree.File(filename)
+	if err != nil {
+		return nil, fmt.Errorf("tree.File: %w", err)
+	}
+	content, err := f.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("file.Contents: %w", err)
+	}
+	return []byte(content), nil
+}
+
+func (c *Client) headTree() (*object.Tree, error) {
+	head, err := c.gitRepo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("git.Head: %w", err)
+	}
+	commit, err := c.gitRepo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("git.CommitObject: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("commit.Tree: %w", err)
+	}
+	return tree, nil
 }
 
 func (c *Client) Close() error {
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	gitV5 "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -233,3 +234,149 @@ func TestSearch(t *testing.T) {
 		})
 	}
 }
+
+func TestListFilesAndGetFileContent(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	// Record the initial commit, then change the file in a new commit.
+	r, err := gitV5.PlainOpen(repoPath)
+	if err != nil {
+		t.Fatalf("PlainOpen() failed: %v", err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		t.Fatalf("Head() failed: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(repoPath, "file"), []byte("Goodbye!"), 0o644) //nolint:gosec
+	if err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() failed: %v", err)
+	}
+	if _, err := w.Add("file"); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	_, err = w.Commit("Update file", &gitV5.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Test Author",
+			Email: "author@example.com",
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Commit() failed: %v", err)
+	}
+
+	client := &Client{}
+	uri := fmt.Sprintf("file://%s", repoPath)
+	if err := client.InitRepo(uri, head.Hash().String(), 1); err != nil {
+		t.Fatalf("InitRepo(%s) failed: %v", uri, err)
+	}
+	defer client.Close()
+
+	files, err := client.ListFiles(func(string) (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("ListFiles() failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"file"}, files); diff != "" {
+		t.Errorf("ListFiles() returned diff (-want +got):\n%s", diff)
+	}
+
+	content, err := client.GetFileContent("file")
+	if err != nil {
+		t.Fatalf("GetFileContent() failed: %v", err)
+	}
+	if string(content) != "Hello, World!" {
+		t.Errorf("GetFileContent() = %q, want the content of the checked out commit", content)
+	}
+}
+
+//nolint:paralleltest
+func TestInitRepoRevision(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	// Point a branch and a tag to the initial commit, then change the file on the default branch.
+	r, err := gitV5.PlainOpen(repoPath)
+	if err != nil {
+		t.Fatalf("PlainOpen() failed: %v", err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		t.Fatalf("Head() failed: %v", err)
+	}
+	if err := r.Storer.SetReference(plumbing.NewHashReference("refs/heads/release", head.Hash())); err != nil {
+		t.Fatalf("SetReference() failed: %v", err)
+	}
+	if _, err := r.CreateTag("v1", head.Hash(), nil); err != nil {
+		t.Fatalf("CreateTag() failed: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(repoPath, "file"), []byte("Goodbye!"), 0o644) //nolint:gosec
+	if err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() failed: %v", err)
+	}
+	if _, err := w.Add("file"); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	_, err = w.Commit("Update file", &gitV5.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Test Author",
+			Email: "author@example.com",
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Commit() failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		revision string
+		wantErr  bool
+	}{
+		{
+			name:     "branch",
+			revision: "release",
+		},
+		{
+			name:     "tag",
+			revision: "v1",
+		},
+		{
+			name:     "short SHA",
+			revision: head.Hash().String()[:7],
+		},
+		{
+			name:     "unknown revision",
+			revision: "unknown",
+			wantErr:  true,
+		},
+	}
+	uri := fmt.Sprintf("file://%s", repoPath)
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			client := &Client{}
+			err := client.InitRepo(uri, test.revision, 1)
+			defer client.Close()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("InitRepo(%s) error: %v, wantErr: %t", test.revision, err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			content, err := client.GetFileContent("file")
+			if err != nil {
+				t.Fatalf("GetFileContent() failed: %v", err)
+			}
+			if string(content) != "Hello, World!" {
+				t.Errorf("GetFileContent() = %q, want the content of %s", content, test.revision)
+			}
+		})
+	}
+}
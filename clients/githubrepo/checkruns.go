@@ -46,6 +46,11 @@ type checkRunsGraphqlData struct {
 													App struct {
 														Slug githubv4.String
 													}
+													WorkflowRun struct {
+														Workflow struct {
+															Name githubv4.String
+														}
+													}
 													Conclusion githubv4.CheckConclusionState
 													Status     githubv4.CheckStatusState
 												}
@@ -154,6 +159,7 @@ func parseCheckRuns(data *checkRunsGraphqlData) checkRunsByRef {
 						// the REST API returns lowercase. the graphQL API returns upper
 						Status:     strings.ToLower(string(checkRun.Status)),
 						Conclusion: strings.ToLower(string(checkRun.Conclusion)),
+						Name:       string(checkRun.WorkflowRun.Workflow.Name),
 						App: clients.CheckRunApp{
 							Slug: string(checkRun.App.Slug),
 						},
@@ -174,6 +180,7 @@ func checkRunsFrom(data *github.ListCheckRunsResults) []clients.CheckRun {
 			Status:     checkRun.GetStatus(),
 			Conclusion: checkRun.GetConclusion(),
 			URL:        checkRun.GetURL(),
+			Name:       checkRun.GetName(),
 			App: clients.CheckRunApp{
 				Slug: checkRun.GetApp().GetSlug(),
 			},
@@ -48,12 +48,16 @@ type Client struct {
 	contributors  *contributorsHandler
 	branches      *branchesHandler
 	releases      *releasesHandler
+	refs          *refsHandler
 	workflows     *workflowsHandler
 	checkruns     *checkrunsHandler
 	statuses      *statusesHandler
 	search        *searchHandler
 	searchCommits *searchCommitsHandler
 	webhook       *webhookHandler
+	codeowners    *codeownersHandler
+	settings      *securitySettingsHandler
+	advisories    *securityAdvisoriesHandler
 	languages     *languagesHandler
 	licenses      *licensesHandler
 	ctx           context.Context
@@ -102,6 +106,9 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 	// Setup releasesHandler.
 	client.releases.init(client.ctx, client.repourl)
 
+	// Setup refsHandler.
+	client.refs.init(client.ctx, client.repourl)
+
 	// Setup workflowsHandler.
 	client.workflows.init(client.ctx, client.repourl)
 
@@ -120,6 +127,15 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 	// Setup webhookHandler.
 	client.webhook.init(client.ctx, client.repourl)
 
+	// Setup codeownersHandler.
+	client.codeowners.init(client.ctx, client.repourl)
+
+	// Setup securitySettingsHandler.
+	client.settings.init(client.ctx, client.repourl, client.repo)
+
+	// Setup securityAdvisoriesHandler.
+	client.advisories.init(client.ctx, client.repourl)
+
 	// Setup languagesHandler.
 	client.languages.init(client.ctx, client.repourl)
 
@@ -168,6 +184,21 @@ func (client *Client) ListReleases() ([]clients.Release, error) {
 	return client.releases.getReleases()
 }
 
+// ListTags implements RepoClient.ListTags.
+func (client *Client) ListTags() ([]clients.Tag, error) {
+	return client.refs.listTags()
+}
+
+// ListBranchHeads implements RepoClient.ListBranchHeads.
+func (client *Client) ListBranchHeads() ([]clients.BranchHead, error) {
+	return client.refs.listBranchHeads()
+}
+
+// IsCommitReachable implements RepoClient.IsCommitReachable.
+func (client *Client) IsCommitReachable(sha, ref string) (bool, error) {
+	return client.refs.isCommitReachable(sha, ref)
+}
+
 // ListContributors implements RepoClient.ListContributors.
 func (client *Client) ListContributors() ([]clients.User, error) {
 	return client.contributors.getContributors()
@@ -178,6 +209,11 @@ func (client *Client) IsArchived() (bool, error) {
 	return client.graphClient.isArchived()
 }
 
+// IsPrivate implements RepoClient.IsPrivate.
+func (client *Client) IsPrivate() (bool, error) {
+	return client.repo.GetPrivate(), nil
+}
+
 // GetDefaultBranch implements RepoClient.GetDefaultBranch.
 func (client *Client) GetDefaultBranch() (*clients.BranchRef, error) {
 	return client.branches.getDefaultBranch()
@@ -222,6 +258,26 @@ func (client *Client) ListWebhooks() ([]clients.Webhook, error) {
 	return client.webhook.listWebhooks()
 }
 
+// ListCodeownersErrors implements RepoClient.ListCodeownersErrors.
+func (client *Client) ListCodeownersErrors() ([]clients.CodeownersError, error) {
+	return client.codeowners.listCodeownersErrors()
+}
+
+// GetSecuritySettings implements RepoClient.GetSecuritySettings.
+func (client *Client) GetSecuritySettings() (*clients.SecuritySettings, error) {
+	return client.settings.getSecuritySettings()
+}
+
+// GetPrivateVulnerabilityReporting implements RepoClient.GetPrivateVulnerabilityReporting.
+func (client *Client) GetPrivateVulnerabilityReporting() (bool, error) {
+	return client.settings.getPrivateVulnerabilityReporting()
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (client *Client) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	return client.advisories.listSecurityAdvisories()
+}
+
 // ListSuccessfulWorkflowRuns implements RepoClient.WorkflowRunsByFilename.
 func (client *Client) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
 	return client.workflows.listSuccessfulWorkflowRuns(filename)
@@ -304,6 +360,9 @@ func CreateGithubRepoClientWithTransport(ctx context.Context, rt http.RoundTripp
 		releases: &releasesHandler{
 			client: client,
 		},
+		refs: &refsHandler{
+			client: client,
+		},
 		workflows: &workflowsHandler{
 			client: client,
 		},
@@ -323,6 +382,15 @@ func CreateGithubRepoClientWithTransport(ctx context.Context, rt http.RoundTripp
 		webhook: &webhookHandler{
 			ghClient: client,
 		},
+		codeowners: &codeownersHandler{
+			ghClient: client,
+		},
+		settings: &securitySettingsHandler{
+			ghClient: client,
+		},
+		advisories: &securityAdvisoriesHandler{
+			ghClient: client,
+		},
 		languages: &languagesHandler{
 			ghclient: client,
 		},
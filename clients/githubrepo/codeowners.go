@@ -0,0 +1,79 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v53/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+type codeownersHandler struct {
+	ghClient *github.Client
+	once     *sync.Once
+	ctx      context.Context
+	errSetup error
+	repourl  *repoURL
+	errors   []clients.CodeownersError
+}
+
+func (handler *codeownersHandler) init(ctx context.Context, repourl *repoURL) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.errSetup = nil
+	handler.once = new(sync.Once)
+	handler.errors = nil
+}
+
+func (handler *codeownersHandler) setup() error {
+	handler.once.Do(func() {
+		// The API only reports errors for the CODEOWNERS file on the default branch.
+		if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+			handler.errSetup = fmt.Errorf("%w: ListCodeownersErrors only supported for HEAD queries",
+				clients.ErrUnsupportedFeature)
+			return
+		}
+		codeownersErrors, _, err := handler.ghClient.Repositories.GetCodeownersErrors(
+			handler.ctx, handler.repourl.owner, handler.repourl.repo)
+		if err != nil {
+			handler.errSetup = fmt.Errorf("error during GetCodeownersErrors: %w", err)
+			return
+		}
+
+		for _, e := range codeownersErrors.Errors {
+			handler.errors = append(handler.errors, clients.CodeownersError{
+				Path:    e.Path,
+				Kind:    e.Kind,
+				Source:  e.Source,
+				Message: e.Message,
+				Line:    e.Line,
+				Column:  e.Column,
+			})
+		}
+	})
+	return handler.errSetup
+}
+
+func (handler *codeownersHandler) listCodeownersErrors() ([]clients.CodeownersError, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during codeownersHandler.setup: %w", err)
+	}
+	return handler.errors, nil
+}
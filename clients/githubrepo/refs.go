@@ -0,0 +1,156 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v53/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// Number of tags and branches to list per page.
+const refsPerPage = 100
+
+type refsHandler struct {
+	client       *github.Client
+	once         *sync.Once
+	branchesOnce *sync.Once
+	ctx          context.Context
+	errSetup     error
+	errBranches  error
+	repourl      *repoURL
+	tags         []clients.Tag
+	branches     []clients.BranchHead
+}
+
+func (handler *refsHandler) init(ctx context.Context, repourl *repoURL) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.errSetup = nil
+	handler.errBranches = nil
+	handler.once = new(sync.Once)
+	handler.branchesOnce = new(sync.Once)
+	handler.tags = nil
+	handler.branches = nil
+}
+
+func (handler *refsHandler) setup() error {
+	handler.once.Do(func() {
+		if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+			handler.errSetup = fmt.Errorf("%w: ListTags only supported for HEAD queries", clients.ErrUnsupportedFeature)
+			return
+		}
+		opts := &github.ListOptions{PerPage: refsPerPage}
+		for {
+			tags, resp, err := handler.client.Repositories.ListTags(
+				handler.ctx, handler.repourl.owner, handler.repourl.repo, opts)
+			if err != nil {
+				handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Repositories.ListTags: %v", err))
+				return
+			}
+			handler.tags = append(handler.tags, tagsFrom(tags)...)
+			if resp == nil || resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+	})
+	return handler.errSetup
+}
+
+func (handler *refsHandler) setupBranches() error {
+	handler.branchesOnce.Do(func() {
+		if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+			handler.errBranches = fmt.Errorf("%w: ListBranchHeads only supported for HEAD queries",
+				clients.ErrUnsupportedFeature)
+			return
+		}
+		opts := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: refsPerPage}}
+		for {
+			branches, resp, err := handler.client.Repositories.ListBranches(
+				handler.ctx, handler.repourl.owner, handler.repourl.repo, opts)
+			if err != nil {
+				handler.errBranches = sce.WithMessage(sce.ErrScorecardInternal,
+					fmt.Sprintf("Repositories.ListBranches: %v", err))
+				return
+			}
+			for _, b := range branches {
+				handler.branches = append(handler.branches, clients.BranchHead{
+					Name: b.GetName(),
+					SHA:  b.GetCommit().GetSHA(),
+				})
+			}
+			if resp == nil || resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+	})
+	return handler.errBranches
+}
+
+func (handler *refsHandler) listTags() ([]clients.Tag, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during refsHandler.setup: %w", err)
+	}
+	return handler.tags, nil
+}
+
+func (handler *refsHandler) listBranchHeads() ([]clients.BranchHead, error) {
+	if err := handler.setupBranches(); err != nil {
+		return nil, fmt.Errorf("error during refsHandler.setupBranches: %w", err)
+	}
+	return handler.branches, nil
+}
+
+// isCommitReachable compares ref with the commit: the commit is reachable from ref
+// if ref is identical to, or ahead of, the commit.
+func (handler *refsHandler) isCommitReachable(sha, ref string) (bool, error) {
+	comparison, _, err := handler.client.Repositories.CompareCommits(
+		handler.ctx, handler.repourl.owner, handler.repourl.repo, sha, ref, &github.ListOptions{PerPage: 1})
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound {
+		// The commit does not exist.
+		return false, nil
+	}
+	if err != nil {
+		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Repositories.CompareCommits: %v", err))
+	}
+	switch comparison.GetStatus() {
+	case "identical", "ahead":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func tagsFrom(data []*github.RepositoryTag) []clients.Tag {
+	var tags []clients.Tag
+	for _, t := range data {
+		tags = append(tags, clients.Tag{
+			Name: t.GetName(),
+			SHA:  t.GetCommit().GetSHA(),
+		})
+	}
+	return tags
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v53/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// pagedTripper serves the JSON pages of an endpoint, e.g., tags, by the `page` query parameter,
+// and links to the next page.
+type pagedTripper struct {
+	pages map[string][]string
+}
+
+func (p pagedTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	page := 1
+	if v := r.URL.Query().Get("page"); v != "" {
+		if _, err := fmt.Sscanf(v, "%d", &page); err != nil {
+			//nolint:wrapcheck
+			return nil, err
+		}
+	}
+	pages := p.pages[path.Base(r.URL.Path)]
+	header := http.Header{}
+	if page < len(pages) {
+		next := *r.URL
+		q := next.Query()
+		q.Set("page", fmt.Sprint(page+1))
+		next.RawQuery = q.Encode()
+		header.Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(pages[page-1])),
+		Request:    r,
+	}, nil
+}
+
+func Test_refsHandler(t *testing.T) {
+	t.Parallel()
+	httpClient := &http.Client{
+		Transport: pagedTripper{
+			pages: map[string][]string{
+				"tags": {
+					`[{"name": "v2", "commit": {"sha": "a"}}]`,
+					`[{"name": "v1", "commit": {"sha": "b"}}]`,
+				},
+				"branches": {
+					`[{"name": "main", "commit": {"sha": "c"}}]`,
+					`[{"name": "releases/v1", "commit": {"sha": "b"}}]`,
+				},
+			},
+		},
+	}
+	handler := &refsHandler{client: github.NewClient(httpClient)}
+	handler.init(context.Background(), &repoURL{
+		owner:     "ossf-tests",
+		repo:      "foo",
+		commitSHA: clients.HeadSHA,
+	})
+
+	tags, err := handler.listTags()
+	if err != nil {
+		t.Fatalf("listTags: %v", err)
+	}
+	wantTags := []clients.Tag{{Name: "v2", SHA: "a"}, {Name: "v1", SHA: "b"}}
+	if diff := cmp.Diff(wantTags, tags); diff != "" {
+		t.Errorf("unexpected tags (-want +got):\n%s", diff)
+	}
+
+	branches, err := handler.listBranchHeads()
+	if err != nil {
+		t.Fatalf("listBranchHeads: %v", err)
+	}
+	wantBranches := []clients.BranchHead{{Name: "main", SHA: "c"}, {Name: "releases/v1", SHA: "b"}}
+	if diff := cmp.Diff(wantBranches, branches); diff != "" {
+		t.Errorf("unexpected branches (-want +got):\n%s", diff)
+	}
+}
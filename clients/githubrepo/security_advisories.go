@@ -0,0 +1,118 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v53/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// advisoriesPerPage is the maximum page size of the repository security advisories API.
+const advisoriesPerPage = 100
+
+type securityAdvisoriesHandler struct {
+	ghClient   *github.Client
+	once       *sync.Once
+	ctx        context.Context
+	errSetup   error
+	repourl    *repoURL
+	advisories []clients.SecurityAdvisory
+}
+
+type repositoryAdvisory struct {
+	CreatedAt   *github.Timestamp `json:"created_at"`
+	PublishedAt *github.Timestamp `json:"published_at"`
+	Submission  *struct {
+		Accepted bool `json:"accepted"`
+	} `json:"submission"`
+	GHSAID   string `json:"ghsa_id"`
+	CVEID    string `json:"cve_id"`
+	Severity string `json:"severity"`
+	HTMLURL  string `json:"html_url"`
+}
+
+func (handler *securityAdvisoriesHandler) init(ctx context.Context, repourl *repoURL) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.errSetup = nil
+	handler.once = new(sync.Once)
+	handler.advisories = nil
+}
+
+func (handler *securityAdvisoriesHandler) setup() error {
+	handler.once.Do(func() {
+		if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+			handler.errSetup = fmt.Errorf("%w: ListSecurityAdvisories only supported for HEAD queries",
+				clients.ErrUnsupportedFeature)
+			return
+		}
+
+		// Only the most recent advisories are listed.
+		u := fmt.Sprintf("repos/%s/%s/security-advisories?state=published&sort=published&direction=desc&per_page=%d",
+			handler.repourl.owner, handler.repourl.repo, advisoriesPerPage)
+		req, err := handler.ghClient.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			handler.errSetup = fmt.Errorf("error during NewRequest: %w", err)
+			return
+		}
+		var advisories []repositoryAdvisory
+		resp, err := handler.ghClient.Do(handler.ctx, req, &advisories)
+		switch {
+		case resp != nil && resp.StatusCode == http.StatusNotFound:
+			handler.advisories = []clients.SecurityAdvisory{}
+			return
+		case err != nil && isPermissionError(err):
+			handler.errSetup = fmt.Errorf("%w: the token cannot list the security advisories", clients.ErrUnsupportedFeature)
+			return
+		case err != nil:
+			handler.errSetup = fmt.Errorf("error during GET security-advisories: %w", err)
+			return
+		}
+
+		handler.advisories = make([]clients.SecurityAdvisory, 0, len(advisories))
+		for i := range advisories {
+			a := &advisories[i]
+			advisory := clients.SecurityAdvisory{
+				ID:                a.GHSAID,
+				CVE:               a.CVEID,
+				Severity:          a.Severity,
+				URL:               a.HTMLURL,
+				PrivatelyReported: a.Submission != nil && a.Submission.Accepted,
+			}
+			if a.CreatedAt != nil {
+				advisory.CreatedAt = a.CreatedAt.Time
+			}
+			if a.PublishedAt != nil {
+				advisory.PublishedAt = a.PublishedAt.Time
+			}
+			handler.advisories = append(handler.advisories, advisory)
+		}
+	})
+	return handler.errSetup
+}
+
+func (handler *securityAdvisoriesHandler) listSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during securityAdvisoriesHandler.setup: %w", err)
+	}
+	return handler.advisories, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v53/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+func Test_listSecurityAdvisories(t *testing.T) {
+	t.Parallel()
+	const advisories = `[
+  {
+    "ghsa_id": "GHSA-abcd-efgh-ijkl",
+    "cve_id": "CVE-2023-12345",
+    "html_url": "https://github.com/owner/repo/security/advisories/GHSA-abcd-efgh-ijkl",
+    "severity": "high",
+    "state": "published",
+    "created_at": "2023-03-01T10:00:00Z",
+    "published_at": "2023-03-15T10:00:00Z",
+    "submission": {"accepted": true}
+  },
+  {
+    "ghsa_id": "GHSA-mnop-qrst-uvwx",
+    "cve_id": null,
+    "html_url": "https://github.com/owner/repo/security/advisories/GHSA-mnop-qrst-uvwx",
+    "severity": "low",
+    "state": "published",
+    "created_at": "2022-01-01T00:00:00Z",
+    "published_at": "2022-06-01T00:00:00Z",
+    "submission": null
+  }
+]`
+	tests := []struct {
+		name      string
+		routes    routeTripper
+		commitSHA string
+		want      []clients.SecurityAdvisory
+		wantErr   error
+	}{
+		{
+			name: "advisories",
+			routes: routeTripper{
+				"/repos/owner/repo/security-advisories": {status: http.StatusOK, body: advisories},
+			},
+			want: []clients.SecurityAdvisory{
+				{
+					ID:                "GHSA-abcd-efgh-ijkl",
+					CVE:               "CVE-2023-12345",
+					Severity:          "high",
+					URL:               "https://github.com/owner/repo/security/advisories/GHSA-abcd-efgh-ijkl",
+					CreatedAt:         time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC),
+					PublishedAt:       time.Date(2023, 3, 15, 10, 0, 0, 0, time.UTC),
+					PrivatelyReported: true,
+				},
+				{
+					ID:          "GHSA-mnop-qrst-uvwx",
+					Severity:    "low",
+					URL:         "https://github.com/owner/repo/security/advisories/GHSA-mnop-qrst-uvwx",
+					CreatedAt:   time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+					PublishedAt: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "no advisories",
+			routes: routeTripper{
+				"/repos/owner/repo/security-advisories": {status: http.StatusOK, body: `[]`},
+			},
+			want: []clients.SecurityAdvisory{},
+		},
+		{
+			name:   "not found",
+			routes: routeTripper{},
+			want:   []clients.SecurityAdvisory{},
+		},
+		{
+			name: "token without the scope",
+			routes: routeTripper{
+				"/repos/owner/repo/security-advisories": {
+					status: http.StatusForbidden,
+					body:   `{"message": "Resource not accessible by integration"}`,
+				},
+			},
+			wantErr: clients.ErrUnsupportedFeature,
+		},
+		{
+			name:      "commit",
+			commitSHA: "a1b2c3",
+			wantErr:   clients.ErrUnsupportedFeature,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			handler := &securityAdvisoriesHandler{
+				ghClient: github.NewClient(&http.Client{Transport: tt.routes}),
+			}
+			commitSHA := tt.commitSHA
+			if commitSHA == "" {
+				commitSHA = clients.HeadSHA
+			}
+			handler.init(context.Background(), &repoURL{owner: "owner", repo: "repo", commitSHA: commitSHA})
+			got, err := handler.listSecurityAdvisories()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("listSecurityAdvisories() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("listSecurityAdvisories() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v53/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const statusEnabled = "enabled"
+
+type securitySettingsHandler struct {
+	ghClient *github.Client
+	once     *sync.Once
+	ctx      context.Context
+	errSetup error
+	repourl  *repoURL
+	repo     *github.Repository
+	settings clients.SecuritySettings
+
+	reportingOnce *sync.Once
+	errReporting  error
+	reporting     *bool
+}
+
+type enabledResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+func (handler *securitySettingsHandler) init(ctx context.Context, repourl *repoURL, repo *github.Repository) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.repo = repo
+	handler.errSetup = nil
+	handler.once = new(sync.Once)
+	handler.settings = clients.SecuritySettings{}
+	handler.reportingOnce = new(sync.Once)
+	handler.errReporting = nil
+	handler.reporting = nil
+}
+
+func (handler *securitySettingsHandler) setup() error {
+	handler.once.Do(func() {
+		if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+			handler.errSetup = fmt.Errorf("%w: GetSecuritySettings only supported for HEAD queries",
+				clients.ErrUnsupportedFeature)
+			return
+		}
+
+		// GitHub only returns security_and_analysis to admins and security managers.
+		if sa := handler.repo.GetSecurityAndAnalysis(); sa != nil {
+			if sa.SecretScanning != nil {
+				handler.settings.SecretScanning = boolPtr(sa.SecretScanning.GetStatus() == statusEnabled)
+			}
+			if sa.SecretScanningPushProtection != nil {
+				handler.settings.SecretScanningPushProtection = boolPtr(
+					sa.SecretScanningPushProtection.GetStatus() == statusEnabled)
+			}
+		}
+		if handler.repo.GetPrivate() && handler.repo.AllowForking != nil {
+			handler.settings.ForkingAllowed = boolPtr(handler.repo.GetAllowForking())
+		}
+
+		// Without admin access, the Dependabot endpoints answer 404 whether or not the feature is enabled.
+		if handler.repo.GetPermissions()["admin"] {
+			alerts, _, err := handler.ghClient.Repositories.GetVulnerabilityAlerts(
+				handler.ctx, handler.repourl.owner, handler.repourl.repo)
+			switch {
+			case err == nil:
+				handler.settings.DependabotAlerts = &alerts
+			case !isPermissionError(err):
+				handler.errSetup = fmt.Errorf("error during GetVulnerabilityAlerts: %w", err)
+				return
+			}
+
+			var fixes enabledResponse
+			found, err := handler.get("automated-security-fixes", &fixes)
+			switch {
+			case err == nil:
+				handler.settings.DependabotSecurityUpdates = boolPtr(found && fixes.Enabled)
+			case !isPermissionError(err):
+				handler.errSetup = err
+				return
+			}
+		}
+
+		if err := handler.setupPrivateVulnerabilityReporting(); err != nil {
+			handler.errSetup = err
+			return
+		}
+		handler.settings.PrivateVulnerabilityReporting = handler.reporting
+
+		if handler.settings == (clients.SecuritySettings{}) {
+			handler.errSetup = fmt.Errorf("%w: the token cannot read the security settings, admin access is required",
+				clients.ErrUnsupportedFeature)
+		}
+	})
+	return handler.errSetup
+}
+
+// setupPrivateVulnerabilityReporting reads the private vulnerability reporting setting alone,
+// so callers needing only that setting skip the other requests of setup.
+func (handler *securitySettingsHandler) setupPrivateVulnerabilityReporting() error {
+	handler.reportingOnce.Do(func() {
+		if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+			handler.errReporting = fmt.Errorf("%w: GetPrivateVulnerabilityReporting only supported for HEAD queries",
+				clients.ErrUnsupportedFeature)
+			return
+		}
+		var reporting enabledResponse
+		found, err := handler.get("private-vulnerability-reporting", &reporting)
+		switch {
+		case err != nil && !isPermissionError(err):
+			handler.errReporting = err
+		case found:
+			handler.reporting = &reporting.Enabled
+		}
+	})
+	return handler.errReporting
+}
+
+// get decodes a repository endpoint, returning false if it is not found.
+func (handler *securitySettingsHandler) get(endpoint string, v interface{}) (bool, error) {
+	u := fmt.Sprintf("repos/%s/%s/%s", handler.repourl.owner, handler.repourl.repo, endpoint)
+	req, err := handler.ghClient.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return false, fmt.Errorf("error during NewRequest: %w", err)
+	}
+	resp, err := handler.ghClient.Do(handler.ctx, req, v)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error during GET %s: %w", endpoint, err)
+	}
+	return true, nil
+}
+
+func isPermissionError(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil &&
+		(errResp.Response.StatusCode == http.StatusForbidden || errResp.Response.StatusCode == http.StatusUnauthorized)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func (handler *securitySettingsHandler) getSecuritySettings() (*clients.SecuritySettings, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during securitySettingsHandler.setup: %w", err)
+	}
+	return &handler.settings, nil
+}
+
+func (handler *securitySettingsHandler) getPrivateVulnerabilityReporting() (bool, error) {
+	if err := handler.setupPrivateVulnerabilityReporting(); err != nil {
+		return false, fmt.Errorf("error during securitySettingsHandler.setupPrivateVulnerabilityReporting: %w", err)
+	}
+	if handler.reporting == nil {
+		return false, fmt.Errorf("%w: the token cannot read private vulnerability reporting",
+			clients.ErrUnsupportedFeature)
+	}
+	return *handler.reporting, nil
+}
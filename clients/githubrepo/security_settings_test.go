@@ -0,0 +1,188 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v53/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+type routeResponse struct {
+	body   string
+	status int
+}
+
+// routeTripper serves responses by URL path, and 404 for other paths.
+type routeTripper map[string]routeResponse
+
+func (r routeTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, ok := r[req.URL.Path]
+	if !ok {
+		resp = routeResponse{status: http.StatusNotFound, body: `{"message": "Not Found"}`}
+	}
+	return &http.Response{
+		Status:     http.StatusText(resp.status),
+		StatusCode: resp.status,
+		Body:       io.NopCloser(strings.NewReader(resp.body)),
+		Request:    req,
+	}, nil
+}
+
+func Test_getSecuritySettings(t *testing.T) {
+	t.Parallel()
+	enabled, disabled := true, false
+	tests := []struct {
+		name      string
+		repo      *github.Repository
+		routes    routeTripper
+		commitSHA string
+		want      *clients.SecuritySettings
+		wantErr   error
+	}{
+		{
+			name: "admin token",
+			repo: &github.Repository{
+				Private:      &enabled,
+				AllowForking: &disabled,
+				Permissions:  map[string]bool{"admin": true},
+				SecurityAndAnalysis: &github.SecurityAndAnalysis{
+					SecretScanning:               &github.SecretScanning{Status: github.String("enabled")},
+					SecretScanningPushProtection: &github.SecretScanningPushProtection{Status: github.String("disabled")},
+				},
+			},
+			routes: routeTripper{
+				"/repos/owner/repo/vulnerability-alerts":            {status: http.StatusNoContent},
+				"/repos/owner/repo/private-vulnerability-reporting": {status: http.StatusOK, body: `{"enabled": true}`},
+			},
+			want: &clients.SecuritySettings{
+				SecretScanning:                &enabled,
+				SecretScanningPushProtection:  &disabled,
+				DependabotAlerts:              &enabled,
+				DependabotSecurityUpdates:     &disabled,
+				PrivateVulnerabilityReporting: &enabled,
+				ForkingAllowed:                &disabled,
+			},
+		},
+		{
+			name: "read-only token",
+			repo: &github.Repository{
+				Private:      &disabled,
+				AllowForking: &enabled,
+			},
+			routes: routeTripper{
+				"/repos/owner/repo/vulnerability-alerts":            {status: http.StatusNoContent},
+				"/repos/owner/repo/private-vulnerability-reporting": {status: http.StatusOK, body: `{"enabled": false}`},
+			},
+			want: &clients.SecuritySettings{
+				PrivateVulnerabilityReporting: &disabled,
+			},
+		},
+		{
+			name: "token without the scope",
+			repo: &github.Repository{},
+			routes: routeTripper{
+				"/repos/owner/repo/private-vulnerability-reporting": {
+					status: http.StatusForbidden,
+					body:   `{"message": "Resource not accessible by integration"}`,
+				},
+			},
+			wantErr: clients.ErrUnsupportedFeature,
+		},
+		{
+			name:      "commit",
+			repo:      &github.Repository{},
+			commitSHA: "a1b2c3",
+			wantErr:   clients.ErrUnsupportedFeature,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			handler := &securitySettingsHandler{
+				ghClient: github.NewClient(&http.Client{Transport: tt.routes}),
+			}
+			commitSHA := tt.commitSHA
+			if commitSHA == "" {
+				commitSHA = clients.HeadSHA
+			}
+			handler.init(ctx, &repoURL{owner: "owner", repo: "repo", commitSHA: commitSHA}, tt.repo)
+			got, err := handler.getSecuritySettings()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("getSecuritySettings() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getSecuritySettings() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_getPrivateVulnerabilityReporting(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		routes  routeTripper
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "enabled",
+			routes: routeTripper{
+				"/repos/owner/repo/private-vulnerability-reporting": {status: http.StatusOK, body: `{"enabled": true}`},
+			},
+			want: true,
+		},
+		{
+			name: "token without the scope",
+			routes: routeTripper{
+				"/repos/owner/repo/private-vulnerability-reporting": {
+					status: http.StatusForbidden,
+					body:   `{"message": "Resource not accessible by integration"}`,
+				},
+			},
+			wantErr: clients.ErrUnsupportedFeature,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// The other settings are not requested: the Dependabot endpoints would answer 404.
+			handler := &securitySettingsHandler{
+				ghClient: github.NewClient(&http.Client{Transport: tt.routes}),
+			}
+			handler.init(context.Background(), &repoURL{owner: "owner", repo: "repo", commitSHA: clients.HeadSHA},
+				&github.Repository{Permissions: map[string]bool{"admin": true}})
+			got, err := handler.getPrivateVulnerabilityReporting()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("getPrivateVulnerabilityReporting() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getPrivateVulnerabilityReporting() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
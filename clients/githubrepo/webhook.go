@@ -17,6 +17,7 @@ package githubrepo
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 	"sync"
 
@@ -59,6 +60,9 @@ func (handler *webhookHandler) setup() error {
 			repoHook := clients.Webhook{
 				ID:             hook.GetID(),
 				UsesAuthSecret: getAuthSecret(hook.Config),
+				Scheme:         getURLScheme(hook.Config),
+				InsecureSSL:    getInsecureSSL(hook.Config),
+				Events:         hook.Events,
 			}
 			handler.webhook = append(handler.webhook, repoHook)
 		}
@@ -77,6 +81,31 @@ func getAuthSecret(config map[string]interface{}) bool {
 	return false
 }
 
+func getURLScheme(config map[string]interface{}) string {
+	rawURL, ok := config["url"].(string)
+	if !ok {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Scheme)
+}
+
+// getInsecureSSL returns whether the webhook skips TLS verification: GitHub sets
+// `insecure_ssl` to "1", or the number 1 in older API responses.
+func getInsecureSSL(config map[string]interface{}) bool {
+	switch val := config["insecure_ssl"].(type) {
+	case string:
+		return val == "1"
+	case float64:
+		return val == 1
+	default:
+		return false
+	}
+}
+
 func (handler *webhookHandler) listWebhooks() ([]clients.Webhook, error) {
 	if err := handler.setup(); err != nil {
 		return nil, fmt.Errorf("error during webhookHandler.setup: %w", err)
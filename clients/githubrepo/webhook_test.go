@@ -58,6 +58,22 @@ func Test_listWebhooks(t *testing.T) {
 				{
 					ID:             12345678,
 					UsesAuthSecret: false,
+					Scheme:         "https",
+					Events:         []string{"push"},
+					InsecureSSL:    true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:         "plaintext webhook subscribed to all events",
+			responsePath: "./testdata/plaintext-webhook.json",
+			want: []clients.Webhook{
+				{
+					ID:             87654321,
+					UsesAuthSecret: true,
+					Scheme:         "http",
+					Events:         []string{clients.AllWebhookEvents},
 				},
 			},
 			wantErr: false,
@@ -50,6 +50,7 @@ type Client struct {
 	search        *searchHandler
 	searchCommits *searchCommitsHandler
 	webhook       *webhookHandler
+	settings      *securitySettingsHandler
 	languages     *languagesHandler
 	licenses      *licensesHandler
 	tarball       *tarballHandler
@@ -147,6 +148,9 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 	// Init webhookHandler
 	client.webhook.init(client.repourl)
 
+	// Init securitySettingsHandler
+	client.settings.init(client.repourl, repo)
+
 	// Init languagesHandler
 	client.languages.init(client.repourl)
 
@@ -208,6 +212,18 @@ func (client *Client) ListReleases() ([]clients.Release, error) {
 	return client.releases.getReleases()
 }
 
+func (client *Client) ListTags() ([]clients.Tag, error) {
+	return nil, fmt.Errorf("ListTags (GitLab): %w", clients.ErrUnsupportedFeature)
+}
+
+func (client *Client) ListBranchHeads() ([]clients.BranchHead, error) {
+	return nil, fmt.Errorf("ListBranchHeads (GitLab): %w", clients.ErrUnsupportedFeature)
+}
+
+func (client *Client) IsCommitReachable(sha, ref string) (bool, error) {
+	return false, fmt.Errorf("IsCommitReachable (GitLab): %w", clients.ErrUnsupportedFeature)
+}
+
 func (client *Client) ListContributors() ([]clients.User, error) {
 	return client.contributors.getContributors()
 }
@@ -216,6 +232,17 @@ func (client *Client) IsArchived() (bool, error) {
 	return client.project.isArchived()
 }
 
+// IsPrivate implements RepoClient.IsPrivate.
+func (client *Client) IsPrivate() (bool, error) {
+	return client.project.isPrivate()
+}
+
+// JobTokenScopeEnabled returns whether the CI_JOB_TOKEN of the project is limited
+// to the projects in its allowlist. It is GitLab-specific and not part of clients.RepoClient.
+func (client *Client) JobTokenScopeEnabled() (bool, error) {
+	return client.project.isJobTokenScopeEnabled()
+}
+
 func (client *Client) GetDefaultBranch() (*clients.BranchRef, error) {
 	return client.branches.getDefaultBranch()
 }
@@ -240,6 +267,26 @@ func (client *Client) ListWebhooks() ([]clients.Webhook, error) {
 	return client.webhook.listWebhooks()
 }
 
+func (client *Client) GetSecuritySettings() (*clients.SecuritySettings, error) {
+	return client.settings.getSecuritySettings()
+}
+
+// GetPrivateVulnerabilityReporting implements RepoClient.GetPrivateVulnerabilityReporting.
+// GitLab has no setting for private vulnerability reports.
+func (client *Client) GetPrivateVulnerabilityReporting() (bool, error) {
+	return false, fmt.Errorf("GetPrivateVulnerabilityReporting: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+// GitLab has no API listing the security advisories published by a project.
+func (client *Client) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	return nil, fmt.Errorf("ListSecurityAdvisories: %w", clients.ErrUnsupportedFeature)
+}
+
+func (client *Client) ListCodeownersErrors() ([]clients.CodeownersError, error) {
+	return nil, fmt.Errorf("ListCodeownersErrors (GitLab): %w", clients.ErrUnsupportedFeature)
+}
+
 func (client *Client) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
 	return client.workflows.listSuccessfulWorkflowRuns(filename)
 }
@@ -323,6 +370,9 @@ func CreateGitlabClientWithToken(ctx context.Context, token, host string) (clien
 		webhook: &webhookHandler{
 			glClient: client,
 		},
+		settings: &securitySettingsHandler{
+			glClient: client,
+		},
 		languages: &languagesHandler{
 			glClient: client,
 		},
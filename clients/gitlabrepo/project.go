@@ -29,6 +29,10 @@ type projectHandler struct {
 	repourl   *repoURL
 	createdAt time.Time
 	archived  bool
+	private   bool
+	// jobTokenScopeEnabled is true if the CI_JOB_TOKEN of the project
+	// can only access the projects in its allowlist.
+	jobTokenScopeEnabled bool
 }
 
 func (handler *projectHandler) init(repourl *repoURL) {
@@ -47,6 +51,8 @@ func (handler *projectHandler) setup() error {
 
 		handler.createdAt = *proj.CreatedAt
 		handler.archived = proj.Archived
+		handler.private = proj.Visibility != gitlab.PublicVisibility
+		handler.jobTokenScopeEnabled = proj.CIJobTokenScopeEnabled
 	})
 
 	return handler.errSetup
@@ -60,6 +66,14 @@ func (handler *projectHandler) isArchived() (bool, error) {
 	return handler.archived, nil
 }
 
+func (handler *projectHandler) isPrivate() (bool, error) {
+	if err := handler.setup(); err != nil {
+		return true, fmt.Errorf("error during projectHandler.setup: %w", err)
+	}
+
+	return handler.private, nil
+}
+
 func (handler *projectHandler) getCreatedAt() (time.Time, error) {
 	if err := handler.setup(); err != nil {
 		return time.Now(), fmt.Errorf("error during projectHandler.setup: %w", err)
@@ -67,3 +81,11 @@ func (handler *projectHandler) getCreatedAt() (time.Time, error) {
 
 	return handler.createdAt, nil
 }
+
+func (handler *projectHandler) isJobTokenScopeEnabled() (bool, error) {
+	if err := handler.setup(); err != nil {
+		return false, fmt.Errorf("error during projectHandler.setup: %w", err)
+	}
+
+	return handler.jobTokenScopeEnabled, nil
+}
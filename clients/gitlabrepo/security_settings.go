@@ -0,0 +1,68 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlabrepo
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+type securitySettingsHandler struct {
+	glClient *gitlab.Client
+	once     *sync.Once
+	errSetup error
+	repourl  *repoURL
+	project  *gitlab.Project
+	settings clients.SecuritySettings
+}
+
+func (handler *securitySettingsHandler) init(repourl *repoURL, project *gitlab.Project) {
+	handler.repourl = repourl
+	handler.project = project
+	handler.errSetup = nil
+	handler.once = new(sync.Once)
+	handler.settings = clients.SecuritySettings{}
+}
+
+func (handler *securitySettingsHandler) setup() error {
+	handler.once.Do(func() {
+		forkingAllowed := handler.project.ForkingAccessLevel != gitlab.DisabledAccessControl
+		handler.settings.ForkingAllowed = &forkingAllowed
+
+		// The push rules are only available on GitLab Premium, to maintainers of the project.
+		rules, resp, err := handler.glClient.Projects.GetProjectPushRules(handler.repourl.projectID)
+		switch {
+		case err == nil:
+			handler.settings.SecretScanningPushProtection = &rules.PreventSecrets
+		case resp != nil && (resp.StatusCode == http.StatusNotFound ||
+			resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized):
+		default:
+			handler.errSetup = fmt.Errorf("request for project push rules failed with %w", err)
+		}
+	})
+	return handler.errSetup
+}
+
+func (handler *securitySettingsHandler) getSecuritySettings() (*clients.SecuritySettings, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during securitySettingsHandler.setup: %w", err)
+	}
+	return &handler.settings, nil
+}
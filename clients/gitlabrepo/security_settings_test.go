@@ -0,0 +1,99 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlabrepo
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+type statusTripper struct {
+	body   string
+	status int
+}
+
+func (s statusTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		Status:     http.StatusText(s.status),
+		StatusCode: s.status,
+		Body:       io.NopCloser(strings.NewReader(s.body)),
+		Request:    req,
+	}, nil
+}
+
+func Test_getSecuritySettings(t *testing.T) {
+	t.Parallel()
+	enabled, disabled := true, false
+	tests := []struct {
+		name    string
+		project *gitlab.Project
+		tripper statusTripper
+		want    *clients.SecuritySettings
+		wantErr bool
+	}{
+		{
+			name:    "push rules preventing secrets",
+			project: &gitlab.Project{ForkingAccessLevel: gitlab.EnabledAccessControl},
+			tripper: statusTripper{status: http.StatusOK, body: `{"id": 1, "prevent_secrets": true}`},
+			want: &clients.SecuritySettings{
+				SecretScanningPushProtection: &enabled,
+				ForkingAllowed:               &enabled,
+			},
+		},
+		{
+			name:    "push rules unavailable",
+			project: &gitlab.Project{ForkingAccessLevel: gitlab.DisabledAccessControl},
+			tripper: statusTripper{status: http.StatusNotFound, body: `{"message": "404 Not Found"}`},
+			want: &clients.SecuritySettings{
+				ForkingAllowed: &disabled,
+			},
+		},
+		{
+			name:    "server error",
+			project: &gitlab.Project{},
+			tripper: statusTripper{status: http.StatusInternalServerError, body: `{"message": "500"}`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, err := gitlab.NewClient("", gitlab.WithHTTPClient(&http.Client{Transport: tt.tripper}),
+				gitlab.WithCustomRetryMax(0))
+			if err != nil {
+				t.Fatalf("gitlab.NewClient error: %v", err)
+			}
+			handler := &securitySettingsHandler{
+				glClient: client,
+			}
+			handler.init(&repoURL{projectID: "1234"}, tt.project)
+			got, err := handler.getSecuritySettings()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getSecuritySettings() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getSecuritySettings() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
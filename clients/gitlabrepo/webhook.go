@@ -16,6 +16,8 @@ package gitlabrepo
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"sync"
 
 	"github.com/xanzy/go-gitlab"
@@ -46,13 +48,16 @@ func (handler *webhookHandler) setup() error {
 			return
 		}
 
-		// TODO: make sure that enablesslverification is similarly equivalent to auth secret.
+		// The API never returns the secret token of a hook, nor whether one is set.
 		for _, hook := range projectHooks {
 			handler.webhooks = append(handler.webhooks,
 				clients.Webhook{
-					Path:           hook.URL,
-					ID:             int64(hook.ID),
-					UsesAuthSecret: hook.EnableSSLVerification,
+					Path:              redactURL(hook.URL),
+					ID:                int64(hook.ID),
+					AuthSecretUnknown: true,
+					Scheme:            urlScheme(hook.URL),
+					Events:            webhookEvents(hook),
+					InsecureSSL:       !hook.EnableSSLVerification,
 				})
 		}
 	})
@@ -67,3 +72,56 @@ func (handler *webhookHandler) listWebhooks() ([]clients.Webhook, error) {
 
 	return handler.webhooks, nil
 }
+
+func urlScheme(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Scheme)
+}
+
+// redactURL removes the parts of a URL which may embed credentials, e.g., tokens.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// webhookEvents returns the events a project hook is subscribed to,
+// or clients.AllWebhookEvents when it is subscribed to all of them.
+func webhookEvents(hook *gitlab.ProjectHook) []string {
+	subscriptions := []struct {
+		event   string
+		enabled bool
+	}{
+		{"push", hook.PushEvents},
+		{"tag_push", hook.TagPushEvents},
+		{"issues", hook.IssuesEvents},
+		{"confidential_issues", hook.ConfidentialIssuesEvents},
+		{"merge_requests", hook.MergeRequestsEvents},
+		{"note", hook.NoteEvents},
+		{"confidential_note", hook.ConfidentialNoteEvents},
+		{"job", hook.JobEvents},
+		{"pipeline", hook.PipelineEvents},
+		{"wiki_page", hook.WikiPageEvents},
+		{"deployment", hook.DeploymentEvents},
+		{"releases", hook.ReleasesEvents},
+	}
+	var events []string
+	for _, s := range subscriptions {
+		if s.enabled {
+			events = append(events, s.event)
+		}
+	}
+	if len(events) == len(subscriptions) {
+		return []string{clients.AllWebhookEvents}
+	}
+	return events
+}
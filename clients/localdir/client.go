@@ -74,6 +74,10 @@ func (client *localDirClient) IsArchived() (bool, error) {
 	return false, fmt.Errorf("IsArchived: %w", clients.ErrUnsupportedFeature)
 }
 
+func (client *localDirClient) IsPrivate() (bool, error) {
+	return false, fmt.Errorf("IsPrivate: %w", clients.ErrUnsupportedFeature)
+}
+
 func isDir(p string) (bool, error) {
 	fileInfo, err := os.Stat(p)
 	if err != nil {
@@ -204,6 +208,21 @@ func (client *localDirClient) ListReleases() ([]clients.Release, error) {
 	return nil, fmt.Errorf("ListReleases: %w", clients.ErrUnsupportedFeature)
 }
 
+// ListTags implements RepoClient.ListTags.
+func (client *localDirClient) ListTags() ([]clients.Tag, error) {
+	return nil, fmt.Errorf("ListTags: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListBranchHeads implements RepoClient.ListBranchHeads.
+func (client *localDirClient) ListBranchHeads() ([]clients.BranchHead, error) {
+	return nil, fmt.Errorf("ListBranchHeads: %w", clients.ErrUnsupportedFeature)
+}
+
+// IsCommitReachable implements RepoClient.IsCommitReachable.
+func (client *localDirClient) IsCommitReachable(sha, ref string) (bool, error) {
+	return false, fmt.Errorf("IsCommitReachable: %w", clients.ErrUnsupportedFeature)
+}
+
 // ListContributors implements RepoClient.ListContributors.
 func (client *localDirClient) ListContributors() ([]clients.User, error) {
 	return nil, fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature)
@@ -229,6 +248,26 @@ func (client *localDirClient) ListWebhooks() ([]clients.Webhook, error) {
 	return nil, fmt.Errorf("ListWebhooks: %w", clients.ErrUnsupportedFeature)
 }
 
+// GetSecuritySettings implements RepoClient.GetSecuritySettings.
+func (client *localDirClient) GetSecuritySettings() (*clients.SecuritySettings, error) {
+	return nil, fmt.Errorf("GetSecuritySettings: %w", clients.ErrUnsupportedFeature)
+}
+
+// GetPrivateVulnerabilityReporting implements RepoClient.GetPrivateVulnerabilityReporting.
+func (client *localDirClient) GetPrivateVulnerabilityReporting() (bool, error) {
+	return false, fmt.Errorf("GetPrivateVulnerabilityReporting: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (client *localDirClient) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	return nil, fmt.Errorf("ListSecurityAdvisories: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListCodeownersErrors implements RepoClient.ListCodeownersErrors.
+func (client *localDirClient) ListCodeownersErrors() ([]clients.CodeownersError, error) {
+	return nil, fmt.Errorf("ListCodeownersErrors: %w", clients.ErrUnsupportedFeature)
+}
+
 // Search implements RepoClient.Search.
 func (client *localDirClient) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
 	return clients.SearchResponse{}, fmt.Errorf("Search: %w", clients.ErrUnsupportedFeature)
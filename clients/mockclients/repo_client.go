@@ -155,6 +155,36 @@ func (mr *MockRepoClientMockRecorder) GetOrgRepoClient(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgRepoClient", reflect.TypeOf((*MockRepoClient)(nil).GetOrgRepoClient), arg0)
 }
 
+// GetPrivateVulnerabilityReporting mocks base method.
+func (m *MockRepoClient) GetPrivateVulnerabilityReporting() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateVulnerabilityReporting")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateVulnerabilityReporting indicates an expected call of GetPrivateVulnerabilityReporting.
+func (mr *MockRepoClientMockRecorder) GetPrivateVulnerabilityReporting() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateVulnerabilityReporting", reflect.TypeOf((*MockRepoClient)(nil).GetPrivateVulnerabilityReporting))
+}
+
+// GetSecuritySettings mocks base method.
+func (m *MockRepoClient) GetSecuritySettings() (*clients.SecuritySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecuritySettings")
+	ret0, _ := ret[0].(*clients.SecuritySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecuritySettings indicates an expected call of GetSecuritySettings.
+func (mr *MockRepoClientMockRecorder) GetSecuritySettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecuritySettings", reflect.TypeOf((*MockRepoClient)(nil).GetSecuritySettings))
+}
+
 // InitRepo mocks base method.
 func (m *MockRepoClient) InitRepo(repo clients.Repo, commitSHA string, commitDepth int) error {
 	m.ctrl.T.Helper()
@@ -184,6 +214,51 @@ func (mr *MockRepoClientMockRecorder) IsArchived() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsArchived", reflect.TypeOf((*MockRepoClient)(nil).IsArchived))
 }
 
+// IsCommitReachable mocks base method.
+func (m *MockRepoClient) IsCommitReachable(sha, ref string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCommitReachable", sha, ref)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCommitReachable indicates an expected call of IsCommitReachable.
+func (mr *MockRepoClientMockRecorder) IsCommitReachable(sha, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCommitReachable", reflect.TypeOf((*MockRepoClient)(nil).IsCommitReachable), sha, ref)
+}
+
+// IsPrivate mocks base method.
+func (m *MockRepoClient) IsPrivate() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPrivate")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsPrivate indicates an expected call of IsPrivate.
+func (mr *MockRepoClientMockRecorder) IsPrivate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrivate", reflect.TypeOf((*MockRepoClient)(nil).IsPrivate))
+}
+
+// ListBranchHeads mocks base method.
+func (m *MockRepoClient) ListBranchHeads() ([]clients.BranchHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranchHeads")
+	ret0, _ := ret[0].([]clients.BranchHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranchHeads indicates an expected call of ListBranchHeads.
+func (mr *MockRepoClientMockRecorder) ListBranchHeads() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranchHeads", reflect.TypeOf((*MockRepoClient)(nil).ListBranchHeads))
+}
+
 // ListCheckRunsForRef mocks base method.
 func (m *MockRepoClient) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
 	m.ctrl.T.Helper()
@@ -199,6 +274,21 @@ func (mr *MockRepoClientMockRecorder) ListCheckRunsForRef(ref interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCheckRunsForRef", reflect.TypeOf((*MockRepoClient)(nil).ListCheckRunsForRef), ref)
 }
 
+// ListCodeownersErrors mocks base method.
+func (m *MockRepoClient) ListCodeownersErrors() ([]clients.CodeownersError, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCodeownersErrors")
+	ret0, _ := ret[0].([]clients.CodeownersError)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCodeownersErrors indicates an expected call of ListCodeownersErrors.
+func (mr *MockRepoClientMockRecorder) ListCodeownersErrors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCodeownersErrors", reflect.TypeOf((*MockRepoClient)(nil).ListCodeownersErrors))
+}
+
 // ListCommits mocks base method.
 func (m *MockRepoClient) ListCommits() ([]clients.Commit, error) {
 	m.ctrl.T.Helper()
@@ -304,6 +394,21 @@ func (mr *MockRepoClientMockRecorder) ListReleases() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReleases", reflect.TypeOf((*MockRepoClient)(nil).ListReleases))
 }
 
+// ListSecurityAdvisories mocks base method.
+func (m *MockRepoClient) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityAdvisories")
+	ret0, _ := ret[0].([]clients.SecurityAdvisory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityAdvisories indicates an expected call of ListSecurityAdvisories.
+func (mr *MockRepoClientMockRecorder) ListSecurityAdvisories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityAdvisories", reflect.TypeOf((*MockRepoClient)(nil).ListSecurityAdvisories))
+}
+
 // ListStatuses mocks base method.
 func (m *MockRepoClient) ListStatuses(ref string) ([]clients.Status, error) {
 	m.ctrl.T.Helper()
@@ -334,6 +439,21 @@ func (mr *MockRepoClientMockRecorder) ListSuccessfulWorkflowRuns(filename interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuccessfulWorkflowRuns", reflect.TypeOf((*MockRepoClient)(nil).ListSuccessfulWorkflowRuns), filename)
 }
 
+// ListTags mocks base method.
+func (m *MockRepoClient) ListTags() ([]clients.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags")
+	ret0, _ := ret[0].([]clients.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockRepoClientMockRecorder) ListTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockRepoClient)(nil).ListTags))
+}
+
 // ListWebhooks mocks base method.
 func (m *MockRepoClient) ListWebhooks() ([]clients.Webhook, error) {
 	m.ctrl.T.Helper()
@@ -166,6 +166,11 @@ func (c *client) IsArchived() (bool, error) {
 	return false, fmt.Errorf("IsArchived: %w", clients.ErrUnsupportedFeature)
 }
 
+// IsPrivate implements RepoClient.IsPrivate.
+func (c *client) IsPrivate() (bool, error) {
+	return false, fmt.Errorf("IsPrivate: %w", clients.ErrUnsupportedFeature)
+}
+
 // LocalPath implements RepoClient.LocalPath.
 func (c *client) LocalPath() (string, error) {
 	return "", fmt.Errorf("LocalPath: %w", clients.ErrUnsupportedFeature)
@@ -216,6 +221,21 @@ func (c *client) ListReleases() ([]clients.Release, error) {
 	return nil, fmt.Errorf("ListReleases: %w", clients.ErrUnsupportedFeature)
 }
 
+// ListTags implements RepoClient.ListTags.
+func (c *client) ListTags() ([]clients.Tag, error) {
+	return nil, fmt.Errorf("ListTags: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListBranchHeads implements RepoClient.ListBranchHeads.
+func (c *client) ListBranchHeads() ([]clients.BranchHead, error) {
+	return nil, fmt.Errorf("ListBranchHeads: %w", clients.ErrUnsupportedFeature)
+}
+
+// IsCommitReachable implements RepoClient.IsCommitReachable.
+func (c *client) IsCommitReachable(sha, ref string) (bool, error) {
+	return false, fmt.Errorf("IsCommitReachable: %w", clients.ErrUnsupportedFeature)
+}
+
 // ListContributors implements RepoClient.ListContributors.
 func (c *client) ListContributors() ([]clients.User, error) {
 	return nil, fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature)
@@ -241,6 +261,26 @@ func (c *client) ListWebhooks() ([]clients.Webhook, error) {
 	return nil, fmt.Errorf("ListWebhooks: %w", clients.ErrUnsupportedFeature)
 }
 
+// GetSecuritySettings implements RepoClient.GetSecuritySettings.
+func (c *client) GetSecuritySettings() (*clients.SecuritySettings, error) {
+	return nil, fmt.Errorf("GetSecuritySettings: %w", clients.ErrUnsupportedFeature)
+}
+
+// GetPrivateVulnerabilityReporting implements RepoClient.GetPrivateVulnerabilityReporting.
+func (c *client) GetPrivateVulnerabilityReporting() (bool, error) {
+	return false, fmt.Errorf("GetPrivateVulnerabilityReporting: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (c *client) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	return nil, fmt.Errorf("ListSecurityAdvisories: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListCodeownersErrors implements RepoClient.ListCodeownersErrors.
+func (c *client) ListCodeownersErrors() ([]clients.CodeownersError, error) {
+	return nil, fmt.Errorf("ListCodeownersErrors: %w", clients.ErrUnsupportedFeature)
+}
+
 // SearchCommits implements RepoClient.SearchCommits.
 func (c *client) SearchCommits(request clients.SearchCommitsOptions) ([]clients.Commit, error) {
 	return nil, fmt.Errorf("SearchCommits: %w", clients.ErrUnsupportedFeature)
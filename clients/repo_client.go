@@ -32,6 +32,8 @@ type RepoClient interface {
 	InitRepo(repo Repo, commitSHA string, commitDepth int) error
 	URI() string
 	IsArchived() (bool, error)
+	// IsPrivate returns true if the repository is not publicly visible.
+	IsPrivate() (bool, error)
 	ListFiles(predicate func(string) (bool, error)) ([]string, error)
 	// Returns an absolute path to the local repository
 	// in the format that matches the local OS
@@ -46,11 +48,23 @@ type RepoClient interface {
 	ListIssues() ([]Issue, error)
 	ListLicenses() ([]License, error)
 	ListReleases() ([]Release, error)
+	ListTags() ([]Tag, error)
+	// ListBranchHeads returns all the branches of the repository.
+	ListBranchHeads() ([]BranchHead, error)
+	// IsCommitReachable returns true if the commit is reachable from, i.e. is an ancestor of, ref.
+	IsCommitReachable(sha, ref string) (bool, error)
 	ListContributors() ([]User, error)
 	ListSuccessfulWorkflowRuns(filename string) ([]WorkflowRun, error)
 	ListCheckRunsForRef(ref string) ([]CheckRun, error)
 	ListStatuses(ref string) ([]Status, error)
 	ListWebhooks() ([]Webhook, error)
+	GetSecuritySettings() (*SecuritySettings, error)
+	// GetPrivateVulnerabilityReporting returns whether users can report vulnerabilities privately.
+	// Unlike GetSecuritySettings, it reads that one setting.
+	GetPrivateVulnerabilityReporting() (bool, error)
+	// ListSecurityAdvisories returns the published security advisories of the repository.
+	ListSecurityAdvisories() ([]SecurityAdvisory, error)
+	ListCodeownersErrors() ([]CodeownersError, error)
 	ListProgrammingLanguages() ([]Language, error)
 	Search(request SearchRequest) (SearchResponse, error)
 	SearchCommits(request SearchCommitsOptions) ([]Commit, error)
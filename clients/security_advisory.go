@@ -0,0 +1,34 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import "time"
+
+// SecurityAdvisory is a security advisory published for a repository,
+// e.g., a GitHub repository security advisory.
+type SecurityAdvisory struct {
+	// CreatedAt is when the advisory was drafted or privately reported.
+	CreatedAt   time.Time
+	PublishedAt time.Time
+	// ID is the identifier of the advisory on the hosting platform, e.g., a GHSA ID.
+	ID string
+	// CVE is the CVE ID assigned to the vulnerability, if any.
+	CVE      string
+	Severity string
+	URL      string
+	// PrivatelyReported is true when the vulnerability was reported
+	// through the private vulnerability reporting of the platform.
+	PrivatelyReported bool
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+// SecuritySettings are the security settings of a repository.
+// A nil setting could not be read, e.g., because the token lacks the scope,
+// or is not available on the hosting platform.
+type SecuritySettings struct {
+	// SecretScanning reports secrets committed to the repository.
+	SecretScanning *bool
+	// SecretScanningPushProtection blocks pushes containing secrets.
+	// On GitLab, this is the `prevent_secrets` push rule.
+	SecretScanningPushProtection *bool
+	// DependabotAlerts reports vulnerable dependencies.
+	DependabotAlerts *bool
+	// DependabotSecurityUpdates opens pull requests updating vulnerable dependencies.
+	DependabotSecurityUpdates *bool
+	// PrivateVulnerabilityReporting lets users report vulnerabilities privately.
+	PrivateVulnerabilityReporting *bool
+	// ForkingAllowed is set when the repository can be forked.
+	// On GitHub, forking can only be restricted for private repositories.
+	ForkingAllowed *bool
+}
@@ -14,9 +14,23 @@
 
 package clients
 
+// AllWebhookEvents is the event of a webhook subscribed to every event.
+const AllWebhookEvents = "*"
+
 // Webhook represents VCS Webhook.
 type Webhook struct {
-	Path           string
+	// Path is the URL the webhook delivers payloads to, if known. Credentials, i.e., the
+	// user info and the query of the URL, are removed.
+	Path string
+	// Scheme is the scheme of the URL the webhook delivers payloads to, such as `https`.
+	Scheme string
+	// Events are the events which trigger the webhook, or AllWebhookEvents.
+	Events         []string
 	ID             int64
 	UsesAuthSecret bool
+	// AuthSecretUnknown is set when the platform does not report whether the webhook
+	// uses a secret, in which case UsesAuthSecret is meaningless.
+	AuthSecretUnknown bool
+	// InsecureSSL is set when the TLS certificate of the payload URL is not verified.
+	InsecureSSL bool
 }
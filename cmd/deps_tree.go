@@ -0,0 +1,561 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/lockfile"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/sbom"
+)
+
+type depsTreeOptions struct {
+	local       string
+	lockfile    string
+	sbom        string
+	format      string
+	checks      []string
+	cache       string
+	cacheMaxAge time.Duration
+	paths       int
+	maxDepth    int
+}
+
+// dependencyTreeReport is the dependency tree of a project along with the scores of the dependencies.
+type dependencyTreeReport struct {
+	// SupplyChainScore is the average score of the dependencies, weighted by the inverse of their depth,
+	// nil when no dependency was scored.
+	SupplyChainScore *float64             `json:"supplyChainScore,omitempty"`
+	Dependencies     []dependencyTreeNode `json:"dependencies"`
+	WeakestPaths     []dependencyPath     `json:"weakestPaths,omitempty"`
+	// LockfileErrors lists the lockfiles which cannot be parsed, and whose dependencies are missing.
+	LockfileErrors []lockfileError `json:"lockfileErrors,omitempty"`
+}
+
+type lockfileError struct {
+	Lockfile string `json:"lockfile"`
+	Error    string `json:"error"`
+}
+
+type dependencyTreeNode struct {
+	ID               string             `json:"id"`
+	Ecosystem        lockfile.Ecosystem `json:"ecosystem"`
+	Name             string             `json:"name"`
+	Version          string             `json:"version,omitempty"`
+	Lockfile         string             `json:"lockfile"`
+	Direct           bool               `json:"direct"`
+	Depth            int                `json:"depth"`
+	SourceRepository string             `json:"sourceRepository,omitempty"`
+	// Score is the aggregate score of the source repository, nil when it was not scored.
+	Score *float64 `json:"score,omitempty"`
+	// WeakestScore is the lowest score of the dependency and its transitive dependencies.
+	WeakestScore *float64 `json:"weakestScore,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// dependencyPath is a path from a direct dependency to the lowest scored of its transitive dependencies.
+type dependencyPath struct {
+	Score float64  `json:"score"`
+	Path  []string `json:"path"`
+}
+
+// scoreCache caches the aggregate scores of repositories. It is shared by all the dependencies
+// and, when backed by a file, by consecutive runs.
+type scoreCache struct {
+	path   string
+	maxAge time.Duration
+	now    func() time.Time
+	scores map[string]cachedScore
+}
+
+type cachedScore struct {
+	Score float64   `json:"score"`
+	Date  time.Time `json:"date"`
+}
+
+// depsTreeRunner resolves and scores the dependencies of a project. The functions are replaced in tests.
+type depsTreeRunner struct {
+	resolve func(d lockfile.Dependency) (string, error)
+	score   func(repo string) (float64, error)
+	cache   *scoreCache
+}
+
+func depsTreeCmd() *cobra.Command {
+	o := &depsTreeOptions{}
+	cmd := &cobra.Command{
+		Use:   "deps-tree [--local=<dir>|--lockfile=<file>|--sbom=<file>] [--cache=<file>]",
+		Short: "Score the direct and transitive dependencies of a project",
+		Long: `Reads the lockfiles of a project, resolves every locked package to its source repository and scores it.
+The report lists the dependency tree with the score of each dependency and the lowest score of its
+transitive dependencies, the weakest paths from the direct dependencies, and a supply chain score:
+the average score of the dependencies weighted by the inverse of their depth.
+Repositories are scored once and, with --cache, their scores are reused by later runs.
+With --sbom, the components of a CycloneDX or SPDX JSON document are scored, identified by their purls.
+The cyclonedx and spdx formats output the scores as the properties, or annotations, of the components of
+the SBOM read by --sbom when it has that format, or else of an SBOM generated from the lockfiles.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			logger := sclog.NewLogger(sclog.DefaultLevel)
+			if !isDependencyTreeFormat(o.format) {
+				return fmt.Errorf("%w: %s", errUnknownFormat, o.format)
+			}
+			g, input, err := loadDependencyGraph(ctx, logger, o)
+			if err != nil {
+				return err
+			}
+			diffRunner, err := newDiffDepsRunner(ctx, logger, o.checks)
+			if err != nil {
+				return err
+			}
+			cache, err := loadScoreCache(o.cache, o.cacheMaxAge)
+			if err != nil {
+				return err
+			}
+			r := &depsTreeRunner{resolve: diffRunner.resolve, score: diffRunner.score, cache: cache}
+			report := r.report(g, o.paths)
+			if err := cache.save(); err != nil {
+				return err
+			}
+			if o.format == string(sbom.FormatCycloneDX) || o.format == string(sbom.FormatSPDX) {
+				// The SBOM has no place for the lockfiles which cannot be parsed.
+				for _, e := range report.LockfileErrors {
+					fmt.Fprintf(os.Stderr, "warning: %s: %s\n", e.Lockfile, e.Error)
+				}
+				return writeDependencyTreeSBOM(os.Stdout, o, &report, g, input)
+			}
+			return writeDependencyTreeReport(os.Stdout, o, &report)
+		},
+	}
+	cmd.Flags().StringVar(&o.local, "local", ".", "local checkout of the project")
+	cmd.Flags().StringVar(&o.lockfile, "lockfile", "", "lockfile to read instead of the lockfiles of --local")
+	cmd.Flags().StringVar(&o.sbom, "sbom", "", "CycloneDX or SPDX JSON SBOM to read instead of lockfiles")
+	cmd.Flags().StringVar(&o.format, "format", "default", "output format: default, json, cyclonedx or spdx")
+	cmd.Flags().StringSliceVar(&o.checks, "checks", nil, "checks to run on the dependencies, all checks by default")
+	cmd.Flags().StringVar(&o.cache, "cache", "", "file caching the scores of repositories across runs")
+	cmd.Flags().DurationVar(&o.cacheMaxAge, "cache-max-age", 7*24*time.Hour, "age after which cached scores are refreshed")
+	cmd.Flags().IntVar(&o.paths, "paths", 5, "number of weakest paths to report")
+	cmd.Flags().IntVar(&o.maxDepth, "max-depth", 0, "depth of the printed tree, unlimited when 0")
+	return cmd
+}
+
+func isDependencyTreeFormat(format string) bool {
+	switch format {
+	case "default", "json", string(sbom.FormatCycloneDX), string(sbom.FormatSPDX):
+		return true
+	default:
+		return false
+	}
+}
+
+// loadDependencyGraph returns the dependency graph of the project, along with the SBOM it was read from, if any.
+func loadDependencyGraph(ctx context.Context, logger *sclog.Logger, o *depsTreeOptions,
+) (*lockfile.Graph, *sbom.SBOM, error) {
+	switch {
+	case o.sbom != "":
+		content, err := os.ReadFile(o.sbom)
+		if err != nil {
+			return nil, nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+		s, err := sbom.Read(filepath.ToSlash(o.sbom), content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sbom.Read: %w", err)
+		}
+		return s.Graph, s, nil
+	case o.lockfile != "":
+		content, err := os.ReadFile(o.lockfile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+		g, err := lockfile.ParseGraph(filepath.ToSlash(o.lockfile), content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ParseGraph: %w", err)
+		}
+		return g, nil, nil
+	}
+	files, closeFiles, err := openLockfiles(ctx, logger, "", o.local)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeFiles()
+	g, err := lockfile.CollectGraph(files)
+	if err != nil {
+		return nil, nil, fmt.Errorf("CollectGraph: %w", err)
+	}
+	return g, nil, nil
+}
+
+// report scores the dependencies of a graph and rolls their scores up.
+func (r *depsTreeRunner) report(g *lockfile.Graph, paths int) dependencyTreeReport {
+	nodes := make([]dependencyTreeNode, len(g.Dependencies))
+	ids := dependencyIDs(g.Dependencies)
+	depths := dependencyDepths(g)
+	for i, d := range g.Dependencies {
+		nodes[i] = dependencyTreeNode{
+			ID:        ids[i],
+			Ecosystem: d.Ecosystem,
+			Name:      d.Name,
+			Version:   d.Version,
+			Lockfile:  d.Path,
+			Depth:     depths[i],
+			Direct:    depths[i] == 1,
+		}
+		for _, j := range g.Edges[i] {
+			nodes[i].Dependencies = append(nodes[i].Dependencies, ids[j])
+		}
+		r.scoreNode(d, &nodes[i])
+	}
+
+	weakest := weakestDependencies(g, nodes)
+	report := dependencyTreeReport{Dependencies: nodes}
+	for i := range g.ParseErrors {
+		report.LockfileErrors = append(report.LockfileErrors, lockfileError{
+			Lockfile: g.ParseErrors[i].Path,
+			Error:    g.ParseErrors[i].Error(),
+		})
+	}
+	for _, i := range g.Direct {
+		if nodes[i].WeakestScore == nil {
+			continue
+		}
+		p := dependencyPath{Score: *nodes[i].WeakestScore}
+		// Follow the dependencies with the lowest scores, guarding against cycles.
+		seen := make(map[int]bool)
+		for j := i; j >= 0 && !seen[j]; j = weakest[j] {
+			seen[j] = true
+			p.Path = append(p.Path, ids[j])
+		}
+		report.WeakestPaths = append(report.WeakestPaths, p)
+	}
+	sort.SliceStable(report.WeakestPaths, func(i, j int) bool {
+		return report.WeakestPaths[i].Score < report.WeakestPaths[j].Score
+	})
+	if len(report.WeakestPaths) > paths {
+		report.WeakestPaths = report.WeakestPaths[:paths]
+	}
+
+	var sum, weights float64
+	for i := range nodes {
+		if nodes[i].Score == nil || *nodes[i].Score < 0 || nodes[i].Depth == 0 {
+			continue
+		}
+		w := 1 / float64(nodes[i].Depth)
+		sum += *nodes[i].Score * w
+		weights += w
+	}
+	if weights > 0 {
+		score := sum / weights
+		report.SupplyChainScore = &score
+	}
+	return report
+}
+
+// scoreNode resolves the source repository of a dependency and scores it, unless it is cached.
+// Errors are recorded in the node so the other dependencies are still scored.
+func (r *depsTreeRunner) scoreNode(d lockfile.Dependency, node *dependencyTreeNode) {
+	repo, err := r.resolve(d)
+	if err != nil {
+		node.Error = err.Error()
+		return
+	}
+	if repo == "" {
+		return
+	}
+	node.SourceRepository = repo
+
+	score, ok := r.cache.get(repo)
+	if !ok {
+		score, err = r.score(repo)
+		if err != nil {
+			node.Error = err.Error()
+			return
+		}
+		r.cache.put(repo, score)
+	}
+	node.Score = &score
+}
+
+// dependencyIDs identifies the dependencies by name and version, and by lockfile
+// when several lockfiles lock the same version.
+func dependencyIDs(deps []lockfile.Dependency) []string {
+	ids := make([]string, len(deps))
+	count := make(map[string]int)
+	for i, d := range deps {
+		ids[i] = d.Name + "@" + d.Version
+		count[ids[i]]++
+	}
+	for i, d := range deps {
+		if count[ids[i]] > 1 {
+			ids[i] += " (" + d.Path + ")"
+		}
+	}
+	return ids
+}
+
+// dependencyDepths returns the length of the shortest path from the project to each dependency.
+func dependencyDepths(g *lockfile.Graph) []int {
+	depths := make([]int, len(g.Dependencies))
+	var queue []int
+	for _, i := range g.Direct {
+		if depths[i] == 0 {
+			depths[i] = 1
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, j := range g.Edges[i] {
+			if depths[j] == 0 {
+				depths[j] = depths[i] + 1
+				queue = append(queue, j)
+			}
+		}
+	}
+	return depths
+}
+
+// weakestDependencies sets the weakest scores of the nodes and returns, for each dependency, the dependency
+// leading to its weakest transitive dependency, or -1 when the dependency itself has the weakest score.
+func weakestDependencies(g *lockfile.Graph, nodes []dependencyTreeNode) []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(nodes))
+	next := make([]int, len(nodes))
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		next[i] = -1
+		var weakest *float64
+		if s := nodes[i].Score; s != nil && *s >= 0 {
+			weakest = s
+		}
+		for _, j := range g.Edges[i] {
+			if state[j] == unvisited {
+				visit(j)
+			}
+			// Dependencies in a cycle are rolled up once.
+			if state[j] == visiting || nodes[j].WeakestScore == nil {
+				continue
+			}
+			if weakest == nil || *nodes[j].WeakestScore < *weakest {
+				weakest = nodes[j].WeakestScore
+				next[i] = j
+			}
+		}
+		if weakest != nil {
+			score := *weakest
+			nodes[i].WeakestScore = &score
+		}
+		state[i] = visited
+	}
+	for i := range nodes {
+		if state[i] == unvisited {
+			visit(i)
+		}
+	}
+	return next
+}
+
+func loadScoreCache(path string, maxAge time.Duration) (*scoreCache, error) {
+	c := &scoreCache{path: path, maxAge: maxAge, now: time.Now, scores: make(map[string]cachedScore)}
+	if path == "" {
+		return c, nil
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	if err := json.Unmarshal(content, &c.scores); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return c, nil
+}
+
+func (c *scoreCache) get(repo string) (float64, bool) {
+	s, ok := c.scores[repo]
+	if !ok || (c.maxAge > 0 && c.now().Sub(s.Date) > c.maxAge) {
+		return 0, false
+	}
+	return s.Score, true
+}
+
+func (c *scoreCache) put(repo string, score float64) {
+	c.scores[repo] = cachedScore{Score: score, Date: c.now()}
+}
+
+func (c *scoreCache) save() error {
+	if c.path == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(c.scores, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: %w", err)
+	}
+	if err := os.WriteFile(c.path, content, 0o600); err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+	return nil
+}
+
+func writeDependencyTreeReport(w io.Writer, o *depsTreeOptions, report *dependencyTreeReport) error {
+	if o.format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("encoder.Encode: %w", err)
+		}
+		return nil
+	}
+
+	if report.SupplyChainScore != nil {
+		fmt.Fprintf(w, "Supply chain score: %.1f\n\n", *report.SupplyChainScore)
+	} else {
+		fmt.Fprintf(w, "Supply chain score: not scored\n\n")
+	}
+
+	byID := make(map[string]*dependencyTreeNode, len(report.Dependencies))
+	for i := range report.Dependencies {
+		byID[report.Dependencies[i].ID] = &report.Dependencies[i]
+	}
+	// Dependencies are expanded once, like `npm ls` marks repeated dependencies with (*).
+	expanded := make(map[string]bool)
+	var printNode func(n *dependencyTreeNode, depth int)
+	printNode = func(n *dependencyTreeNode, depth int) {
+		indent := strings.Repeat("  ", depth-1)
+		repeated := ""
+		if expanded[n.ID] && len(n.Dependencies) > 0 {
+			repeated = " (*)"
+		}
+		fmt.Fprintf(w, "%s%s%s: %s\n", indent, n.ID, repeated, formatTreeNodeScore(n))
+		if repeated != "" || (o.maxDepth > 0 && depth >= o.maxDepth) {
+			return
+		}
+		expanded[n.ID] = true
+		for _, id := range n.Dependencies {
+			printNode(byID[id], depth+1)
+		}
+	}
+	for i := range report.Dependencies {
+		if report.Dependencies[i].Direct {
+			printNode(&report.Dependencies[i], 1)
+		}
+	}
+
+	if len(report.WeakestPaths) > 0 {
+		fmt.Fprintf(w, "\nWeakest paths:\n")
+		for _, p := range report.WeakestPaths {
+			fmt.Fprintf(w, "%.1f %s\n", p.Score, strings.Join(p.Path, " > "))
+		}
+	}
+	if len(report.LockfileErrors) > 0 {
+		fmt.Fprintf(w, "\nLockfiles which cannot be parsed:\n")
+		for _, e := range report.LockfileErrors {
+			fmt.Fprintf(w, "%s: %s\n", e.Lockfile, e.Error)
+		}
+	}
+	return nil
+}
+
+func formatTreeNodeScore(n *dependencyTreeNode) string {
+	switch {
+	case n.Error != "":
+		return "error: " + n.Error
+	case n.Score == nil && n.WeakestScore == nil:
+		return "not scored"
+	case n.Score == nil:
+		return fmt.Sprintf("not scored, weakest %.1f", *n.WeakestScore)
+	case n.WeakestScore != nil && *n.WeakestScore < *n.Score:
+		return fmt.Sprintf("%.1f %s, weakest %.1f", *n.Score, n.SourceRepository, *n.WeakestScore)
+	default:
+		return fmt.Sprintf("%.1f %s", *n.Score, n.SourceRepository)
+	}
+}
+
+// writeDependencyTreeSBOM writes the scores as the properties of the components of the SBOM read by --sbom,
+// or of an SBOM of the dependency graph when the formats differ.
+func writeDependencyTreeSBOM(w io.Writer, o *depsTreeOptions, report *dependencyTreeReport,
+	g *lockfile.Graph, input *sbom.SBOM,
+) error {
+	s := input
+	if s == nil || string(s.Format) != o.format {
+		name := o.local
+		if o.lockfile != "" {
+			name = o.lockfile
+		} else if o.sbom != "" {
+			name = o.sbom
+		}
+		if abs, err := filepath.Abs(name); err == nil {
+			name = filepath.Base(abs)
+		}
+		var err error
+		s, err = sbom.New(sbom.Format(o.format), name, "", g)
+		if err != nil {
+			return fmt.Errorf("sbom.New: %w", err)
+		}
+	}
+
+	for i := range report.Dependencies {
+		n := &report.Dependencies[i]
+		var properties []sbom.Property
+		if n.SourceRepository != "" {
+			properties = append(properties, sbom.Property{Name: sbom.PropertyRepository, Value: n.SourceRepository})
+		}
+		if n.Score != nil {
+			properties = append(properties, sbom.Property{Name: sbom.PropertyScore, Value: fmt.Sprintf("%.1f", *n.Score)})
+		}
+		if n.WeakestScore != nil {
+			properties = append(properties,
+				sbom.Property{Name: sbom.PropertyWeakestScore, Value: fmt.Sprintf("%.1f", *n.WeakestScore)})
+		}
+		if n.Error != "" {
+			properties = append(properties, sbom.Property{Name: sbom.PropertyError, Value: n.Error})
+		}
+		if len(properties) > 0 {
+			s.Annotate(i, properties)
+		}
+	}
+	if report.SupplyChainScore != nil {
+		s.AnnotateRoot([]sbom.Property{
+			{Name: sbom.PropertySupplyChainScore, Value: fmt.Sprintf("%.1f", *report.SupplyChainScore)},
+		})
+	}
+	if err := s.Write(w); err != nil {
+		return fmt.Errorf("Write: %w", err)
+	}
+	return nil
+}
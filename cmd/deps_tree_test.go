@@ -0,0 +1,213 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/lockfile"
+	sclog "github.com/ossf/scorecard/v4/log"
+)
+
+func Test_depsTreeRunner_report(t *testing.T) {
+	t.Parallel()
+	dir := writeFiles(t, map[string]string{
+		"package-lock.json": `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"dependencies": {"a": "^1.0.0", "b": "^1.0.0"}},
+    "node_modules/a": {"version": "1.0.0", "dependencies": {"c": "^1.0.0"}},
+    "node_modules/b": {"version": "1.0.0", "dependencies": {"c": "^1.0.0", "d": "^1.0.0"}},
+    "node_modules/c": {"version": "1.0.0", "dependencies": {"a": "^1.0.0"}},
+    "node_modules/d": {"version": "1.0.0"}
+  }
+}`,
+		"web/package-lock.json": "{",
+		"testdata/Cargo.lock":   "not a lockfile",
+	})
+	g, _, err := loadDependencyGraph(context.Background(), sclog.NewLogger(sclog.DefaultLevel),
+		&depsTreeOptions{local: dir})
+	if err != nil {
+		t.Fatalf("loadDependencyGraph: %v", err)
+	}
+
+	scores := map[string]float64{"a": 8, "b": 6, "c": 2}
+	scored := 0
+	cache, err := loadScoreCache("", 0)
+	if err != nil {
+		t.Fatalf("loadScoreCache: %v", err)
+	}
+	r := &depsTreeRunner{
+		resolve: func(d lockfile.Dependency) (string, error) {
+			if _, ok := scores[d.Name]; !ok {
+				return "", nil
+			}
+			return "https://github.com/o/" + d.Name, nil
+		},
+		score: func(repo string) (float64, error) {
+			scored++
+			return scores[strings.TrimPrefix(repo, "https://github.com/o/")], nil
+		},
+		cache: cache,
+	}
+	report := r.report(g, 1)
+
+	if scored != 3 {
+		t.Errorf("scored %d repositories, want 3", scored)
+	}
+	wantPaths := []dependencyPath{{Score: 2, Path: []string{"a@1.0.0", "c@1.0.0"}}}
+	if diff := cmp.Diff(wantPaths, report.WeakestPaths); diff != "" {
+		t.Errorf("WeakestPaths mismatch (-want +got): %s", diff)
+	}
+	type summary struct {
+		Depth   int
+		Weakest float64
+	}
+	got := make(map[string]summary)
+	for _, n := range report.Dependencies {
+		s := summary{Depth: n.Depth}
+		if n.WeakestScore != nil {
+			s.Weakest = *n.WeakestScore
+		}
+		got[n.ID] = s
+	}
+	want := map[string]summary{
+		"a@1.0.0": {Depth: 1, Weakest: 2},
+		"b@1.0.0": {Depth: 1, Weakest: 2},
+		"c@1.0.0": {Depth: 2, Weakest: 2},
+		"d@1.0.0": {Depth: 2},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Dependencies mismatch (-want +got): %s", diff)
+	}
+	// (8*1 + 6*1 + 2/2) / (1 + 1 + 1/2)
+	if report.SupplyChainScore == nil || *report.SupplyChainScore != 6 {
+		t.Errorf("SupplyChainScore = %v, want 6", report.SupplyChainScore)
+	}
+
+	var out bytes.Buffer
+	if err := writeDependencyTreeReport(&out, &depsTreeOptions{format: "default"}, &report); err != nil {
+		t.Fatalf("writeDependencyTreeReport: %v", err)
+	}
+	wantOut := `Supply chain score: 6.0
+
+a@1.0.0: 8.0 https://github.com/o/a, weakest 2.0
+  c@1.0.0: 2.0 https://github.com/o/c
+    a@1.0.0 (*): 8.0 https://github.com/o/a, weakest 2.0
+b@1.0.0: 6.0 https://github.com/o/b, weakest 2.0
+  c@1.0.0 (*): 2.0 https://github.com/o/c
+  d@1.0.0: not scored
+
+Weakest paths:
+2.0 a@1.0.0 > c@1.0.0
+
+Lockfiles which cannot be parsed:
+web/package-lock.json: internal error: web/package-lock.json: json.Unmarshal: unexpected end of JSON input
+`
+	if diff := cmp.Diff(wantOut, out.String()); diff != "" {
+		t.Errorf("writeDependencyTreeReport() mismatch (-want +got): %s", diff)
+	}
+}
+
+func Test_writeDependencyTreeSBOM(t *testing.T) {
+	t.Parallel()
+	dir := writeFiles(t, map[string]string{
+		"bom.json": `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "version": 1,
+  "components": [
+    {"bom-ref": "rails", "type": "library", "name": "rails", "version": "7.0.0", "purl": "pkg:gem/rails@7.0.0"}
+  ]
+}`,
+	})
+	o := &depsTreeOptions{sbom: filepath.Join(dir, "bom.json"), format: "cyclonedx"}
+	g, input, err := loadDependencyGraph(context.Background(), sclog.NewLogger(sclog.DefaultLevel), o)
+	if err != nil {
+		t.Fatalf("loadDependencyGraph: %v", err)
+	}
+	cache, err := loadScoreCache("", 0)
+	if err != nil {
+		t.Fatalf("loadScoreCache: %v", err)
+	}
+	r := &depsTreeRunner{
+		resolve: func(d lockfile.Dependency) (string, error) {
+			if d.Ecosystem != lockfile.EcosystemRubyGems {
+				t.Errorf("resolve(%v): want a RubyGems dependency", d)
+			}
+			return "https://github.com/rails/rails", nil
+		},
+		score: func(repo string) (float64, error) {
+			return 7.5, nil
+		},
+		cache: cache,
+	}
+	report := r.report(g, 1)
+
+	var out bytes.Buffer
+	if err := writeDependencyTreeSBOM(&out, o, &report, g, input); err != nil {
+		t.Fatalf("writeDependencyTreeSBOM: %v", err)
+	}
+	for _, want := range []string{
+		`"bom-ref": "rails"`,
+		`"name": "ossf:scorecard:score",
+          "value": "7.5"`,
+		`"name": "ossf:scorecard:supply-chain-score",
+        "value": "7.5"`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("writeDependencyTreeSBOM() output does not contain %s:\n%s", want, out.String())
+		}
+	}
+}
+
+func Test_scoreCache(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "scores.json")
+	now := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	c, err := loadScoreCache(path, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("loadScoreCache: %v", err)
+	}
+	c.now = func() time.Time { return now }
+	c.put("https://github.com/o/r", 7.5)
+	if err := c.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	c, err = loadScoreCache(path, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("loadScoreCache: %v", err)
+	}
+	c.now = func() time.Time { return now.Add(time.Hour) }
+	if score, ok := c.get("https://github.com/o/r"); !ok || score != 7.5 {
+		t.Errorf("get() = %v, %v, want 7.5, true", score, ok)
+	}
+	if _, ok := c.get("https://github.com/o/other"); ok {
+		t.Errorf("get() of an uncached repository succeeded")
+	}
+	c.now = func() time.Time { return now.Add(48 * time.Hour) }
+	if _, ok := c.get("https://github.com/o/r"); ok {
+		t.Errorf("get() of an expired score succeeded")
+	}
+}
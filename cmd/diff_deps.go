@@ -0,0 +1,360 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/git"
+	"github.com/ossf/scorecard/v4/clients/localdir"
+	pmc "github.com/ossf/scorecard/v4/cmd/internal/packagemanager"
+	docs "github.com/ossf/scorecard/v4/docs/checks"
+	"github.com/ossf/scorecard/v4/lockfile"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+	"github.com/ossf/scorecard/v4/policy"
+)
+
+var (
+	errBaseAndHead   = errors.New("--base and --head must be set")
+	errScoreBelowMin = errors.New("dependencies scored below the minimum score")
+	errUnknownFormat = errors.New("unknown format")
+)
+
+type diffDepsOptions struct {
+	repo        string
+	base        string
+	head        string
+	format      string
+	checks      []string
+	changeTypes []string
+	minScore    float64
+}
+
+// dependencyDiffResult is a dependency change along with the Scorecard score of its source repository.
+type dependencyDiffResult struct {
+	ChangeType       lockfile.ChangeType `json:"changeType"`
+	Ecosystem        lockfile.Ecosystem  `json:"ecosystem"`
+	Name             string              `json:"name"`
+	Version          string              `json:"version,omitempty"`
+	PreviousVersion  string              `json:"previousVersion,omitempty"`
+	Lockfile         string              `json:"lockfile"`
+	SourceRepository string              `json:"sourceRepository,omitempty"`
+	// Score is the aggregate score of the source repository, nil when it was not scored.
+	Score *float64 `json:"score,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+// diffDepsRunner resolves and scores the dependencies which changed. The functions are replaced in tests.
+type diffDepsRunner struct {
+	open    func(repo, ref string) (lockfile.FileReader, func(), error)
+	resolve func(d lockfile.Dependency) (string, error)
+	score   func(repo string) (float64, error)
+}
+
+func diffDepsCmd() *cobra.Command {
+	o := &diffDepsOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff-deps --base=<dir|commit> --head=<dir|commit> [--repo=<repo>] [--min-score=<score>]",
+		Short: "Score the dependencies added or updated between two versions of a project",
+		Long: `Compares the lockfiles of two versions of a project, resolves the dependencies which were added,
+updated or removed to their source repositories, and scores them.
+--base and --head are local checkouts, or commits of the git repository set by --repo.
+Lockfiles of npm, PyPI, Go, Maven, Cargo and NuGet are supported.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.base == "" || o.head == "" {
+				return errBaseAndHead
+			}
+			cmd.SilenceUsage = true
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			logger := sclog.NewLogger(sclog.DefaultLevel)
+			r, err := newDiffDepsRunner(ctx, logger, o.checks)
+			if err != nil {
+				return err
+			}
+			return r.run(o, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&o.base, "base", "", "local checkout, or commit of --repo, before the change")
+	cmd.Flags().StringVar(&o.head, "head", "", "local checkout, or commit of --repo, after the change")
+	cmd.Flags().StringVar(&o.repo, "repo", "", "git repository, as a URL or a local path, whose commits are compared")
+	cmd.Flags().StringVar(&o.format, "format", "default", "output format: default or json")
+	cmd.Flags().StringSliceVar(&o.checks, "checks", nil, "checks to run on the dependencies, all checks by default")
+	cmd.Flags().StringSliceVar(&o.changeTypes, "change-types",
+		[]string{string(lockfile.Added), string(lockfile.Updated)}, "types of changes whose dependencies are scored")
+	cmd.Flags().Float64Var(&o.minScore, "min-score", 0,
+		"fail if a scored dependency has an aggregate score lower than this")
+	return cmd
+}
+
+func newDiffDepsRunner(ctx context.Context, logger *sclog.Logger, checkNames []string) (*diffDepsRunner, error) {
+	checkDocs, err := docs.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read yaml file: %w", err)
+	}
+	enabledChecks, err := policy.GetEnabled(nil, checkNames, nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetEnabled: %w", err)
+	}
+	manager := &pmc.PackageManagerClient{}
+	return &diffDepsRunner{
+		open: func(repo, ref string) (lockfile.FileReader, func(), error) {
+			return openLockfiles(ctx, logger, repo, ref)
+		},
+		resolve: func(d lockfile.Dependency) (string, error) {
+			return fetchGitRepositoryFromLockfileDependency(d, manager)
+		},
+		score: func(repo string) (float64, error) {
+			return scoreRepository(ctx, logger, repo, enabledChecks, checkDocs)
+		},
+	}, nil
+}
+
+// openLockfiles returns the files of a local checkout, or of a commit of a git repository.
+func openLockfiles(ctx context.Context, logger *sclog.Logger, repo, ref string,
+) (lockfile.FileReader, func(), error) {
+	if repo == "" {
+		localRepo, err := localdir.MakeLocalDirRepo(ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("MakeLocalDirRepo: %w", err)
+		}
+		client := localdir.CreateLocalDirClient(ctx, logger)
+		if err := client.InitRepo(localRepo, clients.HeadSHA, 0); err != nil {
+			return nil, nil, fmt.Errorf("InitRepo: %w", err)
+		}
+		return client, func() { client.Close() }, nil
+	}
+
+	uri := repo
+	if info, err := os.Stat(repo); err == nil && info.IsDir() {
+		abs, err := filepath.Abs(repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("filepath.Abs: %w", err)
+		}
+		uri = "file://" + abs
+	}
+	client := &git.Client{}
+	if err := client.InitRepo(uri, ref, 0); err != nil {
+		return nil, nil, fmt.Errorf("InitRepo: %w", err)
+	}
+	return client, func() { client.Close() }, nil
+}
+
+func (r *diffDepsRunner) run(o *diffDepsOptions, w io.Writer) error {
+	if o.format != "default" && o.format != "json" {
+		return fmt.Errorf("%w: %s", errUnknownFormat, o.format)
+	}
+	base, baseErrs, err := r.collect(o.repo, o.base)
+	if err != nil {
+		return err
+	}
+	head, headErrs, err := r.collect(o.repo, o.head)
+	if err != nil {
+		return err
+	}
+
+	// Lockfiles which cannot be parsed are reported first, as their dependencies are missing from the diff.
+	var results []dependencyDiffResult
+	for _, pe := range [][]lockfile.ParseError{baseErrs, headErrs} {
+		for i := range pe {
+			results = append(results, dependencyDiffResult{Lockfile: pe[i].Path, Error: pe[i].Error()})
+		}
+	}
+
+	// Dependencies may be locked by several lockfiles, so repositories are scored once.
+	scores := make(map[string]float64)
+	belowMin := 0
+	for _, c := range lockfile.Diff(base, head) {
+		result := dependencyDiffResult{
+			ChangeType:      c.Type,
+			Ecosystem:       c.Dependency.Ecosystem,
+			Name:            c.Dependency.Name,
+			Version:         c.Dependency.Version,
+			PreviousVersion: c.PreviousVersion,
+			Lockfile:        c.Dependency.Path,
+		}
+		if isChangeTypeSelected(c.Type, o.changeTypes) {
+			r.scoreDependency(c.Dependency, &result, scores)
+		}
+		if result.Score != nil && *result.Score >= 0 && *result.Score < o.minScore {
+			belowMin++
+		}
+		results = append(results, result)
+	}
+
+	if err := writeDependencyDiffResults(w, o.format, results); err != nil {
+		return err
+	}
+	if belowMin > 0 {
+		return fmt.Errorf("%w: %d dependencies scored below %.1f", errScoreBelowMin, belowMin, o.minScore)
+	}
+	return nil
+}
+
+func (r *diffDepsRunner) collect(repo, ref string) ([]lockfile.Dependency, []lockfile.ParseError, error) {
+	files, closeFiles, err := r.open(repo, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeFiles()
+	deps, parseErrs, err := lockfile.Collect(files)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", ref, err)
+	}
+	for i := range parseErrs {
+		parseErrs[i].Err = fmt.Errorf("%s: %w", ref, parseErrs[i].Err)
+	}
+	return deps, parseErrs, nil
+}
+
+// scoreDependency resolves the source repository of a dependency and scores it.
+// Errors are recorded in the result so the other dependencies are still scored.
+func (r *diffDepsRunner) scoreDependency(d lockfile.Dependency, result *dependencyDiffResult,
+	scores map[string]float64,
+) {
+	repo, err := r.resolve(d)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	if repo == "" {
+		return
+	}
+	result.SourceRepository = repo
+
+	score, ok := scores[repo]
+	if !ok {
+		score, err = r.score(repo)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		scores[repo] = score
+	}
+	result.Score = &score
+}
+
+func isChangeTypeSelected(t lockfile.ChangeType, changeTypes []string) bool {
+	for _, ct := range changeTypes {
+		if strings.EqualFold(ct, string(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchGitRepositoryFromLockfileDependency returns the source repository of a dependency,
+// or an empty string when it cannot be resolved for the ecosystem.
+func fetchGitRepositoryFromLockfileDependency(d lockfile.Dependency, manager pmc.Client) (string, error) {
+	var o options.Options
+	switch d.Ecosystem {
+	case lockfile.EcosystemNpm:
+		o.NPM = d.Name
+	case lockfile.EcosystemPyPI:
+		o.PyPI = d.Name
+	case lockfile.EcosystemRubyGems:
+		o.RubyGems = d.Name
+	case lockfile.EcosystemNuGet:
+		o.Nuget = d.Name
+	case lockfile.EcosystemMaven:
+		o.Maven = d.Name
+		// The POM of the locked version declares the repository, unless the version is a range.
+		if d.Version != "" && !strings.ContainsAny(d.Version, "[]()$,") {
+			o.Maven += ":" + d.Version
+		}
+	case lockfile.EcosystemCrates:
+		o.Cargo = d.Name
+	case lockfile.EcosystemGo:
+		o.Go = d.Name
+	default:
+		return "", nil
+	}
+	resp, err := fetchGitRepositoryFromPackageManagers(&o, manager)
+	if err != nil {
+		return "", err
+	}
+	return resp.associatedRepo, nil
+}
+
+func scoreRepository(ctx context.Context, logger *sclog.Logger, repoURL string,
+	enabledChecks checker.CheckNameToFnMap, checkDocs docs.Doc,
+) (float64, error) {
+	repo, repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, err := checker.GetClients(ctx, repoURL, "", logger)
+	if err != nil {
+		return 0, fmt.Errorf("GetClients: %w", err)
+	}
+	defer repoClient.Close()
+	if ossFuzzRepoClient != nil {
+		defer ossFuzzRepoClient.Close()
+	}
+	result, err := pkg.RunScorecard(ctx, repo, clients.HeadSHA, 0, enabledChecks,
+		repoClient, ossFuzzRepoClient, ciiClient, vulnsClient)
+	if err != nil {
+		return 0, fmt.Errorf("RunScorecard: %w", err)
+	}
+	score, err := result.GetAggregateScore(checkDocs)
+	if err != nil {
+		return 0, fmt.Errorf("GetAggregateScore: %w", err)
+	}
+	return score, nil
+}
+
+func writeDependencyDiffResults(w io.Writer, format string, results []dependencyDiffResult) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		for i := range results {
+			if err := encoder.Encode(results[i]); err != nil {
+				return fmt.Errorf("encoder.Encode: %w", err)
+			}
+		}
+		return nil
+	}
+
+	for i := range results {
+		r := &results[i]
+		if r.ChangeType == "" {
+			fmt.Fprintf(w, "%-8s %s: %s\n", "error", r.Lockfile, r.Error)
+			continue
+		}
+		version := r.Version
+		if r.PreviousVersion != "" {
+			version = r.PreviousVersion + " -> " + r.Version
+		}
+		score := "not scored"
+		switch {
+		case r.Error != "":
+			score = "error: " + r.Error
+		case r.Score != nil:
+			score = fmt.Sprintf("%.1f %s", *r.Score, r.SourceRepository)
+		}
+		fmt.Fprintf(w, "%-8s %-10s %s %s (%s): %s\n", r.ChangeType, r.Ecosystem, r.Name, version, r.Lockfile, score)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	pmc "github.com/ossf/scorecard/v4/cmd/internal/packagemanager"
+	"github.com/ossf/scorecard/v4/lockfile"
+	sclog "github.com/ossf/scorecard/v4/log"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	return dir
+}
+
+func Test_diffDepsRunner_run(t *testing.T) {
+	t.Parallel()
+	base := writeFiles(t, map[string]string{
+		"go.mod":           "module m\n\nrequire (\n\tgithub.com/a/updated v1.0.0\n\tgithub.com/a/removed v1.0.0\n)\n",
+		"requirements.txt": "requests==2.31.0\n",
+	})
+	head := writeFiles(t, map[string]string{
+		"go.mod":            "module m\n\nrequire (\n\tgithub.com/a/updated v1.1.0\n\tgithub.com/a/added v1.0.0\n)\n",
+		"requirements.txt":  "requests==2.31.0\nunresolved==1.0.0\n",
+		"package-lock.json": "{",
+	})
+
+	scored := 0
+	r := &diffDepsRunner{
+		open: func(repo, ref string) (lockfile.FileReader, func(), error) {
+			return openLockfiles(context.Background(), sclog.NewLogger(sclog.DefaultLevel), repo, ref)
+		},
+		resolve: func(d lockfile.Dependency) (string, error) {
+			if d.Ecosystem == lockfile.EcosystemPyPI {
+				return "", errors.New("could not find source repo") //nolint:goerr113
+			}
+			return fetchGitRepositoryFromLockfileDependency(d, nil)
+		},
+		score: func(repo string) (float64, error) {
+			scored++
+			if strings.HasSuffix(repo, "/added") {
+				return 3, nil
+			}
+			return 8, nil
+		},
+	}
+
+	var out bytes.Buffer
+	err := r.run(&diffDepsOptions{
+		base:        base,
+		head:        head,
+		format:      "json",
+		changeTypes: []string{"added", "updated"},
+		minScore:    5,
+	}, &out)
+	if !errors.Is(err, errScoreBelowMin) {
+		t.Errorf("run() error = %v, want %v", err, errScoreBelowMin)
+	}
+	if scored != 2 {
+		t.Errorf("scored %d repositories, want 2", scored)
+	}
+
+	want := []string{
+		`{"changeType":"","ecosystem":"","name":"","lockfile":"package-lock.json","error":"` + head +
+			`: internal error: package-lock.json: json.Unmarshal: unexpected end of JSON input"}`,
+		`{"changeType":"added","ecosystem":"Go","name":"github.com/a/added","version":"v1.0.0",` +
+			`"lockfile":"go.mod","sourceRepository":"https://github.com/a/added","score":3}`,
+		`{"changeType":"removed","ecosystem":"Go","name":"github.com/a/removed","version":"v1.0.0","lockfile":"go.mod"}`,
+		`{"changeType":"updated","ecosystem":"Go","name":"github.com/a/updated","version":"v1.1.0",` +
+			`"previousVersion":"v1.0.0","lockfile":"go.mod","sourceRepository":"https://github.com/a/updated","score":8}`,
+		`{"changeType":"added","ecosystem":"PyPI","name":"unresolved","version":"1.0.0",` +
+			`"lockfile":"requirements.txt","error":"could not find source repo"}`,
+	}
+	got := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("run() output mismatch (-want +got): %s", diff)
+	}
+}
+
+func Test_fetchGitRepositoryFromLockfileDependency(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		dependency lockfile.Dependency
+		response   string
+		want       string
+	}{
+		{
+			name:       "go module on github",
+			dependency: lockfile.Dependency{Ecosystem: lockfile.EcosystemGo, Name: "github.com/Owner/Repo/v2"},
+			want:       "https://github.com/owner/repo",
+		},
+		{
+			name:       "go module on gitlab",
+			dependency: lockfile.Dependency{Ecosystem: lockfile.EcosystemGo, Name: "gitlab.com/group/project"},
+			want:       "https://gitlab.com/group/project",
+		},
+		{
+			name:       "vanity go module",
+			dependency: lockfile.Dependency{Ecosystem: lockfile.EcosystemGo, Name: "golang.org/x/mod"},
+			response: `<meta name="go-import" content="golang.org/x/mod git https://go.googlesource.com/mod">
+<meta name="go-source" content="golang.org/x/mod https://github.com/golang/mod/ ` +
+				`https://github.com/golang/mod/tree/master{/dir} https://github.com/golang/mod/blob/master{/dir}/{file}#L{line}">`,
+			want: "https://github.com/golang/mod",
+		},
+		{
+			name:       "crate",
+			dependency: lockfile.Dependency{Ecosystem: lockfile.EcosystemCrates, Name: "serde"},
+			response:   `{"crate": {"repository": "https://github.com/serde-rs/serde"}}`,
+			want:       "https://github.com/serde-rs/serde",
+		},
+		{
+			name:       "npm",
+			dependency: lockfile.Dependency{Ecosystem: lockfile.EcosystemNpm, Name: "lodash"},
+			response: `{"objects": [{"package": {"links": ` +
+				`{"repository": "git+https://github.com/lodash/lodash.git"}}}]}`,
+			want: "https://github.com/lodash/lodash",
+		},
+		{
+			name:       "rubygems",
+			dependency: lockfile.Dependency{Ecosystem: lockfile.EcosystemRubyGems, Name: "rails"},
+			response:   `{"source_code_uri": "https://github.com/rails/rails/tree/v7.0.0"}`,
+			want:       "https://github.com/rails/rails",
+		},
+		{
+			name:       "unsupported ecosystem",
+			dependency: lockfile.Dependency{Ecosystem: lockfile.Ecosystem("Pub"), Name: "http"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			p := pmc.NewMockClient(ctrl)
+			p.EXPECT().Get(gomock.Any(), tt.dependency.Name).
+				DoAndReturn(func(url, packageName string) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBufferString(tt.response)),
+					}, nil
+				}).AnyTimes()
+			p.EXPECT().GetURI(gomock.Any()).
+				DoAndReturn(func(url string) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBufferString(tt.response)),
+					}, nil
+				}).AnyTimes()
+			got, err := fetchGitRepositoryFromLockfileDependency(tt.dependency, p)
+			if err != nil {
+				t.Fatalf("fetchGitRepositoryFromLockfileDependency() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("fetchGitRepositoryFromLockfileDependency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/githubrepo"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/remediation"
+)
+
+var errRepoOrLocal = errors.New("exactly one of --repo or --local must be set")
+
+type fixPinningOptions struct {
+	repo     string
+	local    string
+	tagCache string
+	diff     bool
+}
+
+func fixCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fix",
+		Short: "Fix issues found by the checks",
+	}
+	cmd.AddCommand(fixPinningCmd())
+	return cmd
+}
+
+func fixPinningCmd() *cobra.Command {
+	o := &fixPinningOptions{}
+	cmd := &cobra.Command{
+		Use:   "pinning (--repo=<repo> | --local=<folder>) [--diff] [--tag-cache=<file>]",
+		Short: "Pin GitHub Actions and Docker images by hash",
+		Long: `Rewrites the actions and reusable workflows used by GitHub workflows to commit SHAs,
+keeping the tag or branch as a version comment, and the images of Dockerfile FROM instructions to digests.
+Local checkouts are edited in place unless --diff is set; otherwise a unified diff is printed.
+References which cannot be resolved are skipped with a warning.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (o.repo == "") == (o.local == "") {
+				return errRepoOrLocal
+			}
+			cmd.SilenceUsage = true
+			return fixPinning(cmd.Context(), o)
+		},
+	}
+	cmd.Flags().StringVar(&o.repo, "repo", "", "repository to pin, e.g., github.com/owner/repo")
+	cmd.Flags().StringVar(&o.local, "local", "", "local checkout to pin")
+	cmd.Flags().StringVar(&o.tagCache, "tag-cache", "",
+		"JSON file mapping owner/repo@ref to commit SHAs, consulted before, and updated by, GitHub lookups")
+	cmd.Flags().BoolVar(&o.diff, "diff", false, "print a unified diff instead of editing a local checkout")
+	return cmd
+}
+
+func fixPinning(ctx context.Context, o *fixPinningOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	logger := sclog.NewLogger(sclog.DefaultLevel)
+	repo, repoClient, ossFuzzRepoClient, _, _, err := checker.GetClients(ctx, o.repo, o.local, logger)
+	if err != nil {
+		return fmt.Errorf("GetClients: %w", err)
+	}
+	defer repoClient.Close()
+	if ossFuzzRepoClient != nil {
+		defer ossFuzzRepoClient.Close()
+	}
+	if err := repoClient.InitRepo(repo, clients.HeadSHA, 0); err != nil {
+		return fmt.Errorf("InitRepo: %w", err)
+	}
+
+	var actions remediation.ActionResolver = &remediation.RepoClientActionResolver{
+		NewClient: func(actionRepo string) (clients.RepoClient, error) {
+			ghRepo, err := githubrepo.MakeGithubRepo(actionRepo)
+			if err != nil {
+				return nil, fmt.Errorf("MakeGithubRepo: %w", err)
+			}
+			client := githubrepo.CreateGithubRepoClient(ctx, logger)
+			if err := client.InitRepo(ghRepo, clients.HeadSHA, 0); err != nil {
+				return nil, fmt.Errorf("InitRepo: %w", err)
+			}
+			return client, nil
+		},
+	}
+	if o.tagCache != "" {
+		cache, err := remediation.LoadTagCache(o.tagCache, actions)
+		if err != nil {
+			return fmt.Errorf("LoadTagCache: %w", err)
+		}
+		defer func() {
+			if err := cache.Save(); err != nil {
+				logger.Error(err, "saving tag cache")
+			}
+		}()
+		actions = cache
+	}
+	images := remediation.CraneDigester{}
+
+	files, err := repoClient.ListFiles(func(path string) (bool, error) {
+		return isPinnableFile(path), nil
+	})
+	if err != nil {
+		return fmt.Errorf("ListFiles: %w", err)
+	}
+
+	for _, f := range files {
+		content, err := repoClient.GetFileContent(f)
+		if err != nil {
+			return fmt.Errorf("GetFileContent: %w", err)
+		}
+		var edits []remediation.LineEdit
+		var skipped []error
+		if isDockerfileName(f) {
+			edits, skipped = remediation.PinDockerfile(content, images)
+		} else {
+			edits, skipped = remediation.PinWorkflow(content, actions, images)
+		}
+		for _, err := range skipped {
+			fmt.Fprintf(os.Stderr, "warning: %s: %v\n", f, err)
+		}
+		if len(edits) == 0 {
+			continue
+		}
+
+		if o.local == "" || o.diff {
+			fmt.Fprint(os.Stdout, remediation.UnifiedDiff(f, content, edits))
+			continue
+		}
+		p := filepath.Join(o.local, filepath.FromSlash(f))
+		info, err := os.Stat(p)
+		if err != nil {
+			return fmt.Errorf("os.Stat: %w", err)
+		}
+		if err := os.WriteFile(p, remediation.ApplyEdits(content, edits), info.Mode()); err != nil {
+			return fmt.Errorf("os.WriteFile: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "pinned %d dependencies in %s\n", len(edits), f)
+	}
+	return nil
+}
+
+// isPinnableFile returns true for GitHub workflows, local actions and Dockerfiles.
+func isPinnableFile(path string) bool {
+	if fileparser.IsWorkflowFile(path) || isDockerfileName(path) {
+		return true
+	}
+	base := filepath.Base(path)
+	return (base == "action.yml" || base == "action.yaml") &&
+		!strings.Contains(path, "node_modules/")
+}
+
+func isDockerfileName(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	return base == "dockerfile" || strings.HasPrefix(base, "dockerfile.") ||
+		strings.HasSuffix(base, ".dockerfile")
+}
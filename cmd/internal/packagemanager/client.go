@@ -27,6 +27,8 @@ type Client interface {
 	GetURI(URI string) (*http.Response, error)
 }
 
+const userAgent = "ossf-scorecard (https://github.com/ossf/scorecard)"
+
 type PackageManagerClient struct{}
 
 // nolint: noctx
@@ -45,6 +47,11 @@ func (c *PackageManagerClient) getRemoteURL(url string) (*http.Response, error)
 	client := &http.Client{
 		Timeout: timeout * time.Second,
 	}
-	//nolint
-	return client.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequest: %w", err)
+	}
+	// Some registries, such as crates.io, reject requests without a user agent.
+	req.Header.Set("User-Agent", userAgent)
+	return client.Do(req)
 }
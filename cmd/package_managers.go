@@ -17,14 +17,20 @@ package cmd
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
+	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/package-url/packageurl-go"
+
 	ngt "github.com/ossf/scorecard/v4/cmd/internal/nuget"
 	pmc "github.com/ossf/scorecard/v4/cmd/internal/packagemanager"
 	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/options"
 )
 
 var (
@@ -65,42 +71,59 @@ var pypiMatchers = []func(string) string{
 	},
 }
 
+// Ecosystems of the packages set by `--npm`, `--pypi`, etc.
+const (
+	ecosystemNPM       = "npm"
+	ecosystemPyPI      = "PyPI"
+	ecosystemRubyGems  = "RubyGems"
+	ecosystemNuGet     = "NuGet"
+	ecosystemMaven     = "Maven"
+	ecosystemCargo     = "Cargo"
+	ecosystemGo        = "Go"
+	ecosystemPackagist = "Packagist"
+	ecosystemHex       = "Hex"
+	ecosystemPURL      = "purl"
+)
+
 type packageMangerResponse struct {
 	associatedRepo string
+	ecosystem      string
+	packageName    string
 	exists         bool
 }
 
-func fetchGitRepositoryFromPackageManagers(npm, pypi, rubygems, nuget string,
-	manager pmc.Client,
-) (packageMangerResponse, error) {
-	if npm != "" {
-		gitRepo, err := fetchGitRepositoryFromNPM(npm, manager)
-		return packageMangerResponse{
-			exists:         true,
-			associatedRepo: gitRepo,
-		}, err
-	}
-	if pypi != "" {
-		gitRepo, err := fetchGitRepositoryFromPYPI(pypi, manager)
-		return packageMangerResponse{
-			exists:         true,
-			associatedRepo: gitRepo,
-		}, err
-	}
-	if rubygems != "" {
-		gitRepo, err := fetchGitRepositoryFromRubyGems(rubygems, manager)
-		return packageMangerResponse{
-			exists:         true,
-			associatedRepo: gitRepo,
-		}, err
-	}
-	if nuget != "" {
-		nugetClient := ngt.NugetClient{Manager: manager}
-		gitRepo, err := fetchGitRepositoryFromNuget(nuget, nugetClient)
-		return packageMangerResponse{
-			exists:         true,
-			associatedRepo: gitRepo,
-		}, err
+func fetchGitRepositoryFromPackageManagers(o *options.Options, manager pmc.Client) (packageMangerResponse, error) {
+	fetchers := []struct {
+		ecosystem   string
+		packageName string
+		fetch       func(packageName string, manager pmc.Client) (string, error)
+	}{
+		{ecosystemNPM, o.NPM, fetchGitRepositoryFromNPM},
+		{ecosystemPyPI, o.PyPI, fetchGitRepositoryFromPYPI},
+		{ecosystemRubyGems, o.RubyGems, fetchGitRepositoryFromRubyGems},
+		{ecosystemNuGet, o.Nuget, func(packageName string, manager pmc.Client) (string, error) {
+			return fetchGitRepositoryFromNuget(packageName, ngt.NugetClient{Manager: manager})
+		}},
+		{ecosystemMaven, o.Maven, fetchGitRepositoryFromMaven},
+		{ecosystemCargo, o.Cargo, fetchGitRepositoryFromCargo},
+		{ecosystemGo, o.Go, fetchGitRepositoryFromGo},
+		{ecosystemPackagist, o.Packagist, fetchGitRepositoryFromPackagist},
+		{ecosystemHex, o.Hex, fetchGitRepositoryFromHex},
+		{ecosystemPURL, o.PURL, fetchGitRepositoryFromPURL},
+	}
+	for _, f := range fetchers {
+		if f.packageName != "" {
+			gitRepo, err := f.fetch(f.packageName, manager)
+			if gitRepo != "" {
+				gitRepo = normalizeRepositoryURL(gitRepo)
+			}
+			return packageMangerResponse{
+				exists:         true,
+				associatedRepo: gitRepo,
+				ecosystem:      f.ecosystem,
+				packageName:    f.packageName,
+			}, err
+		}
 	}
 
 	return packageMangerResponse{}, nil
@@ -221,3 +244,329 @@ func fetchGitRepositoryFromNuget(packageName string, nugetClient ngt.Client) (st
 	}
 	return repositoryURI, nil
 }
+
+// repositoryURLPrefixes are stripped from the repository URLs of registries, e.g., scm:git:git@github.com:o/r.git.
+var repositoryURLPrefixes = []string{"scm:git:", "scm:svn:", "scm:hg:", "git+", "ssh://", "git://", "git@"}
+
+// normalizeRepositoryURL returns an https URL for a repository URL of a registry. The URLs of
+// GitHub and GitLab repositories are reduced to the repository, dropping monorepo subdirectories.
+func normalizeRepositoryURL(url string) string {
+	url = strings.TrimSpace(url)
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, prefix := range repositoryURLPrefixes {
+			if strings.HasPrefix(url, prefix) {
+				url = strings.TrimPrefix(url, prefix)
+				trimmed = true
+			}
+		}
+	}
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		// host:owner/repo, as used by scp-like git URLs.
+		if i := strings.Index(url, ":"); i > 0 && !strings.Contains(url[:i], "/") {
+			url = url[:i] + "/" + url[i+1:]
+		}
+		url = "https://" + url
+	}
+	url = strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	if repo := hostedRepository(url); repo != "" {
+		return repo
+	}
+	return url
+}
+
+// hostedRepository returns the GitHub or GitLab repository of a URL, or an empty string for other URLs.
+func hostedRepository(url string) string {
+	if repo := makeGithubRepo(githubDomainRegexp.FindStringSubmatch(url)); repo != "" {
+		return repo
+	}
+	if match := gitlabDomainRegexp.FindStringSubmatch(url); len(match) >= 3 {
+		// GitLab projects may be in subgroups, and their pages are separated by /-/.
+		path := strings.TrimPrefix(url[strings.Index(url, "gitlab.com/"):], "gitlab.com/")
+		if i := strings.Index(path, "/-/"); i >= 0 {
+			path = path[:i]
+		}
+		return strings.ToLower("https://gitlab.com/" + strings.TrimSuffix(path, ".git"))
+	}
+	return ""
+}
+
+// selectRepository returns the source repository of a package among the URLs of its metadata.
+// sources are the URLs declared as the source repository and others the remaining URLs, such as
+// homepages, each from the most to the least specific. The first GitHub or GitLab repository is
+// selected; otherwise the first source, which may be hosted elsewhere.
+func selectRepository(ecosystem, packageName string, sources, others []string) (string, error) {
+	for _, candidates := range [][]string{sources, others} {
+		for _, url := range candidates {
+			if url == "" {
+				continue
+			}
+			if repo := hostedRepository(normalizeRepositoryURL(url)); repo != "" {
+				return repo, nil
+			}
+		}
+	}
+	for _, url := range sources {
+		if url != "" {
+			return normalizeRepositoryURL(url), nil
+		}
+	}
+	return "", sce.WithMessage(sce.ErrScorecardInternal,
+		fmt.Sprintf("could not find source repo for %s package: %s", ecosystem, packageName))
+}
+
+// getPackageMetadata decodes the JSON metadata of a package.
+func getPackageMetadata(ecosystem string, resp *http.Response, err error, v interface{}) error {
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to get %s package json: %v", ecosystem, err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("failed to get %s package json: %s", ecosystem, resp.Status))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to parse %s package json: %v", ecosystem, err))
+	}
+	return nil
+}
+
+type mavenMetadata struct {
+	Versioning struct {
+		Latest  string `xml:"latest"`
+		Release string `xml:"release"`
+	} `xml:"versioning"`
+}
+
+type mavenPOM struct {
+	URL string `xml:"url"`
+	SCM struct {
+		URL                 string `xml:"url"`
+		Connection          string `xml:"connection"`
+		DeveloperConnection string `xml:"developerConnection"`
+	} `xml:"scm"`
+	Parent struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	} `xml:"parent"`
+}
+
+// mavenParentDepth bounds the parent POMs followed to find an inherited source repository.
+const mavenParentDepth = 3
+
+// Gets the source repository URL for the maven package, given as groupId:artifactId[:version].
+func fetchGitRepositoryFromMaven(coordinates string, manager pmc.Client) (string, error) {
+	parts := strings.Split(coordinates, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return "", sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("invalid maven package %s, expected groupId:artifactId[:version]", coordinates))
+	}
+	groupID, artifactID, version := parts[0], parts[1], ""
+	if len(parts) == 3 {
+		version = parts[2]
+	}
+	if version == "" {
+		var metadata mavenMetadata
+		if err := getMavenXML(manager, mavenURL(groupID, artifactID, "maven-metadata.xml"), &metadata); err != nil {
+			return "", err
+		}
+		version = metadata.Versioning.Release
+		if version == "" {
+			version = metadata.Versioning.Latest
+		}
+	}
+
+	for depth := 0; depth <= mavenParentDepth && version != ""; depth++ {
+		var pom mavenPOM
+		pomURL := mavenURL(groupID, artifactID, fmt.Sprintf("%s/%s-%s.pom", version, artifactID, version))
+		if err := getMavenXML(manager, pomURL, &pom); err != nil {
+			return "", err
+		}
+		sources := []string{pom.SCM.URL, pom.SCM.Connection, pom.SCM.DeveloperConnection}
+		repo, err := selectRepository("maven", coordinates, sources, []string{pom.URL})
+		if err == nil || pom.Parent.ArtifactID == "" {
+			return repo, err
+		}
+		// The source repository may be inherited from the parent POM.
+		groupID, artifactID, version = pom.Parent.GroupID, pom.Parent.ArtifactID, pom.Parent.Version
+	}
+	return "", sce.WithMessage(sce.ErrScorecardInternal,
+		fmt.Sprintf("could not find source repo for maven package: %s", coordinates))
+}
+
+func mavenURL(groupID, artifactID, file string) string {
+	return fmt.Sprintf("https://repo1.maven.org/maven2/%s/%s/%s",
+		strings.ReplaceAll(groupID, ".", "/"), artifactID, file)
+}
+
+func getMavenXML(manager pmc.Client, url string, v interface{}) error {
+	resp, err := manager.GetURI(url)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to get maven metadata: %v", err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("failed to get maven metadata %s: %s", url, resp.Status))
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to parse maven metadata: %v", err))
+	}
+	return nil
+}
+
+type cargoSearchResults struct {
+	Crate struct {
+		Repository string `json:"repository"`
+		Homepage   string `json:"homepage"`
+	} `json:"crate"`
+}
+
+// Gets the source repository URL for the cargo crate.
+func fetchGitRepositoryFromCargo(packageName string, manager pmc.Client) (string, error) {
+	resp, err := manager.Get("https://crates.io/api/v1/crates/%s", packageName)
+	v := &cargoSearchResults{}
+	if err := getPackageMetadata("cargo", resp, err, v); err != nil {
+		return "", err
+	}
+	return selectRepository("cargo", packageName, []string{v.Crate.Repository}, []string{v.Crate.Homepage})
+}
+
+type packagistSearchResults struct {
+	Package struct {
+		Repository string `json:"repository"`
+	} `json:"package"`
+}
+
+// Gets the source repository URL for the packagist package.
+func fetchGitRepositoryFromPackagist(packageName string, manager pmc.Client) (string, error) {
+	resp, err := manager.Get("https://packagist.org/packages/%s.json", packageName)
+	v := &packagistSearchResults{}
+	if err := getPackageMetadata("packagist", resp, err, v); err != nil {
+		return "", err
+	}
+	return selectRepository("packagist", packageName, []string{v.Package.Repository}, nil)
+}
+
+type hexSearchResults struct {
+	Meta struct {
+		Links map[string]string `json:"links"`
+	} `json:"meta"`
+}
+
+// hexSourceLinks matches the names of the links of hex packages pointing to their source repository.
+var hexSourceLinks = regexp.MustCompile(`(?i)^(source|source code|repository|repo|github|gitlab|code)$`)
+
+// Gets the source repository URL for the hex package.
+func fetchGitRepositoryFromHex(packageName string, manager pmc.Client) (string, error) {
+	resp, err := manager.Get("https://hex.pm/api/packages/%s", packageName)
+	v := &hexSearchResults{}
+	if err := getPackageMetadata("hex", resp, err, v); err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(v.Meta.Links))
+	for name := range v.Meta.Links {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sources, others []string
+	for _, name := range names {
+		if hexSourceLinks.MatchString(name) {
+			sources = append(sources, v.Meta.Links[name])
+		} else {
+			others = append(others, v.Meta.Links[name])
+		}
+	}
+	return selectRepository("hex", packageName, sources, others)
+}
+
+// goImportMeta matches the go-import and go-source meta tags of the pages of Go modules.
+var goImportMeta = regexp.MustCompile(`<meta\s+name=["'](go-import|go-source)["']\s+content=["']([^"']+)["']`)
+
+// Gets the source repository URL for the go module.
+func fetchGitRepositoryFromGo(module string, manager pmc.Client) (string, error) {
+	module = strings.SplitN(module, "@", 2)[0]
+	// Modules hosted on GitHub and GitLab are named after their repository.
+	if repo := hostedRepository("https://" + module); repo != "" {
+		return repo, nil
+	}
+
+	resp, err := manager.GetURI(fmt.Sprintf("https://%s?go-get=1", module))
+	if err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to get go module page: %v", err))
+	}
+	defer resp.Body.Close()
+	page, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to read go module page: %v", err))
+	}
+
+	// The meta tags have the module path prefix first; the longest matching prefix applies.
+	var sources, others []string
+	longest := -1
+	for _, m := range goImportMeta.FindAllStringSubmatch(string(page), -1) {
+		fields := strings.Fields(m[2])
+		if len(fields) < 3 || (module != fields[0] && !strings.HasPrefix(module, fields[0]+"/")) {
+			continue
+		}
+		switch m[1] {
+		case "go-import":
+			if len(fields[0]) > longest {
+				longest = len(fields[0])
+				sources = []string{fields[2]}
+			}
+		case "go-source":
+			// go-source declares the home page of the sources, e.g., on GitHub for golang.org/x modules.
+			others = append(others, fields[1])
+		}
+	}
+	return selectRepository("go", module, sources, others)
+}
+
+// Gets the source repository URL for the package identified by a package URL, e.g., pkg:npm/lodash.
+func fetchGitRepositoryFromPURL(purl string, manager pmc.Client) (string, error) {
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid package url %s: %v", purl, err))
+	}
+	name := p.Name
+	if p.Namespace != "" {
+		name = p.Namespace + "/" + p.Name
+	}
+	switch p.Type {
+	case packageurl.TypeNPM:
+		return fetchGitRepositoryFromNPM(name, manager)
+	case packageurl.TypePyPi:
+		return fetchGitRepositoryFromPYPI(p.Name, manager)
+	case packageurl.TypeGem:
+		return fetchGitRepositoryFromRubyGems(p.Name, manager)
+	case packageurl.TypeNuget:
+		return fetchGitRepositoryFromNuget(p.Name, ngt.NugetClient{Manager: manager})
+	case packageurl.TypeMaven:
+		coordinates := p.Namespace + ":" + p.Name
+		if p.Version != "" {
+			coordinates += ":" + p.Version
+		}
+		return fetchGitRepositoryFromMaven(coordinates, manager)
+	case packageurl.TypeCargo:
+		return fetchGitRepositoryFromCargo(p.Name, manager)
+	case packageurl.TypeGolang:
+		return fetchGitRepositoryFromGo(name, manager)
+	case packageurl.TypeComposer:
+		return fetchGitRepositoryFromPackagist(name, manager)
+	case packageurl.TypeHex:
+		return fetchGitRepositoryFromHex(p.Name, manager)
+	case packageurl.TypeGithub:
+		return "https://github.com/" + name, nil
+	case "gitlab":
+		return "https://gitlab.com/" + name, nil
+	case packageurl.TypeBitbucket:
+		return "https://bitbucket.org/" + name, nil
+	default:
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("unsupported package url type: %s", p.Type))
+	}
+}
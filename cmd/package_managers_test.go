@@ -18,6 +18,7 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -27,6 +28,7 @@ import (
 
 	ngt "github.com/ossf/scorecard/v4/cmd/internal/nuget"
 	pmc "github.com/ossf/scorecard/v4/cmd/internal/packagemanager"
+	"github.com/ossf/scorecard/v4/options"
 )
 
 func Test_fetchGitRepositoryFromNPM(t *testing.T) {
@@ -802,3 +804,272 @@ func Test_fetchGitRepositoryFromNuget(t *testing.T) {
 		})
 	}
 }
+
+// mockRegistry returns a package manager client serving the responses by URL, and 404 for other URLs.
+func mockRegistry(t *testing.T, responses map[string]string) pmc.Client {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	p := pmc.NewMockClient(ctrl)
+	respond := func(url string) (*http.Response, error) {
+		body, ok := responses[url]
+		if !ok {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Status:     "404 Not Found",
+				Body:       io.NopCloser(bytes.NewBufferString("")),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+		}, nil
+	}
+	p.EXPECT().Get(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(url, packageName string) (*http.Response, error) {
+			return respond(fmt.Sprintf(url, packageName))
+		}).AnyTimes()
+	p.EXPECT().GetURI(gomock.Any()).DoAndReturn(respond).AnyTimes()
+	return p
+}
+
+func Test_normalizeRepositoryURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "git+https://github.com/Owner/Repo.git", want: "https://github.com/owner/repo"},
+		{url: "scm:git:git@github.com:owner/repo.git", want: "https://github.com/owner/repo"},
+		{url: "git://github.com/owner/repo", want: "https://github.com/owner/repo"},
+		{url: "ssh://git@github.com/owner/repo.git", want: "https://github.com/owner/repo"},
+		{url: "https://github.com/owner/monorepo/tree/main/packages/a", want: "https://github.com/owner/monorepo"},
+		{url: "https://github.com/owner/repo#readme", want: "https://github.com/owner/repo"},
+		{url: "https://gitlab.com/Group/Subgroup/Project/-/tree/main/lib", want: "https://gitlab.com/group/subgroup/project"},
+		{url: "scm:git:https://gitbox.apache.org/repos/asf/commons-lang.git", want: "https://gitbox.apache.org/repos/asf/commons-lang"},
+		{url: "https://bitbucket.org/Owner/Repo/", want: "https://bitbucket.org/Owner/Repo"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			if got := normalizeRepositoryURL(tt.url); got != tt.want {
+				t.Errorf("normalizeRepositoryURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fetchGitRepositoryFromMaven(t *testing.T) {
+	t.Parallel()
+	const (
+		metadataURL = "https://repo1.maven.org/maven2/org/example/lib/maven-metadata.xml"
+		pomURL      = "https://repo1.maven.org/maven2/org/example/lib/2.0.0/lib-2.0.0.pom"
+		parentURL   = "https://repo1.maven.org/maven2/org/example/parent/5/parent-5.pom"
+	)
+	metadata := `<metadata><versioning><latest>2.1.0-SNAPSHOT</latest><release>2.0.0</release></versioning></metadata>`
+	tests := []struct {
+		name        string
+		coordinates string
+		responses   map[string]string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "scm connection of the latest release",
+			coordinates: "org.example:lib",
+			responses: map[string]string{
+				metadataURL: metadata,
+				pomURL: `<project><url>https://example.org</url>` +
+					`<scm><connection>scm:git:git@github.com:example/lib.git</connection></scm></project>`,
+			},
+			want: "https://github.com/example/lib",
+		},
+		{
+			name:        "monorepo subdirectory inherited from the parent",
+			coordinates: "org.example:lib:2.0.0",
+			responses: map[string]string{
+				pomURL: `<project><parent><groupId>org.example</groupId><artifactId>parent</artifactId>` +
+					`<version>5</version></parent></project>`,
+				parentURL: `<project><scm><url>https://github.com/example/monorepo/tree/main/parent</url></scm></project>`,
+			},
+			want: "https://github.com/example/monorepo",
+		},
+		{
+			name:        "repository hosted elsewhere",
+			coordinates: "org.example:lib:2.0.0",
+			responses: map[string]string{
+				pomURL: `<project><scm><url>https://gitbox.apache.org/repos/asf/lib.git</url></scm></project>`,
+			},
+			want: "https://gitbox.apache.org/repos/asf/lib",
+		},
+		{
+			name:        "no repository",
+			coordinates: "org.example:lib:2.0.0",
+			responses:   map[string]string{pomURL: `<project></project>`},
+			wantErr:     true,
+		},
+		{
+			name:        "unknown package",
+			coordinates: "org.example:lib",
+			wantErr:     true,
+		},
+		{
+			name:        "invalid coordinates",
+			coordinates: "lib",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := fetchGitRepositoryFromMaven(tt.coordinates, mockRegistry(t, tt.responses))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchGitRepositoryFromMaven() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("fetchGitRepositoryFromMaven() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fetchGitRepositoryFromRegistries(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		fetch     func(string, pmc.Client) (string, error)
+		pkg       string
+		responses map[string]string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:  "cargo repository",
+			fetch: fetchGitRepositoryFromCargo,
+			pkg:   "serde",
+			responses: map[string]string{
+				"https://crates.io/api/v1/crates/serde": `{"crate": {"homepage": "https://serde.rs", ` +
+					`"repository": "https://github.com/serde-rs/serde"}}`,
+			},
+			want: "https://github.com/serde-rs/serde",
+		},
+		{
+			name:  "cargo homepage on gitlab",
+			fetch: fetchGitRepositoryFromCargo,
+			pkg:   "crate",
+			responses: map[string]string{
+				"https://crates.io/api/v1/crates/crate": `{"crate": {"homepage": "https://gitlab.com/group/crate"}}`,
+			},
+			want: "https://gitlab.com/group/crate",
+		},
+		{
+			name:    "cargo unknown crate",
+			fetch:   fetchGitRepositoryFromCargo,
+			pkg:     "missing",
+			wantErr: true,
+		},
+		{
+			name:  "packagist",
+			fetch: fetchGitRepositoryFromPackagist,
+			pkg:   "symfony/console",
+			responses: map[string]string{
+				"https://packagist.org/packages/symfony/console.json": `{"package": ` +
+					`{"repository": "https://github.com/symfony/console"}}`,
+			},
+			want: "https://github.com/symfony/console",
+		},
+		{
+			name:  "hex prefers the source link",
+			fetch: fetchGitRepositoryFromHex,
+			pkg:   "phoenix",
+			responses: map[string]string{
+				"https://hex.pm/api/packages/phoenix": `{"meta": {"links": {` +
+					`"Changelog": "https://github.com/other/changelog", "GitHub": "https://github.com/phoenixframework/phoenix"}}}`,
+			},
+			want: "https://github.com/phoenixframework/phoenix",
+		},
+		{
+			name:  "go module on github",
+			fetch: fetchGitRepositoryFromGo,
+			pkg:   "github.com/Owner/Repo/sub/v2@v2.0.0",
+			want:  "https://github.com/owner/repo",
+		},
+		{
+			name:  "go vanity module",
+			fetch: fetchGitRepositoryFromGo,
+			pkg:   "example.org/mod/sub",
+			responses: map[string]string{
+				"https://example.org/mod/sub?go-get=1": `<html><head>
+<meta name="go-import" content="example.org git https://example.org/other">
+<meta name="go-import" content="example.org/mod git https://gitlab.com/example/mod.git">
+</head></html>`,
+			},
+			want: "https://gitlab.com/example/mod",
+		},
+		{
+			name:  "purl npm",
+			fetch: fetchGitRepositoryFromPURL,
+			pkg:   "pkg:npm/%40scope/name@1.0.0",
+			responses: map[string]string{
+				"https://registry.npmjs.org/-/v1/search?text=@scope/name&size=1": `{"objects": [{"package": ` +
+					`{"links": {"repository": "git+https://github.com/scope/name.git"}}}]}`,
+			},
+			want: "git+https://github.com/scope/name.git",
+		},
+		{
+			name:  "purl maven",
+			fetch: fetchGitRepositoryFromPURL,
+			pkg:   "pkg:maven/org.example/lib@2.0.0",
+			responses: map[string]string{
+				"https://repo1.maven.org/maven2/org/example/lib/2.0.0/lib-2.0.0.pom": `<project>` +
+					`<scm><url>https://github.com/example/lib</url></scm></project>`,
+			},
+			want: "https://github.com/example/lib",
+		},
+		{
+			name:  "purl github",
+			fetch: fetchGitRepositoryFromPURL,
+			pkg:   "pkg:github/ossf/scorecard@v4.13.0",
+			want:  "https://github.com/ossf/scorecard",
+		},
+		{
+			name:    "purl unsupported type",
+			fetch:   fetchGitRepositoryFromPURL,
+			pkg:     "pkg:deb/debian/curl@7.50.3-1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.fetch(tt.pkg, mockRegistry(t, tt.responses))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetch(%s) error = %v, wantErr %v", tt.pkg, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("fetch(%s) = %v, want %v", tt.pkg, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fetchGitRepositoryFromPackageManagers(t *testing.T) {
+	t.Parallel()
+	p := mockRegistry(t, map[string]string{
+		"https://hex.pm/api/packages/plug": `{"meta": {"links": {"GitHub": "git@github.com:elixir-plug/plug.git"}}}`,
+	})
+	got, err := fetchGitRepositoryFromPackageManagers(&options.Options{Hex: "plug"}, p)
+	if err != nil {
+		t.Fatalf("fetchGitRepositoryFromPackageManagers() error = %v", err)
+	}
+	if !got.exists || got.associatedRepo != "https://github.com/elixir-plug/plug" {
+		t.Errorf("fetchGitRepositoryFromPackageManagers() = %+v", got)
+	}
+
+	got, err = fetchGitRepositoryFromPackageManagers(&options.Options{Repo: "github.com/ossf/scorecard"}, p)
+	if err != nil || got.exists {
+		t.Errorf("fetchGitRepositoryFromPackageManagers() = %+v, %v, want no package", got, err)
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+
+	"github.com/ossf/scorecard/v4/clients"
+	pmc "github.com/ossf/scorecard/v4/cmd/internal/packagemanager"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+const (
+	slsaProvenanceV1  = "https://slsa.dev/provenance/v1"
+	slsaProvenanceV02 = "https://slsa.dev/provenance/v0.2"
+)
+
+// provenanceFetchers return the version of a package, the latest one when no version is set,
+// and the repositories its provenance attestations say it was built from.
+var provenanceFetchers = map[string]func(name, version string, manager pmc.Client) (string, []string, error){
+	ecosystemNPM:  fetchNPMProvenance,
+	ecosystemPyPI: fetchPyPIProvenance,
+}
+
+type npmPackageVersion struct {
+	Version string `json:"version"`
+	Dist    struct {
+		Attestations *struct {
+			URL string `json:"url"`
+		} `json:"attestations"`
+	} `json:"dist"`
+}
+
+type npmAttestations struct {
+	Attestations []struct {
+		PredicateType string `json:"predicateType"`
+		Bundle        struct {
+			DSSEEnvelope struct {
+				Payload string `json:"payload"`
+			} `json:"dsseEnvelope"`
+		} `json:"bundle"`
+	} `json:"attestations"`
+}
+
+// slsaStatement is an in-toto statement with a SLSA provenance predicate.
+type slsaStatement struct {
+	Predicate struct {
+		// SLSA v1.
+		BuildDefinition struct {
+			ExternalParameters struct {
+				Workflow struct {
+					Repository string `json:"repository"`
+				} `json:"workflow"`
+			} `json:"externalParameters"`
+		} `json:"buildDefinition"`
+		// SLSA v0.2.
+		Invocation struct {
+			ConfigSource struct {
+				URI string `json:"uri"`
+			} `json:"configSource"`
+		} `json:"invocation"`
+	} `json:"predicate"`
+}
+
+type pypiRelease struct {
+	Info struct {
+		Version string `json:"version"`
+	} `json:"info"`
+	URLs []struct {
+		Filename string `json:"filename"`
+	} `json:"urls"`
+}
+
+type pypiProvenance struct {
+	AttestationBundles []struct {
+		Publisher struct {
+			Kind       string `json:"kind"`
+			Repository string `json:"repository"`
+		} `json:"publisher"`
+	} `json:"attestation_bundles"`
+}
+
+// verifyRepositoryLink checks that the package set by `--npm`, `--pypi`, etc. can be linked
+// to the repository it was resolved to, rather than trusting the URL the package declares.
+// Provenance attestations link the package with high confidence, a release of the repository
+// matching the version of the package with medium confidence.
+// The registries verify the signatures of attestations when packages are published;
+// they are not verified again here.
+func verifyRepositoryLink(resp packageMangerResponse, repoClient clients.RepoClient,
+	manager pmc.Client,
+) *pkg.RepositoryLink {
+	ecosystem, name, version := packageVersion(resp.ecosystem, resp.packageName)
+	link := &pkg.RepositoryLink{
+		Ecosystem:  ecosystem,
+		Package:    name,
+		Version:    version,
+		Confidence: pkg.RepositoryLinkLow,
+	}
+	repo := normalizeRepositoryURL(resp.associatedRepo)
+
+	if fetch, ok := provenanceFetchers[ecosystem]; ok {
+		v, builtFrom, err := fetch(name, version, manager)
+		if err != nil {
+			link.Reasons = append(link.Reasons, fmt.Sprintf("provenance of the package could not be read: %v", err))
+		}
+		if v != "" {
+			link.Version = v
+		}
+		for _, r := range builtFrom {
+			if strings.EqualFold(normalizeRepositoryURL(r), repo) {
+				link.Confidence = pkg.RepositoryLinkHigh
+				link.Reasons = append(link.Reasons, fmt.Sprintf("%s provenance attestation of %s was built from %s",
+					ecosystem, packageVersionString(name, link.Version), repo))
+				return link
+			}
+		}
+		if len(builtFrom) > 0 {
+			for i := range builtFrom {
+				builtFrom[i] = normalizeRepositoryURL(builtFrom[i])
+			}
+			link.Confidence = pkg.RepositoryLinkNone
+			link.Reasons = append(link.Reasons, fmt.Sprintf("%s provenance attestation of %s was built from %s, not %s",
+				ecosystem, packageVersionString(name, link.Version), strings.Join(builtFrom, ", "), repo))
+			return link
+		}
+	}
+
+	if link.Version != "" && repoClient != nil {
+		releases, err := repoClient.ListReleases()
+		if err != nil {
+			link.Reasons = append(link.Reasons, fmt.Sprintf("releases of the repository could not be listed: %v", err))
+		}
+		for _, r := range releases {
+			if tagMatchesVersion(r.TagName, name, link.Version) {
+				link.Confidence = pkg.RepositoryLinkMedium
+				link.Reasons = append(link.Reasons, fmt.Sprintf("release %s of %s matches version %s of the package",
+					r.TagName, repo, link.Version))
+				return link
+			}
+		}
+	}
+
+	link.Reasons = append(link.Reasons, fmt.Sprintf("%s is only declared in the package metadata", repo))
+	return link
+}
+
+var purlEcosystems = map[string]string{
+	packageurl.TypeNPM:      ecosystemNPM,
+	packageurl.TypePyPi:     ecosystemPyPI,
+	packageurl.TypeGem:      ecosystemRubyGems,
+	packageurl.TypeNuget:    ecosystemNuGet,
+	packageurl.TypeMaven:    ecosystemMaven,
+	packageurl.TypeCargo:    ecosystemCargo,
+	packageurl.TypeGolang:   ecosystemGo,
+	packageurl.TypeComposer: ecosystemPackagist,
+	packageurl.TypeHex:      ecosystemHex,
+}
+
+// packageVersion returns the ecosystem, name and version of a package set on the command line.
+func packageVersion(ecosystem, packageName string) (string, string, string) {
+	switch ecosystem {
+	case ecosystemMaven:
+		if parts := strings.Split(packageName, ":"); len(parts) == 3 {
+			return ecosystem, parts[0] + ":" + parts[1], parts[2]
+		}
+	case ecosystemGo:
+		name, version, _ := strings.Cut(packageName, "@")
+		return ecosystem, name, version
+	case ecosystemPURL:
+		p, err := packageurl.FromString(packageName)
+		if err != nil {
+			return ecosystem, packageName, ""
+		}
+		name := p.Name
+		switch p.Type {
+		case packageurl.TypeMaven:
+			name = p.Namespace + ":" + p.Name
+		case packageurl.TypeNPM, packageurl.TypeGolang, packageurl.TypeComposer:
+			if p.Namespace != "" {
+				name = p.Namespace + "/" + p.Name
+			}
+		}
+		if e, ok := purlEcosystems[p.Type]; ok {
+			return e, name, p.Version
+		}
+		return p.Type, name, p.Version
+	}
+	return ecosystem, packageName, ""
+}
+
+func packageVersionString(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + "@" + version
+}
+
+// tagMatchesVersion returns whether a release tag names a version of a package: `1.2.3` and `v1.2.3`,
+// or, in monorepos, the version prefixed by the package name such as `pkg@1.2.3` or `@scope/pkg-v1.2.3`.
+func tagMatchesVersion(tag, name, version string) bool {
+	tag = strings.ToLower(tag)
+	name = strings.ToLower(name)
+	version = strings.TrimPrefix(strings.ToLower(version), "v")
+	if version == "" {
+		return false
+	}
+	// The name of a package in a monorepo tag is usually its last component, such as the artifactId.
+	shortName := name[strings.LastIndexAny(name, "/:")+1:]
+	for _, v := range []string{version, "v" + version} {
+		if tag == v {
+			return true
+		}
+		for _, sep := range []string{"@", "/", "-", "_"} {
+			prefix := strings.TrimSuffix(tag, sep+v)
+			if prefix != tag && (prefix == name || prefix == shortName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getProvenance decodes a provenance document, returning false if the registry has none.
+func getProvenance(ecosystem string, resp *http.Response, err error, v interface{}) (bool, error) {
+	if err == nil && resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return false, nil
+	}
+	if err := getPackageMetadata(ecosystem, resp, err, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func fetchNPMProvenance(name, version string, manager pmc.Client) (string, []string, error) {
+	if version == "" {
+		version = "latest"
+	}
+	// Scoped packages are requested as @scope%2fname.
+	var p npmPackageVersion
+	resp, err := manager.GetURI(fmt.Sprintf("https://registry.npmjs.org/%s/%s",
+		strings.Replace(name, "/", "%2f", 1), url.PathEscape(version)))
+	if err := getPackageMetadata(ecosystemNPM, resp, err, &p); err != nil {
+		return "", nil, err
+	}
+	if p.Dist.Attestations == nil || p.Dist.Attestations.URL == "" {
+		return p.Version, nil, nil
+	}
+
+	var attestations npmAttestations
+	resp, err = manager.GetURI(p.Dist.Attestations.URL)
+	if found, err := getProvenance(ecosystemNPM, resp, err, &attestations); !found {
+		return p.Version, nil, err
+	}
+	var repos []string
+	for _, a := range attestations.Attestations {
+		if a.PredicateType != slsaProvenanceV1 && a.PredicateType != slsaProvenanceV02 {
+			continue
+		}
+		payload, err := base64.StdEncoding.DecodeString(a.Bundle.DSSEEnvelope.Payload)
+		if err != nil {
+			return p.Version, nil, sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("invalid npm attestation payload: %v", err))
+		}
+		var statement slsaStatement
+		if err := json.Unmarshal(payload, &statement); err != nil {
+			return p.Version, nil, sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("invalid npm attestation statement: %v", err))
+		}
+		repo := statement.Predicate.BuildDefinition.ExternalParameters.Workflow.Repository
+		if repo == "" {
+			// SLSA v0.2 sets the source as git+https://github.com/owner/repo@refs/heads/main.
+			repo, _, _ = strings.Cut(statement.Predicate.Invocation.ConfigSource.URI, "@")
+		}
+		if repo != "" {
+			repos = append(repos, repo)
+		}
+	}
+	return p.Version, repos, nil
+}
+
+func fetchPyPIProvenance(name, version string, manager pmc.Client) (string, []string, error) {
+	releaseURL := fmt.Sprintf("https://pypi.org/pypi/%s/json", name)
+	if version != "" {
+		releaseURL = fmt.Sprintf("https://pypi.org/pypi/%s/%s/json", name, url.PathEscape(version))
+	}
+	var release pypiRelease
+	resp, err := manager.GetURI(releaseURL)
+	if err := getPackageMetadata(ecosystemPyPI, resp, err, &release); err != nil {
+		return "", nil, err
+	}
+
+	version = release.Info.Version
+	var repos []string
+	for _, file := range release.URLs {
+		var provenance pypiProvenance
+		resp, err := manager.GetURI(fmt.Sprintf("https://pypi.org/integrity/%s/%s/%s/provenance",
+			name, url.PathEscape(version), url.PathEscape(file.Filename)))
+		found, err := getProvenance(ecosystemPyPI, resp, err, &provenance)
+		if err != nil {
+			return version, nil, err
+		}
+		if !found {
+			continue
+		}
+		for _, b := range provenance.AttestationBundles {
+			switch b.Publisher.Kind {
+			case "GitHub":
+				repos = append(repos, "https://github.com/"+b.Publisher.Repository)
+			case "GitLab":
+				repos = append(repos, "https://gitlab.com/"+b.Publisher.Repository)
+			}
+		}
+		// The files of a release are published together by the same trusted publisher.
+		if len(repos) > 0 {
+			break
+		}
+	}
+	return version, repos, nil
+}
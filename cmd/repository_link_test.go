@@ -0,0 +1,188 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+func npmAttestationsResponse(predicateType, statement string) string {
+	payload := base64.StdEncoding.EncodeToString([]byte(statement))
+	return fmt.Sprintf(`{"attestations": [{"predicateType": %q, "bundle": {"dsseEnvelope": {"payload": %q}}}]}`,
+		predicateType, payload)
+}
+
+func Test_verifyRepositoryLink(t *testing.T) {
+	t.Parallel()
+	const attestationsURL = "https://registry.npmjs.org/-/npm/v1/attestations/foo@1.2.0"
+	npmVersion := `{"version": "1.2.0", "dist": {"attestations": {"url": "` + attestationsURL + `"}}}`
+	tests := []struct {
+		name        string
+		resp        packageMangerResponse
+		responses   map[string]string
+		releases    []clients.Release
+		releasesErr error
+		want        *pkg.RepositoryLink
+	}{
+		{
+			name: "npm provenance",
+			resp: packageMangerResponse{ecosystem: ecosystemNPM, packageName: "foo", associatedRepo: "https://github.com/owner/foo"},
+			responses: map[string]string{
+				"https://registry.npmjs.org/foo/latest": npmVersion,
+				attestationsURL: npmAttestationsResponse(slsaProvenanceV1,
+					`{"predicate": {"buildDefinition": {"externalParameters": {"workflow": {"repository": "https://github.com/Owner/foo"}}}}}`),
+			},
+			want: &pkg.RepositoryLink{
+				Ecosystem:  ecosystemNPM,
+				Package:    "foo",
+				Version:    "1.2.0",
+				Confidence: pkg.RepositoryLinkHigh,
+				Reasons:    []string{"npm provenance attestation of foo@1.2.0 was built from https://github.com/owner/foo"},
+			},
+		},
+		{
+			name: "npm provenance of another repository",
+			resp: packageMangerResponse{ecosystem: ecosystemNPM, packageName: "foo", associatedRepo: "https://github.com/owner/foo"},
+			responses: map[string]string{
+				"https://registry.npmjs.org/foo/latest": npmVersion,
+				attestationsURL: npmAttestationsResponse(slsaProvenanceV02,
+					`{"predicate": {"invocation": {"configSource": {"uri": "git+https://github.com/evil/foo@refs/heads/main"}}}}`),
+			},
+			releases: []clients.Release{{TagName: "v1.2.0"}},
+			want: &pkg.RepositoryLink{
+				Ecosystem:  ecosystemNPM,
+				Package:    "foo",
+				Version:    "1.2.0",
+				Confidence: pkg.RepositoryLinkNone,
+				Reasons: []string{
+					"npm provenance attestation of foo@1.2.0 was built from https://github.com/evil/foo, " +
+						"not https://github.com/owner/foo",
+				},
+			},
+		},
+		{
+			name: "scoped npm package without provenance, matching release",
+			resp: packageMangerResponse{ecosystem: ecosystemNPM, packageName: "@scope/foo", associatedRepo: "https://github.com/owner/mono"},
+			responses: map[string]string{
+				"https://registry.npmjs.org/@scope%2ffoo/latest": `{"version": "2.0.0", "dist": {}}`,
+			},
+			releases: []clients.Release{{TagName: "@scope/bar@2.0.0"}, {TagName: "@scope/foo@2.0.0"}},
+			want: &pkg.RepositoryLink{
+				Ecosystem:  ecosystemNPM,
+				Package:    "@scope/foo",
+				Version:    "2.0.0",
+				Confidence: pkg.RepositoryLinkMedium,
+				Reasons:    []string{"release @scope/foo@2.0.0 of https://github.com/owner/mono matches version 2.0.0 of the package"},
+			},
+		},
+		{
+			name: "pypi trusted publisher",
+			resp: packageMangerResponse{ecosystem: ecosystemPyPI, packageName: "foo", associatedRepo: "https://github.com/owner/foo"},
+			responses: map[string]string{
+				"https://pypi.org/pypi/foo/json": `{"info": {"version": "0.3"}, "urls": [` +
+					`{"filename": "foo-0.3.tar.gz"}, {"filename": "foo-0.3-py3-none-any.whl"}]}`,
+				"https://pypi.org/integrity/foo/0.3/foo-0.3-py3-none-any.whl/provenance": `{"attestation_bundles": [` +
+					`{"publisher": {"kind": "GitHub", "repository": "owner/foo", "workflow": "release.yml"}}]}`,
+			},
+			want: &pkg.RepositoryLink{
+				Ecosystem:  ecosystemPyPI,
+				Package:    "foo",
+				Version:    "0.3",
+				Confidence: pkg.RepositoryLinkHigh,
+				Reasons:    []string{"PyPI provenance attestation of foo@0.3 was built from https://github.com/owner/foo"},
+			},
+		},
+		{
+			name:     "purl version without a matching release",
+			resp:     packageMangerResponse{ecosystem: ecosystemPURL, packageName: "pkg:cargo/foo@1.0.0", associatedRepo: "https://github.com/owner/foo"},
+			releases: []clients.Release{{TagName: "v1.0.01"}, {TagName: "foo-v2.0.0"}},
+			want: &pkg.RepositoryLink{
+				Ecosystem:  ecosystemCargo,
+				Package:    "foo",
+				Version:    "1.0.0",
+				Confidence: pkg.RepositoryLinkLow,
+				Reasons:    []string{"https://github.com/owner/foo is only declared in the package metadata"},
+			},
+		},
+		{
+			name:        "maven releases not listed",
+			resp:        packageMangerResponse{ecosystem: ecosystemMaven, packageName: "org.example:foo:1.0", associatedRepo: "https://github.com/owner/foo"},
+			releasesErr: errors.New("rate limited"),
+			want: &pkg.RepositoryLink{
+				Ecosystem:  ecosystemMaven,
+				Package:    "org.example:foo",
+				Version:    "1.0",
+				Confidence: pkg.RepositoryLinkLow,
+				Reasons: []string{
+					"releases of the repository could not be listed: rate limited",
+					"https://github.com/owner/foo is only declared in the package metadata",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repoClient := mockrepo.NewMockRepoClient(ctrl)
+			repoClient.EXPECT().ListReleases().Return(tt.releases, tt.releasesErr).AnyTimes()
+			got := verifyRepositoryLink(tt.resp, repoClient, mockRegistry(t, tt.responses))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("verifyRepositoryLink() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_tagMatchesVersion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		tag     string
+		name    string
+		version string
+		want    bool
+	}{
+		{tag: "1.2.3", name: "pkg", version: "1.2.3", want: true},
+		{tag: "v1.2.3", name: "pkg", version: "1.2.3", want: true},
+		{tag: "V1.2.3", name: "pkg", version: "v1.2.3", want: true},
+		{tag: "pkg@1.2.3", name: "pkg", version: "1.2.3", want: true},
+		{tag: "@scope/pkg-v1.2.3", name: "@scope/pkg", version: "1.2.3", want: true},
+		{tag: "artifact-1.2.3", name: "org.example:artifact", version: "1.2.3", want: true},
+		{tag: "other@1.2.3", name: "pkg", version: "1.2.3", want: false},
+		{tag: "v11.2.3", name: "pkg", version: "1.2.3", want: false},
+		{tag: "v1.2.30", name: "pkg", version: "1.2.3", want: false},
+		{tag: "v1.2.3", name: "pkg", version: "", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.tag+" "+tt.version, func(t *testing.T) {
+			t.Parallel()
+			if got := tagMatchesVersion(tt.tag, tt.name, tt.version); got != tt.want {
+				t.Errorf("tagMatchesVersion(%q, %q, %q) = %v, want %v", tt.tag, tt.name, tt.version, got, tt.want)
+			}
+		})
+	}
+}
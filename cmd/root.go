@@ -67,6 +67,9 @@ func New(o *options.Options) *cobra.Command {
 
 	// Add sub-commands.
 	cmd.AddCommand(serveCmd(o))
+	cmd.AddCommand(fixCmd())
+	cmd.AddCommand(diffDepsCmd())
+	cmd.AddCommand(depsTreeCmd())
 	cmd.AddCommand(version.Version())
 	return cmd
 }
@@ -75,7 +78,7 @@ func New(o *options.Options) *cobra.Command {
 func rootCmd(o *options.Options) error {
 	p := &pmc.PackageManagerClient{}
 	// Set `repo` from package managers.
-	pkgResp, err := fetchGitRepositoryFromPackageManagers(o.NPM, o.PyPI, o.RubyGems, o.Nuget, p)
+	pkgResp, err := fetchGitRepositoryFromPackageManagers(o, p)
 	if err != nil {
 		return fmt.Errorf("fetchGitRepositoryFromPackageManagers: %w", err)
 	}
@@ -141,6 +144,9 @@ func rootCmd(o *options.Options) error {
 	}
 
 	repoResult.Metadata = append(repoResult.Metadata, o.Metadata...)
+	if pkgResp.exists {
+		repoResult.RepositoryLink = verifyRepositoryLink(pkgResp, repoClient, p)
+	}
 
 	// Sort them by name
 	sort.Slice(repoResult.Checks, func(i, j int) bool {
@@ -91,6 +91,27 @@ type jsonCommit struct {
 	// TODO: check runs, etc.
 }
 
+type jsonSAST struct {
+	Workflows []jsonSASTWorkflow `json:"workflows"`
+	Commits   []jsonSASTCommit   `json:"commits"`
+	Languages []string           `json:"languages"`
+}
+
+type jsonSASTWorkflow struct {
+	Type      string   `json:"type"`
+	Tool      string   `json:"tool"`
+	File      jsonFile `json:"file"`
+	Triggers  []string `json:"triggers"`
+	Languages []string `json:"languages"`
+}
+
+type jsonSASTCommit struct {
+	URL               *string `json:"url"`
+	Tool              *string `json:"tool"`
+	HeadSHA           string  `json:"headSHA"`
+	PullRequestNumber int     `json:"pullRequestNumber"`
+}
+
 type jsonDatabaseVulnerability struct {
 	// For OSV: OSV-2020-484
 	// For CVE: CVE-2022-23945
@@ -112,6 +133,8 @@ type jsonRawResults struct {
 	BranchProtections []jsonBranchProtection `json:"branchProtections"`
 	// Changesets
 	DefaultBranchChangesets []jsonDefaultBranchChangeset `json:"defaultBranchChangesets"`
+	// SAST tools and the pull requests they analyzed.
+	SAST jsonSAST `json:"sast"`
 }
 
 //nolint:unparam
@@ -228,6 +251,48 @@ func addBranchProtectionRawResults(r *jsonScorecardRawResult, bp *checker.Branch
 	return nil
 }
 
+//nolint:unparam
+func addSASTRawResults(r *jsonScorecardRawResult, sd *checker.SASTData) error {
+	r.Results.SAST = jsonSAST{
+		Workflows: []jsonSASTWorkflow{},
+		Commits:   []jsonSASTCommit{},
+	}
+	for _, l := range sd.Languages {
+		r.Results.SAST.Languages = append(r.Results.SAST.Languages, string(l))
+	}
+	for i := range sd.Workflows {
+		w := &sd.Workflows[i]
+		jw := jsonSASTWorkflow{
+			Type: string(w.Type),
+			Tool: w.Tool,
+			File: jsonFile{
+				Path:   w.File.Path,
+				Offset: int(w.File.Offset),
+			},
+		}
+		for _, t := range w.Triggers {
+			jw.Triggers = append(jw.Triggers, string(t))
+		}
+		for _, l := range w.Languages {
+			jw.Languages = append(jw.Languages, string(l))
+		}
+		r.Results.SAST.Workflows = append(r.Results.SAST.Workflows, jw)
+	}
+	for i := range sd.Commits {
+		c := sd.Commits[i]
+		jc := jsonSASTCommit{
+			HeadSHA:           c.HeadSHA,
+			PullRequestNumber: c.PullRequestNumber,
+		}
+		if c.Tool != "" {
+			jc.Tool = &c.Tool
+			jc.URL = &c.URL
+		}
+		r.Results.SAST.Commits = append(r.Results.SAST.Commits, jc)
+	}
+	return nil
+}
+
 func fillJSONRawResults(r *jsonScorecardRawResult, raw *checker.RawResults) error {
 	// Vulnerabiliries.
 	if err := addVulnerbilitiesRawResults(r, &raw.VulnerabilitiesResults); err != nil {
@@ -259,6 +324,11 @@ func fillJSONRawResults(r *jsonScorecardRawResult, raw *checker.RawResults) erro
 		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 	}
 
+	// SAST.
+	if err := addSASTRawResults(r, &raw.SASTResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
 	return nil
 }
 
@@ -77,3 +77,48 @@ func TestAddCodeReviewRawResults(t *testing.T) {
 		t.Errorf("Expected %v, got %v", want, r.Results.DefaultBranchChangesets)
 	}
 }
+
+func TestAddSASTRawResults(t *testing.T) {
+	t.Parallel()
+
+	r := jsonScorecardRawResult{}
+	sd := checker.SASTData{
+		Workflows: []checker.SASTWorkflow{
+			{
+				Type:      checker.SASTWorkflowCI,
+				Tool:      "gosec",
+				File:      checker.File{Path: ".github/workflows/gosec.yml", Offset: 12},
+				Triggers:  []checker.SASTTrigger{checker.SASTTriggerPullRequest},
+				Languages: []clients.LanguageName{clients.Go},
+			},
+		},
+		Commits: []checker.SASTCommit{
+			{HeadSHA: "sha1", PullRequestNumber: 1, Tool: "Semgrep", URL: "https://example.com/1"},
+			{HeadSHA: "sha2", PullRequestNumber: 2},
+		},
+		Languages: []clients.LanguageName{clients.Go, clients.Python},
+	}
+	if err := addSASTRawResults(&r, &sd); err != nil {
+		t.Errorf("addSASTRawResults: %v", err)
+	}
+	tool, url := "Semgrep", "https://example.com/1"
+	want := jsonSAST{
+		Workflows: []jsonSASTWorkflow{
+			{
+				Type:      "ci",
+				Tool:      "gosec",
+				File:      jsonFile{Path: ".github/workflows/gosec.yml", Offset: 12},
+				Triggers:  []string{"pullRequest"},
+				Languages: []string{"go"},
+			},
+		},
+		Commits: []jsonSASTCommit{
+			{HeadSHA: "sha1", PullRequestNumber: 1, Tool: &tool, URL: &url},
+			{HeadSHA: "sha2", PullRequestNumber: 2},
+		},
+		Languages: []string{"go", "python"},
+	}
+	if !reflect.DeepEqual(r.Results.SAST, want) {
+		t.Errorf("Expected %v, got %v", want, r.Results.SAST)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"sort"
+	"strings"
+)
+
+// ChangeType is the type of change of a dependency.
+type ChangeType string
+
+const (
+	// Added is a dependency which is only locked by the new version of the project.
+	Added ChangeType = "added"
+	// Updated is a dependency locked at different versions.
+	Updated ChangeType = "updated"
+	// Removed is a dependency which is only locked by the old version of the project.
+	Removed ChangeType = "removed"
+)
+
+// Change is a dependency added, updated or removed between two versions of a project.
+type Change struct {
+	Type ChangeType
+	// Dependency is the new dependency, or the old one if it was removed.
+	// Dependencies locked at several versions have their versions separated by commas.
+	Dependency Dependency
+	// PreviousVersion is the version of an updated dependency before the change.
+	PreviousVersion string
+}
+
+type dependencyKey struct {
+	ecosystem Ecosystem
+	name      string
+}
+
+type lockedVersions struct {
+	versions map[string]bool
+	path     string
+}
+
+func (l *lockedVersions) String() string {
+	versions := make([]string, 0, len(l.versions))
+	for v := range l.versions {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return strings.Join(versions, ",")
+}
+
+func groupVersions(deps []Dependency) map[dependencyKey]*lockedVersions {
+	grouped := make(map[dependencyKey]*lockedVersions)
+	for _, d := range deps {
+		k := dependencyKey{ecosystem: d.Ecosystem, name: normalizeName(d.Ecosystem, d.Name)}
+		l, ok := grouped[k]
+		if !ok {
+			l = &lockedVersions{versions: make(map[string]bool), path: d.Path}
+			grouped[k] = l
+		}
+		l.versions[d.Version] = true
+	}
+	return grouped
+}
+
+// normalizeName returns the name used to compare packages, e.g., PyPI names are case-insensitive
+// and do not distinguish `-`, `_` and `.`.
+func normalizeName(e Ecosystem, name string) string {
+	switch e {
+	case EcosystemPyPI:
+		return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+	case EcosystemNuGet:
+		return strings.ToLower(name)
+	default:
+		return name
+	}
+}
+
+// Diff returns the dependencies added, updated and removed between the base and head versions of a project.
+// Packages are compared by ecosystem and name, regardless of the lockfile locking them.
+func Diff(base, head []Dependency) []Change {
+	baseVersions := groupVersions(base)
+	headVersions := groupVersions(head)
+	names := make(map[dependencyKey]string)
+	for _, d := range append(append([]Dependency{}, base...), head...) {
+		names[dependencyKey{ecosystem: d.Ecosystem, name: normalizeName(d.Ecosystem, d.Name)}] = d.Name
+	}
+
+	var changes []Change
+	for k, h := range headVersions {
+		dep := Dependency{Ecosystem: k.ecosystem, Name: names[k], Version: h.String(), Path: h.path}
+		b, ok := baseVersions[k]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Type: Added, Dependency: dep})
+		case b.String() != h.String():
+			changes = append(changes, Change{Type: Updated, Dependency: dep, PreviousVersion: b.String()})
+		}
+	}
+	for k, b := range baseVersions {
+		if _, ok := headVersions[k]; ok {
+			continue
+		}
+		changes = append(changes, Change{
+			Type:       Removed,
+			Dependency: Dependency{Ecosystem: k.ecosystem, Name: names[k], Version: b.String(), Path: b.path},
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Dependency.Ecosystem != changes[j].Dependency.Ecosystem {
+			return changes[i].Dependency.Ecosystem < changes[j].Dependency.Ecosystem
+		}
+		return changes[i].Dependency.Name < changes[j].Dependency.Name
+	})
+	return changes
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	base := []Dependency{
+		{EcosystemNpm, "a", "1.0.0", "package-lock.json"},
+		{EcosystemNpm, "b", "1.0.0", "package-lock.json"},
+		{EcosystemNpm, "b", "2.0.0", "package-lock.json"},
+		{EcosystemPyPI, "Flask_Login", "0.6.2", "requirements.txt"},
+		{EcosystemGo, "github.com/old/module", "v1.0.0", "go.mod"},
+	}
+	head := []Dependency{
+		{EcosystemNpm, "a", "1.0.0", "package-lock.json"},
+		{EcosystemNpm, "b", "2.0.0", "package-lock.json"},
+		{EcosystemNpm, "b", "3.0.0", "package-lock.json"},
+		{EcosystemPyPI, "flask-login", "0.6.2", "requirements.txt"},
+		{EcosystemPyPI, "requests", "2.31.0", "requirements.txt"},
+	}
+	want := []Change{
+		{
+			Type:       Removed,
+			Dependency: Dependency{EcosystemGo, "github.com/old/module", "v1.0.0", "go.mod"},
+		},
+		{
+			Type:       Added,
+			Dependency: Dependency{EcosystemPyPI, "requests", "2.31.0", "requirements.txt"},
+		},
+		{
+			Type:            Updated,
+			Dependency:      Dependency{EcosystemNpm, "b", "2.0.0,3.0.0", "package-lock.json"},
+			PreviousVersion: "1.0.0,2.0.0",
+		},
+	}
+	got := Diff(base, head)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diff() mismatch (-want +got): %s", diff)
+	}
+}
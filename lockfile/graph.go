@@ -0,0 +1,529 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/mod/modfile"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// Graph is the dependency graph of the packages locked by lockfiles.
+type Graph struct {
+	Dependencies []Dependency
+	// Direct lists the indexes, in Dependencies, of the dependencies of the project itself.
+	Direct []int
+	// Edges maps the index of a dependency to the indexes of its own dependencies.
+	Edges map[int][]int
+	// ParseErrors lists the lockfiles which cannot be parsed, and whose dependencies are missing.
+	ParseErrors []ParseError
+}
+
+type graphParser func(content []byte) (*Graph, error)
+
+// graphFormats maps the base names of lockfiles recording the dependencies of each package to their parser.
+// The dependencies of the other lockfiles are all direct.
+var graphFormats = map[string]graphParser{
+	"package-lock.json":   parsePackageLockGraph,
+	"npm-shrinkwrap.json": parsePackageLockGraph,
+	"yarn.lock":           parseYarnLockGraph,
+	"poetry.lock":         parsePoetryLockGraph,
+	"go.mod":              parseGoModGraph,
+	"Cargo.lock":          parseCargoLockGraph,
+	"packages.lock.json":  parseNuGetLockGraph,
+}
+
+// ParseGraph returns the dependency graph of a lockfile.
+func ParseGraph(pathfn string, content []byte) (*Graph, error) {
+	f, ok := formatFor(pathfn)
+	if !ok {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("unsupported lockfile: %s", pathfn))
+	}
+	var g *Graph
+	if parse, ok := graphFormats[path.Base(pathfn)]; ok {
+		var err error
+		g, err = parse(content)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: %v", pathfn, err))
+		}
+	} else {
+		deps, err := f.parse(content)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: %v", pathfn, err))
+		}
+		g = &Graph{Dependencies: deps}
+		for i := range deps {
+			g.Direct = append(g.Direct, i)
+		}
+	}
+	for i := range g.Dependencies {
+		g.Dependencies[i].Ecosystem = f.ecosystem
+		g.Dependencies[i].Path = pathfn
+	}
+	g.MakeUnreachableDirect()
+	return g, nil
+}
+
+// CollectGraph returns the dependency graph of all the lockfiles of a project.
+func CollectGraph(r FileReader) (*Graph, error) {
+	files, err := r.ListFiles(func(pathfn string) (bool, error) {
+		return IsLockfile(pathfn), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListFiles: %w", err)
+	}
+	sort.Strings(files)
+
+	merged := &Graph{Edges: make(map[int][]int)}
+	for _, f := range files {
+		content, err := r.GetFileContent(f)
+		if err != nil {
+			return nil, fmt.Errorf("GetFileContent: %w", err)
+		}
+		g, err := ParseGraph(f, content)
+		if err != nil {
+			merged.ParseErrors = append(merged.ParseErrors, ParseError{Path: f, Err: err})
+			continue
+		}
+		merged.merge(g)
+	}
+	return merged, nil
+}
+
+func (g *Graph) merge(other *Graph) {
+	offset := len(g.Dependencies)
+	g.Dependencies = append(g.Dependencies, other.Dependencies...)
+	for _, d := range other.Direct {
+		g.Direct = append(g.Direct, d+offset)
+	}
+	for from, to := range other.Edges {
+		for _, t := range to {
+			g.Edges[from+offset] = append(g.Edges[from+offset], t+offset)
+		}
+	}
+}
+
+// MakeUnreachableDirect makes the dependencies which cannot be reached from the direct dependencies direct,
+// e.g., the indirect requirements of go.mod files whose dependents are not recorded.
+func (g *Graph) MakeUnreachableDirect() {
+	if g.Edges == nil {
+		g.Edges = make(map[int][]int)
+	}
+	reached := make([]bool, len(g.Dependencies))
+	var visit func(i int)
+	visit = func(i int) {
+		if reached[i] {
+			return
+		}
+		reached[i] = true
+		for _, j := range g.Edges[i] {
+			visit(j)
+		}
+	}
+	for _, i := range g.Direct {
+		visit(i)
+	}
+
+	// Dependencies which no other dependency depends on are visited first, so the
+	// roots of unreachable subgraphs become direct rather than their dependencies.
+	dependedOn := make([]bool, len(g.Dependencies))
+	for _, to := range g.Edges {
+		for _, j := range to {
+			dependedOn[j] = true
+		}
+	}
+	for _, roots := range []bool{false, true} {
+		for i := range g.Dependencies {
+			if !reached[i] && dependedOn[i] == roots {
+				g.Direct = append(g.Direct, i)
+				visit(i)
+			}
+		}
+	}
+}
+
+// graphBuilder deduplicates the packages of a graph.
+type graphBuilder struct {
+	g       *Graph
+	indexes map[string]int
+}
+
+func newGraphBuilder() *graphBuilder {
+	return &graphBuilder{g: &Graph{Edges: make(map[int][]int)}, indexes: make(map[string]int)}
+}
+
+// add returns the index of a package identified by key.
+func (b *graphBuilder) add(key, name, version string) int {
+	if i, ok := b.indexes[key]; ok {
+		return i
+	}
+	b.g.Dependencies = append(b.g.Dependencies, Dependency{Name: name, Version: version})
+	i := len(b.g.Dependencies) - 1
+	b.indexes[key] = i
+	return i
+}
+
+func (b *graphBuilder) edge(from, to int) {
+	for _, t := range b.g.Edges[from] {
+		if t == to {
+			return
+		}
+	}
+	b.g.Edges[from] = append(b.g.Edges[from], to)
+}
+
+func (b *graphBuilder) direct(i int) {
+	for _, d := range b.g.Direct {
+		if d == i {
+			return
+		}
+	}
+	b.g.Direct = append(b.g.Direct, i)
+}
+
+// parsePackageLockGraph parses the packages of package-lock.json files, lockfileVersion 2 and 3.
+// Dependencies are resolved like Node.js does, from the closest node_modules directory.
+func parsePackageLockGraph(content []byte) (*Graph, error) {
+	type lockedPackage struct {
+		Version              string            `json:"version"`
+		Link                 bool              `json:"link"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+	}
+	var lock struct {
+		Packages map[string]lockedPackage `json:"packages"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	if len(lock.Packages) == 0 {
+		// lockfileVersion 1 does not record the dependencies of each package.
+		deps, err := parsePackageLock(content)
+		if err != nil {
+			return nil, err
+		}
+		return flatGraph(deps), nil
+	}
+
+	b := newGraphBuilder()
+	keys := make([]string, 0, len(lock.Packages))
+	for key := range lock.Packages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// The same package may be installed in several node_modules directories.
+	byPath := make(map[string]int)
+	for _, key := range keys {
+		if i := strings.LastIndex(key, "node_modules/"); i >= 0 && !lock.Packages[key].Link {
+			name, version := key[i+len("node_modules/"):], lock.Packages[key].Version
+			byPath[key] = b.add(name+"@"+version, name, version)
+		}
+	}
+
+	// resolve returns the package installed for name when required from the package at dir.
+	resolve := func(dir, name string) (int, bool) {
+		for {
+			key := "node_modules/" + name
+			if dir != "" {
+				key = dir + "/" + key
+			}
+			if i, ok := byPath[key]; ok {
+				return i, true
+			}
+			if dir == "" {
+				return 0, false
+			}
+			// Move up to the enclosing package.
+			if i := strings.LastIndex(dir, "/node_modules/"); i >= 0 {
+				dir = dir[:i]
+			} else {
+				dir = ""
+			}
+		}
+	}
+
+	for _, key := range keys {
+		p := lock.Packages[key]
+		from, isPackage := byPath[key]
+		// The root project, workspaces and linked packages are the project itself.
+		isProject := !isPackage
+		requirements := []map[string]string{p.Dependencies, p.OptionalDependencies, p.PeerDependencies}
+		if isProject {
+			requirements = append(requirements, p.DevDependencies)
+		}
+		for _, r := range requirements {
+			for name := range r {
+				to, ok := resolve(key, name)
+				if !ok {
+					continue
+				}
+				if isProject {
+					b.direct(to)
+				} else {
+					b.edge(from, to)
+				}
+			}
+		}
+	}
+	sortGraph(b.g)
+	return b.g, nil
+}
+
+// parseYarnLockGraph parses yarn.lock files. yarn.lock does not record the direct dependencies,
+// so the packages which no other package depends on are direct.
+func parseYarnLockGraph(content []byte) (*Graph, error) {
+	b := newGraphBuilder()
+	descriptors := make(map[string]int)
+	requires := make(map[int][]string)
+
+	current := -1
+	var specs []string
+	inDependencies := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case !strings.HasPrefix(line, " "):
+			specs = nil
+			for _, spec := range strings.Split(strings.TrimSuffix(line, ":"), ",") {
+				specs = append(specs, strings.Trim(spec, `" `))
+			}
+			current = -1
+			inDependencies = false
+		case !strings.HasPrefix(line, "    "):
+			inDependencies = trimmed == "dependencies:" || trimmed == "optionalDependencies:"
+			m := yarnVersion.FindStringSubmatch(line)
+			if m == nil || len(specs) == 0 {
+				continue
+			}
+			name := yarnPackageName(specs[0])
+			if name == "" {
+				continue
+			}
+			current = b.add(name+"@"+m[1], name, m[1])
+			for _, spec := range specs {
+				descriptors[yarnDescriptor(spec)] = current
+			}
+		case inDependencies && current >= 0:
+			// Classic: `name "range"`, berry: `name: "npm:range"`.
+			fields := strings.Fields(strings.Replace(trimmed, ":", " ", 1))
+			if len(fields) >= 2 {
+				name := strings.Trim(fields[0], `"`)
+				rng := strings.Trim(strings.Join(fields[1:], " "), `"`)
+				requires[current] = append(requires[current], yarnDescriptor(name+"@"+rng))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+
+	for from, descs := range requires {
+		for _, d := range descs {
+			if to, ok := descriptors[d]; ok {
+				b.edge(from, to)
+			}
+		}
+	}
+	sortGraph(b.g)
+	return b.g, nil
+}
+
+// yarnDescriptor normalizes a descriptor, e.g., lodash@npm:^4.17.21 and lodash@^4.17.21.
+func yarnDescriptor(spec string) string {
+	return strings.Replace(spec, "@npm:", "@", 1)
+}
+
+// parsePoetryLockGraph parses poetry.lock files. The direct dependencies are declared in
+// pyproject.toml, so the packages which no other package depends on are direct.
+func parsePoetryLockGraph(content []byte) (*Graph, error) {
+	var lock struct {
+		Package []struct {
+			Name         string                 `toml:"name"`
+			Version      string                 `toml:"version"`
+			Dependencies map[string]interface{} `toml:"dependencies"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("toml.Unmarshal: %w", err)
+	}
+	b := newGraphBuilder()
+	for _, p := range lock.Package {
+		b.add(normalizeName(EcosystemPyPI, p.Name), p.Name, p.Version)
+	}
+	for _, p := range lock.Package {
+		from := b.indexes[normalizeName(EcosystemPyPI, p.Name)]
+		for name := range p.Dependencies {
+			if to, ok := b.indexes[normalizeName(EcosystemPyPI, name)]; ok {
+				b.edge(from, to)
+			}
+		}
+	}
+	sortGraph(b.g)
+	return b.g, nil
+}
+
+// parseGoModGraph parses go.mod files. The modules requiring the indirect dependencies are not recorded.
+func parseGoModGraph(content []byte) (*Graph, error) {
+	f, err := modfile.Parse("go.mod", content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("modfile.Parse: %w", err)
+	}
+	deps, err := parseGoMod(content)
+	if err != nil {
+		return nil, err
+	}
+	g := &Graph{Dependencies: deps}
+	for i, r := range f.Require {
+		if !r.Indirect {
+			g.Direct = append(g.Direct, i)
+		}
+	}
+	return g, nil
+}
+
+// parseCargoLockGraph parses Cargo.lock files. The packages without a source are the crates of the
+// workspace, whose dependencies are direct.
+func parseCargoLockGraph(content []byte) (*Graph, error) {
+	var lock struct {
+		Package []struct {
+			Name         string   `toml:"name"`
+			Version      string   `toml:"version"`
+			Source       string   `toml:"source"`
+			Dependencies []string `toml:"dependencies"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("toml.Unmarshal: %w", err)
+	}
+
+	b := newGraphBuilder()
+	versions := make(map[string][]string)
+	for _, p := range lock.Package {
+		versions[p.Name] = append(versions[p.Name], p.Version)
+		if p.Source != "" {
+			b.add(p.Name+" "+p.Version, p.Name, p.Version)
+		}
+	}
+	for _, p := range lock.Package {
+		from, isPackage := b.indexes[p.Name+" "+p.Version]
+		for _, d := range p.Dependencies {
+			// "name", "name version" or "name version (source)" when several versions are locked.
+			fields := strings.Fields(d)
+			if len(fields) == 0 {
+				continue
+			}
+			version := ""
+			if len(fields) > 1 {
+				version = fields[1]
+			} else if len(versions[fields[0]]) == 1 {
+				version = versions[fields[0]][0]
+			}
+			to, ok := b.indexes[fields[0]+" "+version]
+			if !ok {
+				continue
+			}
+			if isPackage {
+				b.edge(from, to)
+			} else {
+				b.direct(to)
+			}
+		}
+	}
+	sortGraph(b.g)
+	return b.g, nil
+}
+
+// parseNuGetLockGraph parses packages.lock.json files.
+func parseNuGetLockGraph(content []byte) (*Graph, error) {
+	var lock struct {
+		Dependencies map[string]map[string]struct {
+			Type         string            `json:"type"`
+			Resolved     string            `json:"resolved"`
+			Dependencies map[string]string `json:"dependencies"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	b := newGraphBuilder()
+	frameworks := make([]string, 0, len(lock.Dependencies))
+	for f := range lock.Dependencies {
+		frameworks = append(frameworks, f)
+	}
+	sort.Strings(frameworks)
+	for _, f := range frameworks {
+		for name, d := range lock.Dependencies[f] {
+			if strings.EqualFold(d.Type, "Project") {
+				continue
+			}
+			i := b.add(strings.ToLower(name)+"@"+d.Resolved, name, d.Resolved)
+			if strings.EqualFold(d.Type, "Direct") {
+				b.direct(i)
+			}
+		}
+	}
+	for _, f := range frameworks {
+		packages := lock.Dependencies[f]
+		resolved := make(map[string]string)
+		for name, d := range packages {
+			resolved[strings.ToLower(name)] = d.Resolved
+		}
+		for name, d := range packages {
+			from, ok := b.indexes[strings.ToLower(name)+"@"+d.Resolved]
+			if !ok {
+				continue
+			}
+			for dep := range d.Dependencies {
+				if to, ok := b.indexes[strings.ToLower(dep)+"@"+resolved[strings.ToLower(dep)]]; ok {
+					b.edge(from, to)
+				}
+			}
+		}
+	}
+	sortGraph(b.g)
+	return b.g, nil
+}
+
+func flatGraph(deps []Dependency) *Graph {
+	g := &Graph{Dependencies: deps}
+	for i := range deps {
+		g.Direct = append(g.Direct, i)
+	}
+	return g
+}
+
+// sortGraph sorts the edges and direct dependencies, which are built from maps.
+func sortGraph(g *Graph) {
+	sort.Ints(g.Direct)
+	for _, to := range g.Edges {
+		sort.Ints(to)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type graphSummary struct {
+	Edges  map[string][]string
+	Direct []string
+}
+
+func summarize(g *Graph) graphSummary {
+	id := func(i int) string {
+		return g.Dependencies[i].Name + "@" + g.Dependencies[i].Version
+	}
+	s := graphSummary{Edges: make(map[string][]string)}
+	for _, i := range g.Direct {
+		s.Direct = append(s.Direct, id(i))
+	}
+	for from, to := range g.Edges {
+		for _, t := range to {
+			s.Edges[id(from)] = append(s.Edges[id(from)], id(t))
+		}
+	}
+	return s
+}
+
+func TestParseGraph(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    graphSummary
+	}{
+		{
+			name: "package-lock.json resolves nested node_modules",
+			path: "package-lock.json",
+			content: `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"dependencies": {"a": "^1.0.0"}, "devDependencies": {"b": "^1.0.0"}},
+    "node_modules/a": {"version": "1.0.0", "dependencies": {"b": "^2.0.0", "c": "^1.0.0"}},
+    "node_modules/a/node_modules/b": {"version": "2.0.0", "dependencies": {"c": "^1.0.0"}},
+    "node_modules/b": {"version": "1.0.0"},
+    "node_modules/c": {"version": "1.0.0"}
+  }
+}`,
+			want: graphSummary{
+				Direct: []string{"a@1.0.0", "b@1.0.0"},
+				Edges: map[string][]string{
+					"a@1.0.0": {"b@2.0.0", "c@1.0.0"},
+					"b@2.0.0": {"c@1.0.0"},
+				},
+			},
+		},
+		{
+			name: "yarn.lock",
+			path: "yarn.lock",
+			content: `# yarn lockfile v1
+
+a@^1.0.0:
+  version "1.0.0"
+  dependencies:
+    b "^2.0.0"
+
+b@^2.0.0, b@^2.1.0:
+  version "2.1.0"
+`,
+			want: graphSummary{
+				Direct: []string{"a@1.0.0"},
+				Edges:  map[string][]string{"a@1.0.0": {"b@2.1.0"}},
+			},
+		},
+		{
+			name: "poetry.lock",
+			path: "poetry.lock",
+			content: `[[package]]
+name = "requests"
+version = "2.31.0"
+
+[package.dependencies]
+charset-normalizer = ">=2,<4"
+
+[[package]]
+name = "Charset_Normalizer"
+version = "3.3.0"
+`,
+			want: graphSummary{
+				Direct: []string{"requests@2.31.0"},
+				Edges:  map[string][]string{"requests@2.31.0": {"Charset_Normalizer@3.3.0"}},
+			},
+		},
+		{
+			name: "go.mod indirect dependencies",
+			path: "go.mod",
+			content: `module m
+
+require (
+	github.com/a/b v1.0.0
+	github.com/c/d v0.1.0 // indirect
+)
+`,
+			want: graphSummary{
+				Direct: []string{"github.com/a/b@v1.0.0", "github.com/c/d@v0.1.0"},
+				Edges:  map[string][]string{},
+			},
+		},
+		{
+			name: "Cargo.lock",
+			path: "Cargo.lock",
+			content: `[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = ["serde", "rand 0.8.5"]
+
+[[package]]
+name = "serde"
+version = "1.0.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+dependencies = ["rand 0.7.0"]
+
+[[package]]
+name = "rand"
+version = "0.7.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+			want: graphSummary{
+				Direct: []string{"serde@1.0.0", "rand@0.8.5"},
+				Edges:  map[string][]string{"rand@0.8.5": {"rand@0.7.0"}},
+			},
+		},
+		{
+			name: "packages.lock.json",
+			path: "packages.lock.json",
+			content: `{
+  "version": 1,
+  "dependencies": {
+    "net6.0": {
+      "Serilog.Sinks.File": {"type": "Direct", "resolved": "5.0.0", "dependencies": {"Serilog": "2.10.0"}},
+      "Serilog": {"type": "Transitive", "resolved": "2.10.0"}
+    }
+  }
+}`,
+			want: graphSummary{
+				Direct: []string{"Serilog.Sinks.File@5.0.0"},
+				Edges:  map[string][]string{"Serilog.Sinks.File@5.0.0": {"Serilog@2.10.0"}},
+			},
+		},
+		{
+			name:    "flat lockfile",
+			path:    "requirements.txt",
+			content: "requests==2.31.0\nurllib3==2.0.0\n",
+			want: graphSummary{
+				Direct: []string{"requests@2.31.0", "urllib3@2.0.0"},
+				Edges:  map[string][]string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g, err := ParseGraph(tt.path, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseGraph() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, summarize(g)); diff != "" {
+				t.Errorf("ParseGraph() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCollectGraph(t *testing.T) {
+	t.Parallel()
+	r := fileReader{
+		"go.mod":           "module m\n\nrequire github.com/a/b v1.0.0\n",
+		"requirements.txt": "requests==2.31.0\n",
+		"Cargo.lock":       "[[package]\n",
+	}
+	g, err := CollectGraph(r)
+	if err != nil {
+		t.Fatalf("CollectGraph() failed: %v", err)
+	}
+	if len(g.ParseErrors) != 1 || g.ParseErrors[0].Path != "Cargo.lock" {
+		t.Errorf("CollectGraph() parse errors = %v, want Cargo.lock", g.ParseErrors)
+	}
+	want := graphSummary{
+		Direct: []string{"github.com/a/b@v1.0.0", "requests@2.31.0"},
+		Edges:  map[string][]string{},
+	}
+	if diff := cmp.Diff(want, summarize(g)); diff != "" {
+		t.Errorf("CollectGraph() mismatch (-want +got): %s", diff)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lockfile parses the dependencies of a project from its lockfiles.
+package lockfile
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// Ecosystem is the name of a package ecosystem, using the naming of OSV.
+type Ecosystem string
+
+const (
+	// EcosystemNpm is the npm ecosystem.
+	EcosystemNpm Ecosystem = "npm"
+	// EcosystemPyPI is the Python Package Index.
+	EcosystemPyPI Ecosystem = "PyPI"
+	// EcosystemGo is the Go modules ecosystem.
+	EcosystemGo Ecosystem = "Go"
+	// EcosystemMaven is the Maven ecosystem, including Gradle.
+	EcosystemMaven Ecosystem = "Maven"
+	// EcosystemCrates is the crates.io ecosystem for Rust.
+	EcosystemCrates Ecosystem = "crates.io"
+	// EcosystemNuGet is the NuGet ecosystem.
+	EcosystemNuGet Ecosystem = "NuGet"
+	// EcosystemRubyGems is the RubyGems ecosystem.
+	EcosystemRubyGems Ecosystem = "RubyGems"
+)
+
+// Dependency is a package locked by a lockfile.
+type Dependency struct {
+	Ecosystem Ecosystem
+	// Name is the name of the package, e.g., `group:artifact` for Maven.
+	Name string
+	// Version is the locked version. It is empty when the file does not pin one.
+	Version string
+	// Path is the path of the lockfile.
+	Path string
+}
+
+// ParseError is a lockfile which cannot be parsed. Collect and CollectGraph record it
+// and go on with the other lockfiles.
+type ParseError struct {
+	// Path is the path of the lockfile.
+	Path string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+type parser func(content []byte) ([]Dependency, error)
+
+type lockfileFormat struct {
+	ecosystem Ecosystem
+	parse     parser
+}
+
+// formats maps the base names of lockfiles to their format.
+var formats = map[string]lockfileFormat{
+	"package-lock.json":   {EcosystemNpm, parsePackageLock},
+	"npm-shrinkwrap.json": {EcosystemNpm, parsePackageLock},
+	"yarn.lock":           {EcosystemNpm, parseYarnLock},
+	"pnpm-lock.yaml":      {EcosystemNpm, parsePnpmLock},
+	"poetry.lock":         {EcosystemPyPI, parseTomlPackages},
+	"Pipfile.lock":        {EcosystemPyPI, parsePipfileLock},
+	"go.mod":              {EcosystemGo, parseGoMod},
+	"pom.xml":             {EcosystemMaven, parsePomXML},
+	"gradle.lockfile":     {EcosystemMaven, parseGradleLockfile},
+	"Cargo.lock":          {EcosystemCrates, parseCargoLock},
+	"packages.lock.json":  {EcosystemNuGet, parseNuGetLock},
+	"packages.config":     {EcosystemNuGet, parsePackagesConfig},
+}
+
+// FileReader lists and reads the files of a project, e.g., a clients.RepoClient.
+type FileReader interface {
+	ListFiles(predicate func(string) (bool, error)) ([]string, error)
+	GetFileContent(filename string) ([]byte, error)
+}
+
+func formatFor(pathfn string) (lockfileFormat, bool) {
+	base := path.Base(pathfn)
+	if f, ok := formats[base]; ok {
+		return f, true
+	}
+	// requirements.txt, requirements-dev.txt, requirements/test.txt.
+	if strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt") ||
+		path.Base(path.Dir(pathfn)) == "requirements" && strings.HasSuffix(base, ".txt") {
+		return lockfileFormat{EcosystemPyPI, parseRequirements}, true
+	}
+	return lockfileFormat{}, false
+}
+
+// IsLockfile returns true if the file is a lockfile, or a manifest pinning versions, supported by Parse.
+func IsLockfile(pathfn string) bool {
+	if isVendored(pathfn) {
+		return false
+	}
+	_, ok := formatFor(pathfn)
+	return ok
+}
+
+// isVendored returns true for the files of installed dependencies, and for test fixtures.
+func isVendored(pathfn string) bool {
+	for _, dir := range strings.Split(path.Dir(pathfn), "/") {
+		if dir == "node_modules" || dir == "vendor" || dir == "third_party" || dir == "testdata" {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse returns the dependencies of a lockfile.
+func Parse(pathfn string, content []byte) ([]Dependency, error) {
+	f, ok := formatFor(pathfn)
+	if !ok {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("unsupported lockfile: %s", pathfn))
+	}
+	deps, err := f.parse(content)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: %v", pathfn, err))
+	}
+	for i := range deps {
+		deps[i].Ecosystem = f.ecosystem
+		deps[i].Path = pathfn
+	}
+	return deps, nil
+}
+
+// Collect returns the dependencies of all the lockfiles of a project, sorted by ecosystem, name and version,
+// along with the lockfiles which cannot be parsed.
+func Collect(r FileReader) ([]Dependency, []ParseError, error) {
+	files, err := r.ListFiles(func(pathfn string) (bool, error) {
+		return IsLockfile(pathfn), nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("ListFiles: %w", err)
+	}
+	sort.Strings(files)
+
+	var deps []Dependency
+	var parseErrs []ParseError
+	for _, f := range files {
+		content, err := r.GetFileContent(f)
+		if err != nil {
+			return nil, nil, fmt.Errorf("GetFileContent: %w", err)
+		}
+		fdeps, err := Parse(f, content)
+		if err != nil {
+			parseErrs = append(parseErrs, ParseError{Path: f, Err: err})
+			continue
+		}
+		deps = append(deps, fdeps...)
+	}
+	sortDependencies(deps)
+	return deps, parseErrs, nil
+}
+
+func sortDependencies(deps []Dependency) {
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].Ecosystem != deps[j].Ecosystem {
+			return deps[i].Ecosystem < deps[j].Ecosystem
+		}
+		if deps[i].Name != deps[j].Name {
+			return deps[i].Name < deps[j].Name
+		}
+		if deps[i].Version != deps[j].Version {
+			return deps[i].Version < deps[j].Version
+		}
+		return deps[i].Path < deps[j].Path
+	})
+}
@@ -0,0 +1,342 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    []Dependency
+		wantErr bool
+	}{
+		{
+			name: "package-lock.json v3",
+			path: "package-lock.json",
+			content: `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "root"},
+    "node_modules/@scope/a": {"version": "1.0.0"},
+    "node_modules/b/node_modules/c": {"version": "2.0.0"},
+    "node_modules/local": {"resolved": "packages/local", "link": true}
+  }
+}`,
+			want: []Dependency{
+				{EcosystemNpm, "@scope/a", "1.0.0", "package-lock.json"},
+				{EcosystemNpm, "c", "2.0.0", "package-lock.json"},
+			},
+		},
+		{
+			name: "package-lock.json v1",
+			path: "web/npm-shrinkwrap.json",
+			content: `{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "a": {"version": "1.0.0", "dependencies": {"b": {"version": "2.0.0"}}}
+  }
+}`,
+			want: []Dependency{
+				{EcosystemNpm, "a", "1.0.0", "web/npm-shrinkwrap.json"},
+				{EcosystemNpm, "b", "2.0.0", "web/npm-shrinkwrap.json"},
+			},
+		},
+		{
+			name: "yarn classic",
+			path: "yarn.lock",
+			content: `# THIS IS AN AUTOGENERATED FILE.
+"@babel/core@^7.0.0", "@babel/core@^7.1.0":
+  version "7.22.10"
+  resolved "https://registry.yarnpkg.com/@babel/core/-/core-7.22.10.tgz"
+
+lodash@^4.17.21:
+  version "4.17.21"
+`,
+			want: []Dependency{
+				{EcosystemNpm, "@babel/core", "7.22.10", "yarn.lock"},
+				{EcosystemNpm, "lodash", "4.17.21", "yarn.lock"},
+			},
+		},
+		{
+			name: "yarn berry",
+			path: "yarn.lock",
+			content: `__metadata:
+  version: 6
+
+"lodash@npm:^4.17.21":
+  version: 4.17.21
+
+"root@workspace:.":
+  version: 0.0.0-use.local
+`,
+			want: []Dependency{
+				{EcosystemNpm, "lodash", "4.17.21", "yarn.lock"},
+			},
+		},
+		{
+			name: "pnpm",
+			path: "pnpm-lock.yaml",
+			content: `lockfileVersion: '6.0'
+packages:
+  /@scope/a@1.0.0:
+    resolution: {integrity: sha512-abc}
+  /b@2.0.0(react@18.2.0):
+    resolution: {integrity: sha512-def}
+`,
+			want: []Dependency{
+				{EcosystemNpm, "@scope/a", "1.0.0", "pnpm-lock.yaml"},
+				{EcosystemNpm, "b", "2.0.0", "pnpm-lock.yaml"},
+			},
+		},
+		{
+			name: "requirements",
+			path: "requirements/dev.txt",
+			content: `# comment
+-r base.txt
+requests[socks]==2.31.0 \
+    --hash=sha256:abc
+flask>=2.0
+`,
+			want: []Dependency{
+				{EcosystemPyPI, "flask", "", "requirements/dev.txt"},
+				{EcosystemPyPI, "requests", "2.31.0", "requirements/dev.txt"},
+			},
+		},
+		{
+			name: "poetry",
+			path: "poetry.lock",
+			content: `[[package]]
+name = "requests"
+version = "2.31.0"
+
+[metadata]
+lock-version = "2.0"
+`,
+			want: []Dependency{
+				{EcosystemPyPI, "requests", "2.31.0", "poetry.lock"},
+			},
+		},
+		{
+			name:    "Pipfile.lock",
+			path:    "Pipfile.lock",
+			content: `{"default": {"requests": {"version": "==2.31.0"}}, "develop": {"pytest": {"version": "==7.4.0"}}}`,
+			want: []Dependency{
+				{EcosystemPyPI, "pytest", "7.4.0", "Pipfile.lock"},
+				{EcosystemPyPI, "requests", "2.31.0", "Pipfile.lock"},
+			},
+		},
+		{
+			name: "go.mod",
+			path: "go.mod",
+			content: `module example.com/m
+
+go 1.21
+
+require (
+	github.com/google/go-cmp v0.5.9
+	golang.org/x/mod v0.12.0 // indirect
+)
+
+replace golang.org/x/mod => golang.org/x/mod v0.13.0
+`,
+			want: []Dependency{
+				{EcosystemGo, "github.com/google/go-cmp", "v0.5.9", "go.mod"},
+				{EcosystemGo, "golang.org/x/mod", "v0.13.0", "go.mod"},
+			},
+		},
+		{
+			name: "pom.xml",
+			path: "pom.xml",
+			content: `<project>
+  <properties><guava.version>32.1.2-jre</guava.version></properties>
+  <dependencies>
+    <dependency>
+      <groupId>com.google.guava</groupId>
+      <artifactId>guava</artifactId>
+      <version>${guava.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>4.13.2</version>
+    </dependency>
+  </dependencies>
+</project>`,
+			want: []Dependency{
+				{EcosystemMaven, "com.google.guava:guava", "32.1.2-jre", "pom.xml"},
+				{EcosystemMaven, "junit:junit", "4.13.2", "pom.xml"},
+			},
+		},
+		{
+			name: "gradle.lockfile",
+			path: "app/gradle.lockfile",
+			content: `# This is a Gradle generated file for dependency locking.
+com.google.guava:guava:32.1.2-jre=compileClasspath,runtimeClasspath
+empty=annotationProcessor
+`,
+			want: []Dependency{
+				{EcosystemMaven, "com.google.guava:guava", "32.1.2-jre", "app/gradle.lockfile"},
+			},
+		},
+		{
+			name: "Cargo.lock",
+			path: "Cargo.lock",
+			content: `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+
+[[package]]
+name = "serde"
+version = "1.0.188"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+			want: []Dependency{
+				{EcosystemCrates, "serde", "1.0.188", "Cargo.lock"},
+			},
+		},
+		{
+			name: "packages.lock.json",
+			path: "src/packages.lock.json",
+			content: `{
+  "version": 1,
+  "dependencies": {
+    "net6.0": {
+      "Newtonsoft.Json": {"type": "Direct", "requested": "[13.0.3, )", "resolved": "13.0.3"},
+      "Lib": {"type": "Project"}
+    }
+  }
+}`,
+			want: []Dependency{
+				{EcosystemNuGet, "Newtonsoft.Json", "13.0.3", "src/packages.lock.json"},
+			},
+		},
+		{
+			name:    "packages.config",
+			path:    "packages.config",
+			content: `<packages><package id="NUnit" version="3.13.3" targetFramework="net48" /></packages>`,
+			want: []Dependency{
+				{EcosystemNuGet, "NUnit", "3.13.3", "packages.config"},
+			},
+		},
+		{
+			name:    "invalid",
+			path:    "package-lock.json",
+			content: `{`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported",
+			path:    "README.md",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Parse(tt.path, []byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			sortDependencies(got)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestIsLockfile(t *testing.T) {
+	t.Parallel()
+	tests := map[string]bool{
+		"package-lock.json":                    true,
+		"services/api/go.mod":                  true,
+		"requirements-dev.txt":                 true,
+		"requirements/test.txt":                true,
+		"node_modules/a/package-lock.json":     false,
+		"vendor/github.com/a/b/go.mod":         false,
+		"package.json":                         false,
+		"docs/requirements-explained.md":       false,
+		"third_party/project/Cargo.lock":       false,
+		"internal/parser/testdata/yarn.lock":   false,
+		"src/Project/packages.lock.json":       true,
+		"gradle/dependency-locks/app.lockfile": false,
+	}
+	for path, want := range tests {
+		if got := IsLockfile(path); got != want {
+			t.Errorf("IsLockfile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+type fileReader map[string]string
+
+func (f fileReader) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	var files []string
+	for name := range f {
+		matches, err := predicate(name)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+func (f fileReader) GetFileContent(filename string) ([]byte, error) {
+	content, ok := f[filename]
+	if !ok {
+		return nil, fmt.Errorf("no such file: %s", filename) //nolint:goerr113
+	}
+	return []byte(content), nil
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+	r := fileReader{
+		"go.mod":                   "module m\n\nrequire github.com/a/b v1.0.0\n",
+		"web/requirements.txt":     "requests==2.31.0\n",
+		"README.md":                "# readme",
+		"node_modules/x/yarn.lock": "x@^1.0.0:\n  version \"1.0.0\"\n",
+		"testdata/go.mod":          "not a go.mod",
+		"web/package-lock.json":    "{",
+	}
+	got, parseErrs, err := Collect(r)
+	if err != nil {
+		t.Fatalf("Collect() failed: %v", err)
+	}
+	if len(parseErrs) != 1 || parseErrs[0].Path != "web/package-lock.json" {
+		t.Errorf("Collect() parse errors = %v, want web/package-lock.json", parseErrs)
+	}
+	want := []Dependency{
+		{EcosystemGo, "github.com/a/b", "v1.0.0", "go.mod"},
+		{EcosystemPyPI, "requests", "2.31.0", "web/requirements.txt"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Collect() mismatch (-want +got): %s", diff)
+	}
+}
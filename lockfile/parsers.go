@@ -0,0 +1,381 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+)
+
+// parsePackageLock parses package-lock.json and npm-shrinkwrap.json files.
+func parsePackageLock(content []byte) ([]Dependency, error) {
+	type v1Dependency struct {
+		Dependencies map[string]json.RawMessage `json:"dependencies"`
+		Version      string                     `json:"version"`
+	}
+	var lock struct {
+		// lockfileVersion 2 and 3.
+		Packages map[string]struct {
+			Version string `json:"version"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+		// lockfileVersion 1.
+		Dependencies map[string]json.RawMessage `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	var deps []Dependency
+	if len(lock.Packages) > 0 {
+		for key, p := range lock.Packages {
+			// The root project and workspaces are not installed in node_modules.
+			i := strings.LastIndex(key, "node_modules/")
+			if i < 0 || p.Link {
+				continue
+			}
+			deps = append(deps, Dependency{Name: key[i+len("node_modules/"):], Version: p.Version})
+		}
+		return deps, nil
+	}
+
+	var walk func(map[string]json.RawMessage) error
+	walk = func(dependencies map[string]json.RawMessage) error {
+		for name, raw := range dependencies {
+			var d v1Dependency
+			if err := json.Unmarshal(raw, &d); err != nil {
+				return fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			deps = append(deps, Dependency{Name: name, Version: d.Version})
+			if err := walk(d.Dependencies); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(lock.Dependencies); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// yarnVersion matches the version of a yarn.lock entry, e.g., `version "1.2.3"` or `version: 1.2.3`.
+var yarnVersion = regexp.MustCompile(`^\s+version:?\s+"?([^"\s]+)"?`)
+
+// parseYarnLock parses yarn.lock files of yarn classic and berry.
+func parseYarnLock(content []byte) ([]Dependency, error) {
+	var deps []Dependency
+	name := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		// "@babel/core@^7.0.0", "@babel/core@^7.1.0":
+		case !strings.HasPrefix(line, " "):
+			spec := strings.Trim(strings.SplitN(strings.TrimSuffix(line, ":"), ",", 2)[0], `" `)
+			name = yarnPackageName(spec)
+		case name != "":
+			if m := yarnVersion.FindStringSubmatch(line); m != nil {
+				deps = append(deps, Dependency{Name: name, Version: m[1]})
+				name = ""
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+	return deps, nil
+}
+
+// yarnPackageName returns the package name of a yarn.lock descriptor, e.g., @scope/name@npm:^1.0.0.
+func yarnPackageName(spec string) string {
+	i := strings.LastIndex(spec, "@")
+	if i <= 0 {
+		return ""
+	}
+	name, rng := spec[:i], spec[i+1:]
+	// The metadata and workspaces of yarn berry are not dependencies.
+	if name == "__metadata" || strings.HasPrefix(rng, "workspace:") || strings.Contains(name, "@workspace:") {
+		return ""
+	}
+	// @scope/name@npm:1.0.0, name@patch:name@npm%3A1.0.0#...
+	if j := strings.Index(name, "@"); j > 0 {
+		name = name[:j]
+	}
+	return name
+}
+
+// parsePnpmLock parses pnpm-lock.yaml files.
+func parsePnpmLock(content []byte) ([]Dependency, error) {
+	var lock struct {
+		Packages map[string]struct {
+			Name    string `yaml:"name"`
+			Version string `yaml:"version"`
+		} `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+
+	var deps []Dependency
+	for key, p := range lock.Packages {
+		if p.Name != "" && p.Version != "" {
+			deps = append(deps, Dependency{Name: p.Name, Version: p.Version})
+			continue
+		}
+		// Peer dependencies are suffixed, e.g., /name@1.0.0(react@18.0.0) or /name/1.0.0_react@18.0.0.
+		key = strings.TrimPrefix(key, "/")
+		if i := strings.Index(key, "("); i >= 0 {
+			key = key[:i]
+		}
+		// pnpm 6: /name@1.0.0, pnpm 5: /name/1.0.0.
+		sep := strings.LastIndex(key, "@")
+		if sep <= 0 {
+			sep = strings.LastIndex(key, "/")
+		}
+		if sep <= 0 {
+			continue
+		}
+		version := key[sep+1:]
+		if i := strings.Index(version, "_"); i >= 0 {
+			version = version[:i]
+		}
+		deps = append(deps, Dependency{Name: key[:sep], Version: version})
+	}
+	return deps, nil
+}
+
+// parseTomlPackages parses the [[package]] tables of poetry.lock files.
+func parseTomlPackages(content []byte) ([]Dependency, error) {
+	var lock struct {
+		Package []struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("toml.Unmarshal: %w", err)
+	}
+	deps := make([]Dependency, 0, len(lock.Package))
+	for _, p := range lock.Package {
+		deps = append(deps, Dependency{Name: p.Name, Version: p.Version})
+	}
+	return deps, nil
+}
+
+// parseCargoLock parses Cargo.lock files. Packages without a source are the crates of the workspace.
+func parseCargoLock(content []byte) ([]Dependency, error) {
+	var lock struct {
+		Package []struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+			Source  string `toml:"source"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("toml.Unmarshal: %w", err)
+	}
+	var deps []Dependency
+	for _, p := range lock.Package {
+		if p.Source == "" {
+			continue
+		}
+		deps = append(deps, Dependency{Name: p.Name, Version: p.Version})
+	}
+	return deps, nil
+}
+
+// parsePipfileLock parses Pipfile.lock files.
+func parsePipfileLock(content []byte) ([]Dependency, error) {
+	type packages map[string]struct {
+		Version string `json:"version"`
+	}
+	var lock struct {
+		Default packages `json:"default"`
+		Develop packages `json:"develop"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	var deps []Dependency
+	for _, p := range []packages{lock.Default, lock.Develop} {
+		for name, d := range p {
+			deps = append(deps, Dependency{Name: name, Version: strings.TrimPrefix(d.Version, "==")})
+		}
+	}
+	return deps, nil
+}
+
+// requirement matches the name and the optional exact version of a requirement, e.g., requests[socks]==2.31.0.
+var requirement = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*(===?\s*([^\s;,#\\]+))?`)
+
+// parseRequirements parses pip requirements files. Requirements without an exact version have no version.
+func parseRequirements(content []byte) ([]Dependency, error) {
+	var deps []Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// Options, e.g., -r other.txt or --hash, and continued hashes.
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		m := requirement.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		deps = append(deps, Dependency{Name: m[1], Version: m[4]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+	return deps, nil
+}
+
+// parseGoMod parses the requirements of go.mod files, which the Go command keeps at the selected versions.
+func parseGoMod(content []byte) ([]Dependency, error) {
+	f, err := modfile.Parse("go.mod", content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("modfile.Parse: %w", err)
+	}
+	replaced := make(map[string]string)
+	for _, r := range f.Replace {
+		// Replacements by local directories have no version.
+		if r.New.Version != "" {
+			replaced[r.Old.Path] = r.New.Version
+		}
+	}
+	deps := make([]Dependency, 0, len(f.Require))
+	for _, r := range f.Require {
+		version := r.Mod.Version
+		if v, ok := replaced[r.Mod.Path]; ok {
+			version = v
+		}
+		deps = append(deps, Dependency{Name: r.Mod.Path, Version: version})
+	}
+	return deps, nil
+}
+
+// parsePomXML parses the dependencies of pom.xml files, resolving the versions defined as properties.
+func parsePomXML(content []byte) ([]Dependency, error) {
+	type mavenDependency struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	}
+	var pom struct {
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+		Dependencies []mavenDependency `xml:"dependencies>dependency"`
+		Managed      []mavenDependency `xml:"dependencyManagement>dependencies>dependency"`
+	}
+	if err := xml.Unmarshal(content, &pom); err != nil {
+		return nil, fmt.Errorf("xml.Unmarshal: %w", err)
+	}
+
+	properties := make(map[string]string)
+	for _, p := range pom.Properties.Entries {
+		properties[p.XMLName.Local] = strings.TrimSpace(p.Value)
+	}
+	var deps []Dependency
+	for _, d := range append(pom.Dependencies, pom.Managed...) {
+		version := strings.TrimSpace(d.Version)
+		if strings.HasPrefix(version, "${") && strings.HasSuffix(version, "}") {
+			version = properties[version[2:len(version)-1]]
+		}
+		deps = append(deps, Dependency{
+			Name:    strings.TrimSpace(d.GroupID) + ":" + strings.TrimSpace(d.ArtifactID),
+			Version: version,
+		})
+	}
+	return deps, nil
+}
+
+// parseGradleLockfile parses gradle.lockfile files, e.g., com.google.guava:guava:32.1.2-jre=compileClasspath.
+func parseGradleLockfile(content []byte) ([]Dependency, error) {
+	var deps []Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "empty=") {
+			continue
+		}
+		coordinates := strings.SplitN(line, "=", 2)[0]
+		parts := strings.Split(coordinates, ":")
+		if len(parts) != 3 {
+			continue
+		}
+		deps = append(deps, Dependency{Name: parts[0] + ":" + parts[1], Version: parts[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+	return deps, nil
+}
+
+// parseNuGetLock parses packages.lock.json files. Project references are not packages.
+func parseNuGetLock(content []byte) ([]Dependency, error) {
+	var lock struct {
+		Dependencies map[string]map[string]struct {
+			Type     string `json:"type"`
+			Resolved string `json:"resolved"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	var deps []Dependency
+	for _, framework := range lock.Dependencies {
+		for name, d := range framework {
+			if strings.EqualFold(d.Type, "Project") {
+				continue
+			}
+			deps = append(deps, Dependency{Name: name, Version: d.Resolved})
+		}
+	}
+	return deps, nil
+}
+
+// parsePackagesConfig parses packages.config files.
+func parsePackagesConfig(content []byte) ([]Dependency, error) {
+	var config struct {
+		Packages []struct {
+			ID      string `xml:"id,attr"`
+			Version string `xml:"version,attr"`
+		} `xml:"package"`
+	}
+	if err := xml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("xml.Unmarshal: %w", err)
+	}
+	deps := make([]Dependency, 0, len(config.Packages))
+	for _, p := range config.Packages {
+		deps = append(deps, Dependency{Name: p.ID, Version: p.Version})
+	}
+	return deps, nil
+}
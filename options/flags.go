@@ -48,6 +48,24 @@ const (
 	// FlagNuget is the flag name for specifying a Nuget repository.
 	FlagNuget = "nuget"
 
+	// FlagMaven is the flag name for specifying a Maven package.
+	FlagMaven = "maven"
+
+	// FlagCargo is the flag name for specifying a Cargo crate.
+	FlagCargo = "cargo"
+
+	// FlagGo is the flag name for specifying a Go module.
+	FlagGo = "go"
+
+	// FlagPackagist is the flag name for specifying a Packagist package.
+	FlagPackagist = "packagist"
+
+	// FlagHex is the flag name for specifying a Hex package.
+	FlagHex = "hex"
+
+	// FlagPURL is the flag name for specifying a package by its package URL.
+	FlagPURL = "purl"
+
 	// FlagMetadata is the flag name for specifying metadata for the project.
 	FlagMetadata = "metadata"
 
@@ -130,6 +148,48 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 		"nuget package to check, given that the nuget package has a GitHub repository",
 	)
 
+	cmd.Flags().StringVar(
+		&o.Maven,
+		FlagMaven,
+		o.Maven,
+		"maven package to check, as groupId:artifactId[:version], given that its POM declares a source repository",
+	)
+
+	cmd.Flags().StringVar(
+		&o.Cargo,
+		FlagCargo,
+		o.Cargo,
+		"cargo crate to check, given that the crate has a GitHub or GitLab repository",
+	)
+
+	cmd.Flags().StringVar(
+		&o.Go,
+		FlagGo,
+		o.Go,
+		"go module to check, given that the module has a GitHub or GitLab repository",
+	)
+
+	cmd.Flags().StringVar(
+		&o.Packagist,
+		FlagPackagist,
+		o.Packagist,
+		"packagist package to check, as vendor/package, given that the package has a GitHub or GitLab repository",
+	)
+
+	cmd.Flags().StringVar(
+		&o.Hex,
+		FlagHex,
+		o.Hex,
+		"hex package to check, given that the package has a GitHub or GitLab repository",
+	)
+
+	cmd.Flags().StringVar(
+		&o.PURL,
+		FlagPURL,
+		o.PURL,
+		"package URL of the package to check, e.g., pkg:npm/lodash",
+	)
+
 	cmd.Flags().StringSliceVar(
 		&o.Metadata,
 		FlagMetadata,
@@ -166,6 +226,8 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 	allowedFormats := []string{
 		FormatDefault,
 		FormatJSON,
+		FormatCycloneDX,
+		FormatSPDX,
 	}
 
 	if o.isSarifEnabled() {
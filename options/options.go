@@ -38,6 +38,12 @@ type Options struct {
 	PyPI       string
 	RubyGems   string
 	Nuget      string
+	Maven      string
+	Cargo      string
+	Go         string
+	Packagist  string
+	Hex        string
+	PURL       string
 	PolicyFile string
 	// TODO(action): Add logic for writing results to file
 	ResultsFile string
@@ -91,6 +97,10 @@ const (
 	FormatDefault = "default"
 	// FormatRaw specifies that results should be output in raw format.
 	FormatRaw = "raw"
+	// FormatCycloneDX specifies that results should be output as properties of a CycloneDX SBOM.
+	FormatCycloneDX = "cyclonedx"
+	// FormatSPDX specifies that results should be output as annotations of an SPDX SBOM.
+	FormatSPDX = "spdx"
 
 	// Environment variables.
 	// EnvVarEnableSarif is the environment variable which controls enabling
@@ -114,7 +124,8 @@ var (
 	errPolicyFileNotSupported          = errors.New("policy file is not supported yet")
 	errRawOptionNotSupported           = errors.New("raw option is not supported yet")
 	errRepoOptionMustBeSet             = errors.New(
-		"exactly one of `repo`, `npm`, `pypi`, `rubygems`, `nuget` or `local` must be set",
+		"exactly one of `repo`, `npm`, `pypi`, `rubygems`, `nuget`, `maven`, `cargo`, `go`, " +
+			"`packagist`, `hex`, `purl` or `local` must be set",
 	)
 	errSARIFNotSupported = errors.New("SARIF format is not supported yet")
 	errValidate          = errors.New("some options could not be validated")
@@ -125,12 +136,18 @@ var (
 func (o *Options) Validate() error {
 	var errs []error
 
-	// Validate exactly one of `--repo`, `--npm`, `--pypi`, `--rubygems`, `--nuget`, `--local` is enabled.
+	// Validate exactly one of `--repo`, `--local` or a package manager flag is enabled.
 	if boolSum(o.Repo != "",
 		o.NPM != "",
 		o.PyPI != "",
 		o.RubyGems != "",
 		o.Nuget != "",
+		o.Maven != "",
+		o.Cargo != "",
+		o.Go != "",
+		o.Packagist != "",
+		o.Hex != "",
+		o.PURL != "",
 		o.Local != "") != 1 {
 		errs = append(
 			errs,
@@ -265,7 +282,8 @@ func (o *Options) isV6Enabled() bool {
 func validateFormat(format string) bool {
 	switch format {
 	case FormatJSON, FormatSJSON, FormatFJSON,
-		FormatPJSON, FormatSarif, FormatDefault, FormatRaw:
+		FormatPJSON, FormatSarif, FormatDefault, FormatRaw,
+		FormatCycloneDX, FormatSPDX:
 		return true
 	default:
 		return false
@@ -66,6 +66,14 @@ type jsonScorecardV2 struct {
 	Commit  string `json:"commit"`
 }
 
+type jsonRepositoryLinkV2 struct {
+	Ecosystem  string   `json:"ecosystem"`
+	Package    string   `json:"package"`
+	Version    string   `json:"version,omitempty"`
+	Confidence string   `json:"confidence"`
+	Reasons    []string `json:"reasons"`
+}
+
 type jsonFloatScore float64
 
 func (s jsonFloatScore) MarshalJSON() ([]byte, error) {
@@ -77,12 +85,13 @@ func (s jsonFloatScore) MarshalJSON() ([]byte, error) {
 //
 //nolint:govet
 type JSONScorecardResultV2 struct {
-	Date           string              `json:"date"`
-	Repo           jsonRepoV2          `json:"repo"`
-	Scorecard      jsonScorecardV2     `json:"scorecard"`
-	AggregateScore jsonFloatScore      `json:"score"`
-	Checks         []jsonCheckResultV2 `json:"checks"`
-	Metadata       []string            `json:"metadata"`
+	Date           string                `json:"date"`
+	Repo           jsonRepoV2            `json:"repo"`
+	Scorecard      jsonScorecardV2       `json:"scorecard"`
+	AggregateScore jsonFloatScore        `json:"score"`
+	Checks         []jsonCheckResultV2   `json:"checks"`
+	Metadata       []string              `json:"metadata"`
+	RepositoryLink *jsonRepositoryLinkV2 `json:"repositoryLink,omitempty"`
 }
 
 // AsJSON exports results as JSON for new detail format.
@@ -140,6 +149,15 @@ func (r *ScorecardResult) AsJSON2(showDetails bool,
 		Metadata:       r.Metadata,
 		AggregateScore: jsonFloatScore(score),
 	}
+	if link := r.RepositoryLink; link != nil {
+		out.RepositoryLink = &jsonRepositoryLinkV2{
+			Ecosystem:  link.Ecosystem,
+			Package:    link.Package,
+			Version:    link.Version,
+			Confidence: string(link.Confidence),
+			Reasons:    link.Reasons,
+		}
+	}
 
 	for _, checkResult := range r.Checks {
 		doc, e := checkDocs.GetCheck(checkResult.Name)
@@ -202,6 +220,15 @@ func ExperimentalFromJSON2(r io.Reader) (ScorecardResult, error) {
 		Metadata: jsr.Metadata,
 		Checks:   make([]checker.CheckResult, 0, len(jsr.Checks)),
 	}
+	if link := jsr.RepositoryLink; link != nil {
+		sr.RepositoryLink = &RepositoryLink{
+			Ecosystem:  link.Ecosystem,
+			Package:    link.Package,
+			Version:    link.Version,
+			Confidence: RepositoryLinkConfidence(link.Confidence),
+			Reasons:    link.Reasons,
+		}
+	}
 
 	for _, check := range jsr.Checks {
 		cr := checker.CheckResult{
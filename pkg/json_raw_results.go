@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
@@ -76,6 +77,32 @@ type jsonBranchProtection struct {
 type jsonBranchProtectionMetadata struct {
 	Branches        []jsonBranchProtection `json:"branches"`
 	CodeownersFiles []string               `json:"codeownersFiles"`
+	Codeowners      []jsonCodeownersFile   `json:"codeowners,omitempty"`
+}
+
+type jsonCodeowner struct {
+	Resolved *bool  `json:"resolved,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+}
+
+type jsonCodeownersRule struct {
+	Pattern string          `json:"pattern"`
+	Section string          `json:"section,omitempty"`
+	Owners  []jsonCodeowner `json:"owners"`
+	Line    uint            `json:"line"`
+}
+
+type jsonCodeownersCoverage struct {
+	Path   string          `json:"path"`
+	Type   string          `json:"type"`
+	Owners []jsonCodeowner `json:"owners"`
+}
+
+type jsonCodeownersFile struct {
+	Path           string                   `json:"path"`
+	Rules          []jsonCodeownersRule     `json:"rules"`
+	SensitivePaths []jsonCodeownersCoverage `json:"sensitivePaths"`
 }
 
 type jsonReview struct {
@@ -99,6 +126,57 @@ type jsonContributors struct {
 	// TODO: high-level statistics, etc
 }
 
+type jsonBusFactor struct {
+	LookbackDate time.Time        `json:"lookbackDate"`
+	Maintainers  []jsonMaintainer `json:"maintainers"`
+	NumCommits   int              `json:"numCommits"`
+}
+
+type jsonSAST struct {
+	Workflows []jsonSASTWorkflow `json:"workflows"`
+	Commits   []jsonSASTCommit   `json:"commits"`
+	// Languages is null if the languages of the repository are unknown.
+	Languages []string `json:"languages"`
+}
+
+type jsonSASTWorkflow struct {
+	Type     string   `json:"type"`
+	Tool     string   `json:"tool"`
+	File     jsonFile `json:"file"`
+	Triggers []string `json:"triggers"`
+	// Languages is null if the tool analyzes all languages.
+	Languages []string `json:"languages"`
+}
+
+type jsonSASTCommit struct {
+	URL               *string `json:"url,omitempty"`
+	Tool              *string `json:"tool,omitempty"`
+	HeadSHA           string  `json:"headSHA"`
+	PullRequestNumber int     `json:"pullRequestNumber"`
+}
+
+type jsonRepositorySettings struct {
+	SecretScanning                *bool `json:"secretScanning,omitempty"`
+	SecretScanningPushProtection  *bool `json:"secretScanningPushProtection,omitempty"`
+	DependabotAlerts              *bool `json:"dependabotAlerts,omitempty"`
+	DependabotSecurityUpdates     *bool `json:"dependabotSecurityUpdates,omitempty"`
+	PrivateVulnerabilityReporting *bool `json:"privateVulnerabilityReporting,omitempty"`
+	ForkingAllowed                *bool `json:"forkingAllowed,omitempty"`
+}
+
+type jsonSecret struct {
+	Reason      string   `json:"reason,omitempty"`
+	Rule        string   `json:"rule"`
+	File        jsonFile `json:"file"`
+	Allowlisted bool     `json:"allowlisted"`
+}
+
+type jsonMaintainer struct {
+	User       jsonUser `json:"user"`
+	NumCommits int      `json:"numCommits"`
+	NumMerges  int      `json:"numMerges"`
+}
+
 type jsonOrganization struct {
 	Login string `json:"login"`
 	// TODO: other info.
@@ -119,6 +197,12 @@ type jsonDefaultBranchChangeset struct {
 	// TODO: check runs, etc.
 }
 
+type jsonCIRevision struct {
+	HeadSHA           string   `json:"headSHA"`
+	Categories        []string `json:"categories"`
+	PullRequestNumber int      `json:"pullRequestNumber"`
+}
+
 type jsonCommit struct {
 	Message   string   `json:"message"`
 	SHA       string   `json:"sha"`
@@ -198,10 +282,12 @@ type jsonWorkflowJob struct {
 }
 
 type jsonPackage struct {
-	Name *string          `json:"name,omitempty"`
-	Job  *jsonWorkflowJob `json:"job,omitempty"`
-	File *jsonFile        `json:"file,omitempty"`
-	Runs []jsonRun        `json:"runs,omitempty"`
+	Name     *string          `json:"name,omitempty"`
+	Job      *jsonWorkflowJob `json:"job,omitempty"`
+	File     *jsonFile        `json:"file,omitempty"`
+	Registry string           `json:"registry,omitempty"`
+	Auth     string           `json:"auth,omitempty"`
+	Runs     []jsonRun        `json:"runs,omitempty"`
 }
 
 type jsonRun struct {
@@ -210,7 +296,22 @@ type jsonRun struct {
 }
 
 type jsonPinningDependenciesData struct {
-	Dependencies []jsonDependency `json:"dependencies"`
+	Dependencies []jsonDependency         `json:"dependencies"`
+	Manifests    []jsonDependencyManifest `json:"manifests,omitempty"`
+	// PinVerifications lists the dependencies pinned by hash that failed verification.
+	PinVerifications []jsonPinVerification `json:"pinVerifications,omitempty"`
+}
+
+type jsonPinVerification struct {
+	Dependency jsonDependency `json:"dependency"`
+	Issue      string         `json:"issue"`
+	Version    string         `json:"version,omitempty"`
+}
+
+type jsonDependencyManifest struct {
+	File      jsonFile  `json:"file"`
+	Lockfile  *jsonFile `json:"lockfile,omitempty"`
+	Ecosystem string    `json:"ecosystem"`
 }
 
 type jsonDependency struct {
@@ -241,6 +342,16 @@ type jsonSecurityFile struct {
 	ContentLength uint                     `json:"contentLength,omitempty"`
 }
 
+type jsonSecurityAdvisory struct {
+	CreatedAt         time.Time `json:"createdAt"`
+	PublishedAt       time.Time `json:"publishedAt"`
+	ID                string    `json:"id"`
+	CVE               string    `json:"cve,omitempty"`
+	Severity          string    `json:"severity,omitempty"`
+	URL               string    `json:"url,omitempty"`
+	PrivatelyReported bool      `json:"privatelyReported"`
+}
+
 type jsonSecurityPolicyHits struct {
 	Type       string `json:"type"`
 	Match      string `json:"match,omitempty"`
@@ -265,8 +376,12 @@ type jsonRawResults struct {
 	// List of binaries found in the repo.
 	Binaries []jsonFile `json:"binaries"`
 	// List of security policy files found in the repo.
-	// Note: we return one at most.
+	// Note: we return one security policy document and one security.txt file at most.
 	SecurityPolicies []jsonSecurityFile `json:"securityPolicies"`
+	// Published security advisories, unset when they could not be listed.
+	SecurityAdvisories []jsonSecurityAdvisory `json:"securityAdvisories,omitempty"`
+	// Private vulnerability reporting, unset when the setting could not be read.
+	PrivateVulnerabilityReporting *bool `json:"privateVulnerabilityReporting,omitempty"`
 	// List of update tools.
 	// Note: we return one at most.
 	DependencyUpdateTools []jsonTool `json:"dependencyUpdateTools"`
@@ -276,8 +391,20 @@ type jsonRawResults struct {
 	// However, it's harder to get statistics using commit list, so we have a dedicated
 	// structure for it.
 	Contributors jsonContributors `json:"Contributors"`
+	// Maintainers who recently committed or merged changes.
+	// Only present when the experimental Bus-Factor check is run.
+	BusFactor *jsonBusFactor `json:"busFactor,omitempty"`
+	// Security settings of the repository, unset when they could not be read.
+	// Only present when the experimental Repository-Settings check is run.
+	RepositorySettings *jsonRepositorySettings `json:"repositorySettings,omitempty"`
+	// Potential secrets found in the repository files, without their values.
+	// Only present when the experimental Committed-Secrets check is run.
+	CommittedSecrets []jsonSecret `json:"committedSecrets,omitempty"`
 	// Commits.
 	DefaultBranchChangesets []jsonDefaultBranchChangeset `json:"defaultBranchChangesets"`
+	// Categories of the successful CI runs of recently merged pull requests.
+	// Only present when the CI-Tests check is run.
+	CITests []jsonCIRevision `json:"ciTests,omitempty"`
 	// Archived status of the repo.
 	ArchivedStatus jsonArchivedStatus `json:"archived"`
 	// Repo creation time
@@ -290,6 +417,8 @@ type jsonRawResults struct {
 	Packages []jsonPackage `json:"packages"`
 	// Dependency pinning.
 	DependencyPinning jsonPinningDependenciesData `json:"dependencyPinning"`
+	// SAST tools and the pull requests they analyzed.
+	SAST *jsonSAST `json:"sast,omitempty"`
 }
 
 func asPointer(s string) *string {
@@ -364,6 +493,12 @@ func (r *jsonScorecardRawResult) addPackagingRawResults(pk *checker.PackagingDat
 			jpk.File.Snippet = asPointer(p.File.Snippet)
 		}
 
+		if p.Name != nil && *p.Name != "" {
+			jpk.Name = p.Name
+		}
+		jpk.Registry = p.Registry
+		jpk.Auth = string(p.Auth)
+
 		for _, run := range p.Runs {
 			jpk.Runs = append(jpk.Runs,
 				jsonRun{
@@ -403,6 +538,43 @@ func (r *jsonScorecardRawResult) addDependencyPinningRawResults(pd *checker.Pinn
 
 		r.Results.DependencyPinning.Dependencies = append(r.Results.DependencyPinning.Dependencies, v)
 	}
+
+	for i := range pd.PinVerifications {
+		pv := pd.PinVerifications[i]
+		if pv.Dependency.Location == nil {
+			continue
+		}
+		v := jsonPinVerification{
+			Dependency: jsonDependency{
+				Location: &jsonFile{
+					Path:      pv.Dependency.Location.Path,
+					Offset:    pv.Dependency.Location.Offset,
+					EndOffset: pv.Dependency.Location.EndOffset,
+				},
+				Name:     pv.Dependency.Name,
+				PinnedAt: pv.Dependency.PinnedAt,
+				Type:     string(pv.Dependency.Type),
+			},
+			Issue:   string(pv.Issue),
+			Version: pv.Version,
+		}
+		if pv.Dependency.Location.Snippet != "" {
+			v.Dependency.Location.Snippet = &pv.Dependency.Location.Snippet
+		}
+		r.Results.DependencyPinning.PinVerifications = append(r.Results.DependencyPinning.PinVerifications, v)
+	}
+
+	for i := range pd.Manifests {
+		m := pd.Manifests[i]
+		v := jsonDependencyManifest{
+			File:      jsonFile{Path: m.File.Path},
+			Ecosystem: m.Ecosystem,
+		}
+		if m.Lockfile != nil {
+			v.Lockfile = &jsonFile{Path: m.Lockfile.Path}
+		}
+		r.Results.DependencyPinning.Manifests = append(r.Results.DependencyPinning.Manifests, v)
+	}
 	return nil
 }
 
@@ -466,6 +638,142 @@ func (r *jsonScorecardRawResult) addContributorsRawResults(cr *checker.Contribut
 	return nil
 }
 
+//nolint:unparam
+func (r *jsonScorecardRawResult) addBusFactorRawResults(bf *checker.BusFactorData) error {
+	// The check did not run.
+	if bf.LookbackDate.IsZero() {
+		return nil
+	}
+
+	r.Results.BusFactor = &jsonBusFactor{
+		LookbackDate: bf.LookbackDate,
+		NumCommits:   bf.NumCommits,
+		Maintainers:  []jsonMaintainer{},
+	}
+	for i := range bf.Maintainers {
+		m := &bf.Maintainers[i]
+		u := jsonUser{
+			Login: m.User.Login,
+		}
+		for _, org := range m.User.Organizations {
+			u.Organizations = append(u.Organizations,
+				jsonOrganization{
+					Login: org.Login,
+				},
+			)
+		}
+		for _, comp := range m.User.Companies {
+			u.Companies = append(u.Companies,
+				jsonCompany{
+					Name: comp,
+				},
+			)
+		}
+		r.Results.BusFactor.Maintainers = append(r.Results.BusFactor.Maintainers, jsonMaintainer{
+			User:       u,
+			NumCommits: m.NumCommits,
+			NumMerges:  m.NumMerges,
+		})
+	}
+
+	return nil
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addSASTRawResults(sd *checker.SASTData) error {
+	// The check did not run.
+	if sd.Workflows == nil {
+		return nil
+	}
+
+	r.Results.SAST = &jsonSAST{
+		Workflows: []jsonSASTWorkflow{},
+		Commits:   []jsonSASTCommit{},
+		Languages: languagesToJSON(sd.Languages),
+	}
+	for i := range sd.Workflows {
+		w := &sd.Workflows[i]
+		jw := jsonSASTWorkflow{
+			Type: string(w.Type),
+			Tool: w.Tool,
+			File: jsonFile{
+				Path:      w.File.Path,
+				Offset:    w.File.Offset,
+				EndOffset: w.File.EndOffset,
+			},
+			Triggers:  []string{},
+			Languages: languagesToJSON(w.Languages),
+		}
+		if w.File.Snippet != "" {
+			jw.File.Snippet = asPointer(w.File.Snippet)
+		}
+		for _, t := range w.Triggers {
+			jw.Triggers = append(jw.Triggers, string(t))
+		}
+		r.Results.SAST.Workflows = append(r.Results.SAST.Workflows, jw)
+	}
+	for i := range sd.Commits {
+		c := &sd.Commits[i]
+		jc := jsonSASTCommit{
+			HeadSHA:           c.HeadSHA,
+			PullRequestNumber: c.PullRequestNumber,
+		}
+		if c.Tool != "" {
+			jc.Tool = asPointer(c.Tool)
+			jc.URL = asPointer(c.URL)
+		}
+		r.Results.SAST.Commits = append(r.Results.SAST.Commits, jc)
+	}
+
+	return nil
+}
+
+func languagesToJSON(languages []clients.LanguageName) []string {
+	if languages == nil {
+		return nil
+	}
+	ret := []string{}
+	for _, l := range languages {
+		ret = append(ret, string(l))
+	}
+	return ret
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addRepositorySettingsRawResults(rs *checker.RepositorySettingsData) error {
+	// The check did not run, or no setting could be read.
+	if rs.Settings == (clients.SecuritySettings{}) {
+		return nil
+	}
+
+	r.Results.RepositorySettings = &jsonRepositorySettings{
+		SecretScanning:                rs.Settings.SecretScanning,
+		SecretScanningPushProtection:  rs.Settings.SecretScanningPushProtection,
+		DependabotAlerts:              rs.Settings.DependabotAlerts,
+		DependabotSecurityUpdates:     rs.Settings.DependabotSecurityUpdates,
+		PrivateVulnerabilityReporting: rs.Settings.PrivateVulnerabilityReporting,
+		ForkingAllowed:                rs.Settings.ForkingAllowed,
+	}
+	return nil
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addCommittedSecretsRawResults(cs *checker.CommittedSecretsData) error {
+	for i := range cs.Secrets {
+		s := &cs.Secrets[i]
+		r.Results.CommittedSecrets = append(r.Results.CommittedSecrets, jsonSecret{
+			Rule:        s.Rule,
+			Allowlisted: s.Allowlisted,
+			Reason:      s.Reason,
+			File: jsonFile{
+				Path:   s.File.Path,
+				Offset: s.File.Offset,
+			},
+		})
+	}
+	return nil
+}
+
 //nolint:unparam
 func (r *jsonScorecardRawResult) addSignedReleasesRawResults(sr *checker.SignedReleasesData) error {
 	r.Results.Releases = []jsonRelease{}
@@ -594,6 +902,23 @@ func (r *jsonScorecardRawResult) addCodeReviewRawResults(cr *checker.CodeReviewD
 	return r.setDefaultCommitData(cr.DefaultBranchChangesets)
 }
 
+//nolint:unparam
+func (r *jsonScorecardRawResult) addCITestsRawResults(ct *checker.CITestData) error {
+	for i := range ct.CIInfo {
+		info := &ct.CIInfo[i]
+		categories := info.Categories
+		if categories == nil {
+			categories = []string{}
+		}
+		r.Results.CITests = append(r.Results.CITests, jsonCIRevision{
+			HeadSHA:           info.HeadSHA,
+			Categories:        categories,
+			PullRequestNumber: info.PullRequestNumber,
+		})
+	}
+	return nil
+}
+
 //nolint:unparam
 func (r *jsonScorecardRawResult) addLicenseRawResults(ld *checker.LicenseData) error {
 	r.Results.Licenses = []jsonLicense{}
@@ -656,6 +981,19 @@ func (r *jsonScorecardRawResult) addSecurityPolicyRawResults(sp *checker.Securit
 			}
 		}
 	}
+	for i := range sp.Advisories {
+		a := &sp.Advisories[i]
+		r.Results.SecurityAdvisories = append(r.Results.SecurityAdvisories, jsonSecurityAdvisory{
+			CreatedAt:         a.CreatedAt,
+			PublishedAt:       a.PublishedAt,
+			ID:                a.ID,
+			CVE:               a.CVE,
+			Severity:          a.Severity,
+			URL:               a.URL,
+			PrivatelyReported: a.PrivatelyReported,
+		})
+	}
+	r.Results.PrivateVulnerabilityReporting = sp.PrivateVulnerabilityReporting
 	return nil
 }
 
@@ -731,9 +1069,47 @@ func (r *jsonScorecardRawResult) addBranchProtectionRawResults(bp *checker.Branc
 
 	r.Results.BranchProtections.CodeownersFiles = bp.CodeownersFiles
 
+	for i := range bp.Codeowners {
+		f := &bp.Codeowners[i]
+		jf := jsonCodeownersFile{
+			Path:           f.File.Path,
+			Rules:          []jsonCodeownersRule{},
+			SensitivePaths: []jsonCodeownersCoverage{},
+		}
+		for j := range f.Rules {
+			rule := &f.Rules[j]
+			jf.Rules = append(jf.Rules, jsonCodeownersRule{
+				Pattern: rule.Pattern,
+				Section: rule.Section,
+				Line:    rule.Line,
+				Owners:  codeownersToJSON(rule.Owners),
+			})
+		}
+		for _, sp := range f.SensitivePaths {
+			jf.SensitivePaths = append(jf.SensitivePaths, jsonCodeownersCoverage{
+				Path:   sp.Path,
+				Type:   string(sp.Type),
+				Owners: codeownersToJSON(sp.Owners),
+			})
+		}
+		r.Results.BranchProtections.Codeowners = append(r.Results.BranchProtections.Codeowners, jf)
+	}
+
 	return nil
 }
 
+func codeownersToJSON(owners []checker.Codeowner) []jsonCodeowner {
+	ret := []jsonCodeowner{}
+	for i := range owners {
+		ret = append(ret, jsonCodeowner{
+			Name:     owners[i].Name,
+			Type:     string(owners[i].Type),
+			Resolved: owners[i].Resolved,
+		})
+	}
+	return ret
+}
+
 func (r *jsonScorecardRawResult) fillJSONRawResults(raw *checker.RawResults) error {
 	// Licenses.
 	if err := r.addLicenseRawResults(&raw.LicenseResults); err != nil {
@@ -765,6 +1141,11 @@ func (r *jsonScorecardRawResult) fillJSONRawResults(raw *checker.RawResults) err
 		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 	}
 
+	// CI-Tests.
+	if err := r.addCITestsRawResults(&raw.CITestResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
 	// Code-Review.
 	if err := r.addCodeReviewRawResults(&raw.CodeReviewResults); err != nil {
 		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
@@ -785,6 +1166,26 @@ func (r *jsonScorecardRawResult) fillJSONRawResults(raw *checker.RawResults) err
 		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 	}
 
+	// Bus-Factor.
+	if err := r.addBusFactorRawResults(&raw.BusFactorResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// Repository-Settings.
+	if err := r.addRepositorySettingsRawResults(&raw.RepositorySettingsResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// Committed-Secrets.
+	if err := r.addCommittedSecretsRawResults(&raw.CommittedSecretsResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// SAST.
+	if err := r.addSASTRawResults(&raw.SASTResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
 	// DependencyPinning.
 	if err := r.addDependencyPinningRawResults(&raw.PinningDependenciesResults); err != nil {
 		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
@@ -373,6 +373,200 @@ func TestJsonScorecardRawResult_AddContributorsRawResults(t *testing.T) {
 	}
 }
 
+func TestJsonScorecardRawResult_AddBusFactorRawResults(t *testing.T) {
+	t.Parallel()
+
+	lookback := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct { //nolint:govet
+		name     string
+		input    *checker.BusFactorData
+		expected *jsonBusFactor
+	}{
+		{
+			name:     "test_check_not_run",
+			input:    &checker.BusFactorData{},
+			expected: nil,
+		},
+		{
+			name: "test_with_valid_data",
+			input: &checker.BusFactorData{
+				LookbackDate: lookback,
+				NumCommits:   3,
+				Maintainers: []checker.Maintainer{
+					{
+						User: clients.User{
+							Login: "testLogin",
+							Organizations: []clients.User{
+								{Login: "testOrg"},
+							},
+							Companies: []string{"testCompany"},
+						},
+						NumCommits: 2,
+						NumMerges:  1,
+					},
+				},
+			},
+			expected: &jsonBusFactor{
+				LookbackDate: lookback,
+				NumCommits:   3,
+				Maintainers: []jsonMaintainer{
+					{
+						User: jsonUser{
+							Login:         "testLogin",
+							Organizations: []jsonOrganization{{Login: "testOrg"}},
+							Companies:     []jsonCompany{{Name: "testCompany"}},
+						},
+						NumCommits: 2,
+						NumMerges:  1,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &jsonScorecardRawResult{}
+			if err := r.addBusFactorRawResults(test.input); err != nil {
+				t.Errorf("addBusFactorRawResults() error = %v", err)
+			}
+			if diff := cmp.Diff(test.expected, r.Results.BusFactor); diff != "" {
+				t.Errorf("addBusFactorRawResults() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJsonScorecardRawResult_AddRepositorySettingsRawResults(t *testing.T) {
+	t.Parallel()
+
+	enabled, disabled := true, false
+	tests := []struct { //nolint:govet
+		name     string
+		input    *checker.RepositorySettingsData
+		expected *jsonRepositorySettings
+	}{
+		{
+			name:     "test_check_not_run",
+			input:    &checker.RepositorySettingsData{},
+			expected: nil,
+		},
+		{
+			name: "test_with_valid_data",
+			input: &checker.RepositorySettingsData{
+				Settings: clients.SecuritySettings{
+					SecretScanning:                &enabled,
+					PrivateVulnerabilityReporting: &disabled,
+				},
+			},
+			expected: &jsonRepositorySettings{
+				SecretScanning:                &enabled,
+				PrivateVulnerabilityReporting: &disabled,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &jsonScorecardRawResult{}
+			if err := r.addRepositorySettingsRawResults(test.input); err != nil {
+				t.Errorf("addRepositorySettingsRawResults() error = %v", err)
+			}
+			if diff := cmp.Diff(test.expected, r.Results.RepositorySettings); diff != "" {
+				t.Errorf("addRepositorySettingsRawResults() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJsonScorecardRawResult_AddCITestsRawResults(t *testing.T) {
+	t.Parallel()
+
+	ct := &checker.CITestData{
+		CIInfo: []checker.RevisionCIInfo{
+			{HeadSHA: "sha1", PullRequestNumber: 1, Categories: []string{"lint", "tests"}},
+			{HeadSHA: "sha2", PullRequestNumber: 2},
+		},
+	}
+	r := &jsonScorecardRawResult{}
+	if err := r.addCITestsRawResults(ct); err != nil {
+		t.Fatalf("addCITestsRawResults() error = %v", err)
+	}
+	expected := []jsonCIRevision{
+		{HeadSHA: "sha1", PullRequestNumber: 1, Categories: []string{"lint", "tests"}},
+		{HeadSHA: "sha2", PullRequestNumber: 2, Categories: []string{}},
+	}
+	if diff := cmp.Diff(expected, r.Results.CITests); diff != "" {
+		t.Errorf("addCITestsRawResults() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJsonScorecardRawResult_AddCommittedSecretsRawResults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct { //nolint:govet
+		name     string
+		input    *checker.CommittedSecretsData
+		expected []jsonSecret
+	}{
+		{
+			name:     "test_no_secrets",
+			input:    &checker.CommittedSecretsData{},
+			expected: nil,
+		},
+		{
+			name: "test_with_valid_data",
+			input: &checker.CommittedSecretsData{
+				Secrets: []checker.Secret{
+					{
+						Rule: "aws-access-key-id",
+						File: checker.File{Path: "deploy.sh", Offset: 3},
+					},
+					{
+						Rule:        "private-key",
+						Reason:      "test key",
+						Allowlisted: true,
+						File:        checker.File{Path: "examples/key.pem", Offset: 1},
+					},
+				},
+			},
+			expected: []jsonSecret{
+				{
+					Rule: "aws-access-key-id",
+					File: jsonFile{Path: "deploy.sh", Offset: 3},
+				},
+				{
+					Rule:        "private-key",
+					Reason:      "test key",
+					Allowlisted: true,
+					File:        jsonFile{Path: "examples/key.pem", Offset: 1},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &jsonScorecardRawResult{}
+			if err := r.addCommittedSecretsRawResults(test.input); err != nil {
+				t.Errorf("addCommittedSecretsRawResults() error = %v", err)
+			}
+			if diff := cmp.Diff(test.expected, r.Results.CommittedSecrets); diff != "" {
+				t.Errorf("addCommittedSecretsRawResults() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestJsonScorecardRawResult_AddSignedReleasesRawResults(t *testing.T) {
 	t.Parallel()
 
@@ -916,6 +1110,48 @@ func TestAddSecurityPolicyRawResults(t *testing.T) {
 	}
 }
 
+func TestAddSecurityPolicyRawResults_Advisories(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	published := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	sp := &checker.SecurityPolicyData{
+		Advisories: []clients.SecurityAdvisory{
+			{
+				CreatedAt:         published.AddDate(0, -1, 0),
+				PublishedAt:       published,
+				ID:                "GHSA-xxxx-xxxx-xxxx",
+				CVE:               "CVE-2023-0001",
+				Severity:          "high",
+				URL:               "https://github.com/owner/repo/security/advisories/GHSA-xxxx-xxxx-xxxx",
+				PrivatelyReported: true,
+			},
+		},
+		PrivateVulnerabilityReporting: &enabled,
+	}
+	r := &jsonScorecardRawResult{}
+	if err := r.addSecurityPolicyRawResults(sp); err != nil {
+		t.Fatalf("addSecurityPolicyRawResults returned an error: %v", err)
+	}
+	expected := []jsonSecurityAdvisory{
+		{
+			CreatedAt:         published.AddDate(0, -1, 0),
+			PublishedAt:       published,
+			ID:                "GHSA-xxxx-xxxx-xxxx",
+			CVE:               "CVE-2023-0001",
+			Severity:          "high",
+			URL:               "https://github.com/owner/repo/security/advisories/GHSA-xxxx-xxxx-xxxx",
+			PrivatelyReported: true,
+		},
+	}
+	if diff := cmp.Diff(expected, r.Results.SecurityAdvisories); diff != "" {
+		t.Errorf("addSecurityPolicyRawResults() advisories mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&enabled, r.Results.PrivateVulnerabilityReporting); diff != "" {
+		t.Errorf("addSecurityPolicyRawResults() private vulnerability reporting mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestAddVulnerabilitiesRawResults(t *testing.T) {
 	r := &jsonScorecardRawResult{}
 	vd := &checker.VulnerabilitiesData{
@@ -413,6 +413,50 @@ func TestJSONOutput(t *testing.T) {
 				Metadata: []string{},
 			},
 		},
+		{
+			name:        "repository link",
+			showDetails: true,
+			expected:    "./testdata/check7.json",
+			logLevel:    log.WarnLevel,
+			result: ScorecardResult{
+				Repo: RepoInfo{
+					Name:      repoName,
+					CommitSHA: repoCommit,
+				},
+				Scorecard: ScorecardInfo{
+					Version:   scorecardVersion,
+					CommitSHA: scorecardCommit,
+				},
+				Date: date,
+				Checks: []checker.CheckResult{
+					{
+						Details: []checker.CheckDetail{
+							{
+								Type: checker.DetailWarn,
+								Msg: checker.LogMessage{
+									Text: "warn message",
+									Path: "https://domain.com/something",
+									Type: finding.FileTypeURL,
+								},
+							},
+						},
+						Score:  6,
+						Reason: "six score reason",
+						Name:   "Check-Name",
+					},
+				},
+				Metadata: []string{},
+				RepositoryLink: &RepositoryLink{
+					Ecosystem:  "npm",
+					Package:    "name",
+					Version:    "1.0.0",
+					Confidence: RepositoryLinkHigh,
+					Reasons: []string{
+						"npm provenance attestation of name@1.0.0 was built from https://github.com/org/name",
+					},
+				},
+			},
+		},
 	}
 
 	// Load the JSON schema.
@@ -0,0 +1,39 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+// RepositoryLinkConfidence is how confidently a package is linked to the repository it was resolved to.
+type RepositoryLinkConfidence string
+
+const (
+	// RepositoryLinkHigh is set when a provenance attestation of the package names the repository.
+	RepositoryLinkHigh RepositoryLinkConfidence = "high"
+	// RepositoryLinkMedium is set when a release of the repository matches the version of the package.
+	RepositoryLinkMedium RepositoryLinkConfidence = "medium"
+	// RepositoryLinkLow is set when only the metadata of the package declares the repository.
+	RepositoryLinkLow RepositoryLinkConfidence = "low"
+	// RepositoryLinkNone is set when the package was built from another repository.
+	RepositoryLinkNone RepositoryLinkConfidence = "none"
+)
+
+// RepositoryLink describes how the package scored with `--npm`, `--pypi`, etc.
+// was linked to its source repository.
+type RepositoryLink struct {
+	Ecosystem  string
+	Package    string
+	Version    string
+	Confidence RepositoryLinkConfidence
+	Reasons    []string
+}
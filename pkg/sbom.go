@@ -0,0 +1,62 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/docs/checks"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/lockfile"
+	"github.com/ossf/scorecard/v4/sbom"
+)
+
+// AsSBOM exports results as the properties of the repository component of a CycloneDX SBOM,
+// or as the annotations of the repository package of an SPDX SBOM.
+func (r *ScorecardResult) AsSBOM(format sbom.Format, checkDocs checks.Doc, writer io.Writer) error {
+	score, err := r.GetAggregateScore(checkDocs)
+	if err != nil {
+		return err
+	}
+	s, err := sbom.New(format, r.Repo.Name, r.Repo.CommitSHA, &lockfile.Graph{})
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("sbom.New: %v", err))
+	}
+
+	properties := []sbom.Property{
+		{Name: sbom.PropertyRepository, Value: r.Repo.Name},
+		{Name: sbom.PropertyCommit, Value: r.Repo.CommitSHA},
+		{Name: sbom.PropertyDate, Value: r.Date.Format(time.RFC3339)},
+		{Name: sbom.PropertyVersion, Value: r.Scorecard.Version},
+		{Name: sbom.PropertyScore, Value: scoreToString(score)},
+	}
+	for i := range r.Checks {
+		value := "?"
+		if r.Checks[i].Score != checker.InconclusiveResultScore {
+			value = strconv.Itoa(r.Checks[i].Score)
+		}
+		properties = append(properties, sbom.Property{Name: sbom.PropertyCheckPrefix + r.Checks[i].Name, Value: value})
+	}
+	s.AnnotateRoot(properties)
+
+	if err := s.Write(writer); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Write: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/sbom"
+)
+
+func TestAsSBOM(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Repo: RepoInfo{
+			Name:      "github.com/org/name",
+			CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+		},
+		Scorecard: ScorecardInfo{Version: "1.2.3"},
+		Date:      time.Date(2023, 3, 2, 16, 30, 43, 0, time.UTC),
+		Checks: []checker.CheckResult{
+			{Name: "Check-Name", Score: 5},
+			{Name: "Check-Name2", Score: checker.InconclusiveResultScore},
+		},
+	}
+	tests := []struct {
+		format sbom.Format
+		want   []string
+	}{
+		{
+			format: sbom.FormatCycloneDX,
+			want: []string{
+				`"name": "github.com/org/name",
+      "version": "68bc59901773ab4c051dfcea0cc4201a1567ab32"`,
+				`"name": "ossf:scorecard:score",
+          "value": "5.0"`,
+				`"name": "ossf:scorecard:check:Check-Name",
+          "value": "5"`,
+				`"name": "ossf:scorecard:check:Check-Name2",
+          "value": "?"`,
+				`"name": "ossf:scorecard:date",
+          "value": "2023-03-02T16:30:43Z"`,
+			},
+		},
+		{
+			format: sbom.FormatSPDX,
+			want: []string{
+				`"name": "github.com/org/name"`,
+				`"comment": "ossf:scorecard:score=5.0"`,
+				`"comment": "ossf:scorecard:check:Check-Name=5"`,
+				`"comment": "ossf:scorecard:check:Check-Name2=?"`,
+				`"comment": "ossf:scorecard:version=1.2.3"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.format), func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			if err := result.AsSBOM(tt.format, jsonMockDocRead(), &out); err != nil {
+				t.Fatalf("AsSBOM() failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("AsSBOM() output does not contain %s:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
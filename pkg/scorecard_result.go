@@ -29,6 +29,7 @@ import (
 	"github.com/ossf/scorecard/v4/log"
 	"github.com/ossf/scorecard/v4/options"
 	spol "github.com/ossf/scorecard/v4/policy"
+	"github.com/ossf/scorecard/v4/sbom"
 )
 
 // ScorecardInfo contains information about the scorecard code that was run.
@@ -46,13 +47,14 @@ type RepoInfo struct {
 // ScorecardResult struct is returned on a successful Scorecard run.
 // nolint
 type ScorecardResult struct {
-	Repo       RepoInfo
-	Date       time.Time
-	Scorecard  ScorecardInfo
-	Checks     []checker.CheckResult
-	RawResults checker.RawResults
-	Findings   []finding.Finding
-	Metadata   []string
+	Repo           RepoInfo
+	Date           time.Time
+	Scorecard      ScorecardInfo
+	Checks         []checker.CheckResult
+	RawResults     checker.RawResults
+	Findings       []finding.Finding
+	Metadata       []string
+	RepositoryLink *RepositoryLink
 }
 
 func scoreToString(s float64) string {
@@ -125,6 +127,10 @@ func FormatResults(
 		err = results.AsPJSON(os.Stdout)
 	case options.FormatRaw:
 		err = results.AsRawJSON(os.Stdout)
+	case options.FormatCycloneDX:
+		err = results.AsSBOM(sbom.FormatCycloneDX, doc, os.Stdout)
+	case options.FormatSPDX:
+		err = results.AsSBOM(sbom.FormatSPDX, doc, os.Stdout)
 	default:
 		err = sce.WithMessage(
 			sce.ErrScorecardInternal,
@@ -196,6 +202,15 @@ func (r *ScorecardResult) AsString(showDetails bool, logLevel log.Level,
 		s = "Aggregate score: ?\n\n"
 	}
 	fmt.Fprint(os.Stdout, s)
+	if r.RepositoryLink != nil {
+		link := r.RepositoryLink
+		fmt.Fprintf(os.Stdout, "Repository link confidence for %s package %s: %s\n",
+			link.Ecosystem, link.Package, link.Confidence)
+		for _, reason := range link.Reasons {
+			fmt.Fprintf(os.Stdout, "  - %s\n", reason)
+		}
+		fmt.Fprintln(os.Stdout)
+	}
 	fmt.Fprintln(os.Stdout, "Check scores:")
 
 	table := tablewriter.NewWriter(os.Stdout)
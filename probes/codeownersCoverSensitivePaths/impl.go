@@ -0,0 +1,97 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package codeownersCoverSensitivePaths
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "codeownersCoverSensitivePaths"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+
+	codeowners := raw.BranchProtectionResults.Codeowners
+	if len(codeowners) == 0 {
+		f, err := finding.NewNegative(fs, Probe, "no CODEOWNERS file detected", nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	// A path is covered if any CODEOWNERS file assigns it a valid owner.
+	var paths []checker.CodeownersCoverage
+	covered := make(map[string]bool)
+	for i := range codeowners {
+		for _, s := range codeowners[i].SensitivePaths {
+			if _, seen := covered[s.Path]; !seen {
+				paths = append(paths, s)
+				covered[s.Path] = false
+			}
+			if hasValidOwner(s.Owners) {
+				covered[s.Path] = true
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		f, err := finding.NewNotAvailable(fs, Probe, "no sensitive file detected", nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	var findings []finding.Finding
+	for i := range paths {
+		loc := &finding.Location{
+			Type: finding.FileTypeSource,
+			Path: paths[i].Path,
+		}
+		outcome := finding.OutcomeNegative
+		text := fmt.Sprintf("%s file has no codeowner", paths[i].Type)
+		if covered[paths[i].Path] {
+			outcome = finding.OutcomePositive
+			text = fmt.Sprintf("%s file has a codeowner", paths[i].Type)
+		}
+		f, err := finding.NewWith(fs, Probe, text, loc, outcome)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		findings = append(findings, *f)
+	}
+	return findings, Probe, nil
+}
+
+func hasValidOwner(owners []checker.Codeowner) bool {
+	for i := range owners {
+		if owners[i].Resolved == nil || *owners[i].Resolved {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package codeownersOwnersResolved
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "codeownersOwnersResolved"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+
+	codeowners := raw.BranchProtectionResults.Codeowners
+	if len(codeowners) == 0 {
+		f, err := finding.NewNotAvailable(fs, Probe, "no CODEOWNERS file detected", nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	var findings []finding.Finding
+	verified := false
+	for i := range codeowners {
+		file := &codeowners[i].File
+		for j := range codeowners[i].Rules {
+			rule := &codeowners[i].Rules[j]
+			for k := range rule.Owners {
+				owner := &rule.Owners[k]
+				if owner.Resolved == nil {
+					continue
+				}
+				verified = true
+				if *owner.Resolved {
+					continue
+				}
+				line := rule.Line
+				loc := &finding.Location{
+					Type:      file.Type,
+					Path:      file.Path,
+					LineStart: &line,
+				}
+				text := fmt.Sprintf("owner '%s' cannot be resolved", owner.Name)
+				if owner.Type == checker.CodeownerTypeInvalid {
+					text = fmt.Sprintf("owner '%s' is invalid", owner.Name)
+				}
+				f, err := finding.NewNegative(fs, Probe, text, loc)
+				if err != nil {
+					return nil, Probe, fmt.Errorf("create finding: %w", err)
+				}
+				findings = append(findings, *f)
+			}
+		}
+	}
+
+	if len(findings) > 0 {
+		return findings, Probe, nil
+	}
+
+	if !verified {
+		f, err := finding.NewNotAvailable(fs, Probe, "CODEOWNERS owners cannot be verified", nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	f, err := finding.NewPositive(fs, Probe, "all CODEOWNERS owners are resolved", nil)
+	if err != nil {
+		return nil, Probe, fmt.Errorf("create finding: %w", err)
+	}
+	return []finding.Finding{*f}, Probe, nil
+}
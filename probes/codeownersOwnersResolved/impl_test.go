@@ -0,0 +1,160 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package codeownersOwnersResolved
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+func Test_Run(t *testing.T) {
+	t.Parallel()
+	resolved, unresolved := true, false
+	// nolint:govet
+	tests := []struct {
+		name     string
+		raw      *checker.RawResults
+		outcomes []finding.Outcome
+		err      error
+	}{
+		{
+			name: "all owners resolved",
+			raw: &checker.RawResults{
+				BranchProtectionResults: checker.BranchProtectionsData{
+					Codeowners: []checker.CodeownersFile{
+						{
+							File: checker.File{Path: "CODEOWNERS"},
+							Rules: []checker.CodeownersRule{
+								{
+									Pattern: "*",
+									Line:    1,
+									Owners: []checker.Codeowner{
+										{Name: "@alice", Type: checker.CodeownerTypeUser, Resolved: &resolved},
+										{Name: "@org/team", Type: checker.CodeownerTypeTeam, Resolved: &resolved},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			outcomes: []finding.Outcome{
+				finding.OutcomePositive,
+			},
+		},
+		{
+			name: "invalid and unresolved owners",
+			raw: &checker.RawResults{
+				BranchProtectionResults: checker.BranchProtectionsData{
+					Codeowners: []checker.CodeownersFile{
+						{
+							File: checker.File{Path: "CODEOWNERS"},
+							Rules: []checker.CodeownersRule{
+								{
+									Pattern: "*",
+									Line:    1,
+									Owners: []checker.Codeowner{
+										{Name: "@alice", Type: checker.CodeownerTypeUser, Resolved: &resolved},
+										{Name: "@ghost", Type: checker.CodeownerTypeUser, Resolved: &unresolved},
+									},
+								},
+								{
+									Pattern: "/docs/",
+									Line:    2,
+									Owners: []checker.Codeowner{
+										{Name: "bob", Type: checker.CodeownerTypeInvalid, Resolved: &unresolved},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			outcomes: []finding.Outcome{
+				finding.OutcomeNegative,
+				finding.OutcomeNegative,
+			},
+		},
+		{
+			name: "owners not verified",
+			raw: &checker.RawResults{
+				BranchProtectionResults: checker.BranchProtectionsData{
+					Codeowners: []checker.CodeownersFile{
+						{
+							File: checker.File{Path: ".gitlab/CODEOWNERS"},
+							Rules: []checker.CodeownersRule{
+								{
+									Pattern: "*",
+									Line:    1,
+									Owners: []checker.Codeowner{
+										{Name: "@alice", Type: checker.CodeownerTypeUser},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			outcomes: []finding.Outcome{
+				finding.OutcomeNotAvailable,
+			},
+		},
+		{
+			name: "no codeowners file",
+			raw:  &checker.RawResults{},
+			outcomes: []finding.Outcome{
+				finding.OutcomeNotAvailable,
+			},
+		},
+		{
+			name: "nil raw",
+			err:  uerror.ErrNil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			findings, s, err := Run(tt.raw)
+			if !cmp.Equal(tt.err, err, cmpopts.EquateErrors()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(tt.err, err, cmpopts.EquateErrors()))
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(Probe, s); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(len(tt.outcomes), len(findings)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			for i := range tt.outcomes {
+				outcome := &tt.outcomes[i]
+				f := &findings[i]
+				if diff := cmp.Diff(*outcome, f.Outcome); diff != "" {
+					t.Errorf("mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -17,6 +17,12 @@ package probes
 import (
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/codeownersCoverSensitivePaths"
+	"github.com/ossf/scorecard/v4/probes/codeownersOwnersResolved"
+	"github.com/ossf/scorecard/v4/probes/codeownersPresent"
+	"github.com/ossf/scorecard/v4/probes/dependabotAlertsEnabled"
+	"github.com/ossf/scorecard/v4/probes/dependabotSecurityUpdatesEnabled"
+	"github.com/ossf/scorecard/v4/probes/forkingRestricted"
 	"github.com/ossf/scorecard/v4/probes/fuzzedWithClusterFuzzLite"
 	"github.com/ossf/scorecard/v4/probes/fuzzedWithGoNative"
 	"github.com/ossf/scorecard/v4/probes/fuzzedWithOSSFuzz"
@@ -24,14 +30,35 @@ import (
 	"github.com/ossf/scorecard/v4/probes/fuzzedWithPropertyBasedHaskell"
 	"github.com/ossf/scorecard/v4/probes/fuzzedWithPropertyBasedJavascript"
 	"github.com/ossf/scorecard/v4/probes/fuzzedWithPropertyBasedTypescript"
+	"github.com/ossf/scorecard/v4/probes/hasMultipleActiveMaintainers"
+	"github.com/ossf/scorecard/v4/probes/maintainersFromMultipleOrgs"
+	"github.com/ossf/scorecard/v4/probes/noCommittedSecrets"
+	"github.com/ossf/scorecard/v4/probes/privateVulnerabilityReportingEnabled"
+	"github.com/ossf/scorecard/v4/probes/sastToolConfigured"
+	"github.com/ossf/scorecard/v4/probes/sastToolCoversLanguages"
+	"github.com/ossf/scorecard/v4/probes/sastToolRunsOnAllCommits"
+	"github.com/ossf/scorecard/v4/probes/sastToolRunsOnPullRequests"
+	"github.com/ossf/scorecard/v4/probes/secretScanningEnabled"
+	"github.com/ossf/scorecard/v4/probes/secretScanningPushProtectionEnabled"
+	"github.com/ossf/scorecard/v4/probes/securityAdvisoriesHaveCVE"
+	"github.com/ossf/scorecard/v4/probes/securityAdvisoriesPublished"
+	"github.com/ossf/scorecard/v4/probes/securityAdvisoriesPublishedPromptly"
 	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsLinks"
+	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsPrivateReportingChannel"
+	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsResponseTime"
+	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsSupportedVersions"
 	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsText"
 	"github.com/ossf/scorecard/v4/probes/securityPolicyContainsVulnerabilityDisclosure"
 	"github.com/ossf/scorecard/v4/probes/securityPolicyPresent"
+	"github.com/ossf/scorecard/v4/probes/securityPolicyPrivateReportingEnabled"
+	"github.com/ossf/scorecard/v4/probes/securityTxtContainsContact"
+	"github.com/ossf/scorecard/v4/probes/securityTxtNotExpired"
+	"github.com/ossf/scorecard/v4/probes/sonarConfigured"
 	"github.com/ossf/scorecard/v4/probes/toolDependabotInstalled"
 	"github.com/ossf/scorecard/v4/probes/toolPyUpInstalled"
 	"github.com/ossf/scorecard/v4/probes/toolRenovateInstalled"
 	"github.com/ossf/scorecard/v4/probes/toolSonatypeLiftInstalled"
+	"github.com/ossf/scorecard/v4/probes/topContributorCommitShareLow"
 )
 
 // ProbeImpl is the implementation of a probe.
@@ -47,6 +74,15 @@ var (
 		securityPolicyContainsLinks.Run,
 		securityPolicyContainsVulnerabilityDisclosure.Run,
 		securityPolicyContainsText.Run,
+		securityPolicyContainsSupportedVersions.Run,
+		securityPolicyContainsResponseTime.Run,
+		securityPolicyContainsPrivateReportingChannel.Run,
+		securityTxtContainsContact.Run,
+		securityTxtNotExpired.Run,
+		securityAdvisoriesPublished.Run,
+		securityAdvisoriesHaveCVE.Run,
+		securityAdvisoriesPublishedPromptly.Run,
+		securityPolicyPrivateReportingEnabled.Run,
 	}
 	// DependencyToolUpdates is all the probes for the
 	// DpendencyUpdateTool check.
@@ -65,6 +101,44 @@ var (
 		fuzzedWithPropertyBasedTypescript.Run,
 		fuzzedWithPropertyBasedJavascript.Run,
 	}
+	// BusFactor is all the probes for the
+	// Bus-Factor check.
+	BusFactor = []ProbeImpl{
+		hasMultipleActiveMaintainers.Run,
+		topContributorCommitShareLow.Run,
+		maintainersFromMultipleOrgs.Run,
+	}
+	// Codeowners is all the probes for the
+	// CODEOWNERS file, used as an input to Branch-Protection.
+	Codeowners = []ProbeImpl{
+		codeownersPresent.Run,
+		codeownersOwnersResolved.Run,
+		codeownersCoverSensitivePaths.Run,
+	}
+	// SAST is all the probes for the
+	// SAST check.
+	SAST = []ProbeImpl{
+		sastToolRunsOnAllCommits.Run,
+		sastToolConfigured.Run,
+		sonarConfigured.Run,
+		sastToolRunsOnPullRequests.Run,
+		sastToolCoversLanguages.Run,
+	}
+	// RepositorySettings is all the probes for the
+	// Repository-Settings check.
+	RepositorySettings = []ProbeImpl{
+		secretScanningEnabled.Run,
+		secretScanningPushProtectionEnabled.Run,
+		dependabotAlertsEnabled.Run,
+		dependabotSecurityUpdatesEnabled.Run,
+		privateVulnerabilityReportingEnabled.Run,
+		forkingRestricted.Run,
+	}
+	// CommittedSecrets is all the probes for the
+	// Committed-Secrets check.
+	CommittedSecrets = []ProbeImpl{
+		noCommittedSecrets.Run,
+	}
 )
 
 //nolint:gochecknoinits
@@ -73,6 +147,11 @@ func init() {
 		DependencyToolUpdates,
 		SecurityPolicy,
 		Fuzzing,
+		BusFactor,
+		Codeowners,
+		SAST,
+		RepositorySettings,
+		CommittedSecrets,
 	})
 }
 
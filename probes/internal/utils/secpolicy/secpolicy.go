@@ -16,6 +16,8 @@ package secpolicy
 
 import (
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/finding"
 )
 
 func CountSecInfo(secInfo []checker.SecurityPolicyInformation,
@@ -51,3 +53,14 @@ func FindSecInfo(secInfo []checker.SecurityPolicyInformation,
 	}
 	return secList
 }
+
+// AdvisoryLocation returns the location of the advisory page, if known.
+func AdvisoryLocation(advisory *clients.SecurityAdvisory) *finding.Location {
+	if advisory.URL == "" {
+		return nil
+	}
+	return &finding.Location{
+		Type: finding.FileTypeURL,
+		Path: advisory.URL,
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// Run runs the probe for a repository setting.
+// If the setting could not be read, e.g., because the token lacks the scope, it returns
+// a finding with OutcomeNotAvailable. If the setting is enabled, it returns a finding
+// with the 'enabledOutcome'. If not, it returns a finding with the 'disabledOutcome'.
+func Run(setting *bool, fs embed.FS, probeID, name string,
+	enabledOutcome, disabledOutcome finding.Outcome,
+) ([]finding.Finding, string, error) {
+	text := fmt.Sprintf("%s could not be read", name)
+	outcome := finding.OutcomeNotAvailable
+	switch {
+	case setting == nil:
+	case *setting:
+		text = fmt.Sprintf("%s is enabled", name)
+		outcome = enabledOutcome
+	default:
+		text = fmt.Sprintf("%s is disabled", name)
+		outcome = disabledOutcome
+	}
+
+	f, err := finding.NewWith(fs, probeID, text, nil, outcome)
+	if err != nil {
+		return nil, probeID, fmt.Errorf("create finding: %w", err)
+	}
+	return []finding.Finding{*f}, probeID, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package maintainersFromMultipleOrgs
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "maintainersFromMultipleOrgs"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+
+	// shared contains the entities all affiliated maintainers
+	// seen so far belong to. Maintainers without a known
+	// affiliation are skipped.
+	var shared map[string]bool
+	affiliated := 0
+	maintainers := raw.BusFactorResults.Maintainers
+	for i := range maintainers {
+		entities := affiliations(&maintainers[i])
+		if len(entities) == 0 {
+			continue
+		}
+		affiliated++
+		if shared == nil {
+			shared = entities
+			continue
+		}
+		for e := range shared {
+			if !entities[e] {
+				delete(shared, e)
+			}
+		}
+	}
+
+	if affiliated < 2 {
+		f, err := finding.NewNotAvailable(fs, Probe,
+			fmt.Sprintf("organization or company found for %d maintainer(s), need at least 2", affiliated), nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	if len(shared) > 0 {
+		names := make([]string, 0, len(shared))
+		for e := range shared {
+			names = append(names, e)
+		}
+		sort.Strings(names)
+		f, err := finding.NewNegative(fs, Probe,
+			fmt.Sprintf("all affiliated maintainers belong to %s", strings.Join(names, ",")), nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	f, err := finding.NewPositive(fs, Probe,
+		fmt.Sprintf("%d affiliated maintainer(s) do not all belong to the same organization", affiliated), nil)
+	if err != nil {
+		return nil, Probe, fmt.Errorf("create finding: %w", err)
+	}
+	return []finding.Finding{*f}, Probe, nil
+}
+
+func affiliations(m *checker.Maintainer) map[string]bool {
+	entities := make(map[string]bool)
+	for _, org := range m.User.Organizations {
+		entities[org.Login] = true
+	}
+	for _, comp := range m.User.Companies {
+		entities[comp] = true
+	}
+	return entities
+}
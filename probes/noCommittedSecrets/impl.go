@@ -0,0 +1,72 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package noCommittedSecrets
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "noCommittedSecrets"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+
+	secrets := raw.CommittedSecretsResults.Secrets
+	if len(secrets) == 0 {
+		f, err := finding.NewPositive(fs, Probe, "no secret detected", nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	var findings []finding.Finding
+	for i := range secrets {
+		s := &secrets[i]
+		line := s.File.Offset
+		loc := &finding.Location{
+			Type:      s.File.Type,
+			Path:      s.File.Path,
+			LineStart: &line,
+		}
+		// The value of the secret must never be part of the finding.
+		outcome := finding.OutcomeNegative
+		text := fmt.Sprintf("potential secret detected by rule %s", s.Rule)
+		if s.Allowlisted {
+			outcome = finding.OutcomePositive
+			text = fmt.Sprintf("allowlisted secret detected by rule %s", s.Rule)
+			if s.Reason != "" {
+				text = fmt.Sprintf("%s: %s", text, s.Reason)
+			}
+		}
+		f, err := finding.NewWith(fs, Probe, text, loc, outcome)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		findings = append(findings, *f)
+	}
+	return findings, Probe, nil
+}
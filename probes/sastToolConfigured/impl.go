@@ -0,0 +1,79 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package sastToolConfigured
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "sastToolConfigured"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+
+	var findings []finding.Finding
+	workflows := raw.SASTResults.Workflows
+	for i := range workflows {
+		w := &workflows[i]
+		if w.Type != checker.SASTWorkflowCI {
+			continue
+		}
+		line := w.File.Offset
+		loc := &finding.Location{
+			Type:      w.File.Type,
+			Path:      w.File.Path,
+			LineStart: &line,
+		}
+		f, err := finding.NewPositive(fs, Probe,
+			fmt.Sprintf("%s detected, runs on: %s", w.Tool, triggersString(w.Triggers)), loc)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		findings = append(findings, *f)
+	}
+
+	if len(findings) > 0 {
+		return findings, Probe, nil
+	}
+
+	f, err := finding.NewNegative(fs, Probe, "no SAST tool detected in CI configurations", nil)
+	if err != nil {
+		return nil, Probe, fmt.Errorf("create finding: %w", err)
+	}
+	return []finding.Finding{*f}, Probe, nil
+}
+
+func triggersString(triggers []checker.SASTTrigger) string {
+	if len(triggers) == 0 {
+		return "other events"
+	}
+	s := make([]string, len(triggers))
+	for i := range triggers {
+		s[i] = string(triggers[i])
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package sastToolCoversLanguages
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "sastToolCoversLanguages"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+
+	data := &raw.SASTResults
+	configured := false
+	allLanguages := false
+	covered := map[clients.LanguageName]bool{}
+	for i := range data.Workflows {
+		w := &data.Workflows[i]
+		if w.Type != checker.SASTWorkflowCI {
+			continue
+		}
+		configured = true
+		// Tools without a list of languages analyze all of them.
+		if w.Languages == nil {
+			allLanguages = true
+		}
+		for _, l := range w.Languages {
+			covered[l] = true
+		}
+	}
+
+	if !configured {
+		return notAvailable("no SAST tool detected in CI configurations")
+	}
+	if data.Languages == nil {
+		return notAvailable("languages of the repository are unknown")
+	}
+
+	var findings []finding.Finding
+	if !allLanguages {
+		for _, l := range data.Languages {
+			if covered[l] {
+				continue
+			}
+			f, err := finding.NewNegative(fs, Probe, fmt.Sprintf("language not analyzed by a SAST tool: %s", l), nil)
+			if err != nil {
+				return nil, Probe, fmt.Errorf("create finding: %w", err)
+			}
+			findings = append(findings, *f)
+		}
+	}
+	if len(findings) > 0 {
+		return findings, Probe, nil
+	}
+
+	f, err := finding.NewPositive(fs, Probe, "all languages are analyzed by a SAST tool", nil)
+	if err != nil {
+		return nil, Probe, fmt.Errorf("create finding: %w", err)
+	}
+	return []finding.Finding{*f}, Probe, nil
+}
+
+func notAvailable(text string) ([]finding.Finding, string, error) {
+	f, err := finding.NewNotAvailable(fs, Probe, text, nil)
+	if err != nil {
+		return nil, Probe, fmt.Errorf("create finding: %w", err)
+	}
+	return []finding.Finding{*f}, Probe, nil
+}
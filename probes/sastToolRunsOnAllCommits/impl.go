@@ -0,0 +1,68 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package sastToolRunsOnAllCommits
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "sastToolRunsOnAllCommits"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+
+	commits := raw.SASTResults.Commits
+	if len(commits) == 0 {
+		f, err := finding.NewNotAvailable(fs, Probe, "no pull requests merged into dev branch", nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	findings := make([]finding.Finding, 0, len(commits))
+	for i := range commits {
+		commit := &commits[i]
+		var f *finding.Finding
+		var err error
+		if commit.Tool != "" {
+			loc := &finding.Location{
+				Type: finding.FileTypeURL,
+				Path: commit.URL,
+			}
+			f, err = finding.NewPositive(fs, Probe,
+				fmt.Sprintf("merged PR %d checked by %s", commit.PullRequestNumber, commit.Tool), loc)
+		} else {
+			f, err = finding.NewNegative(fs, Probe,
+				fmt.Sprintf("merged PR %d without SAST check at HEAD: %s", commit.PullRequestNumber, commit.HeadSHA), nil)
+		}
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		findings = append(findings, *f)
+	}
+	return findings, Probe, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package sastToolRunsOnPullRequests
+
+import (
+	"embed"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "sastToolRunsOnPullRequests"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+
+	configured := false
+	workflows := raw.SASTResults.Workflows
+	for i := range workflows {
+		w := &workflows[i]
+		if w.Type != checker.SASTWorkflowCI {
+			continue
+		}
+		configured = true
+		if !slices.Contains(w.Triggers, checker.SASTTriggerPullRequest) {
+			continue
+		}
+		line := w.File.Offset
+		loc := &finding.Location{
+			Type:      w.File.Type,
+			Path:      w.File.Path,
+			LineStart: &line,
+		}
+		f, err := finding.NewPositive(fs, Probe, fmt.Sprintf("%s runs on pull requests", w.Tool), loc)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	var f *finding.Finding
+	var err error
+	if configured {
+		f, err = finding.NewNegative(fs, Probe, "SAST tools are not run on pull requests", nil)
+	} else {
+		f, err = finding.NewNotAvailable(fs, Probe, "no SAST tool detected in CI configurations", nil)
+	}
+	if err != nil {
+		return nil, Probe, fmt.Errorf("create finding: %w", err)
+	}
+	return []finding.Finding{*f}, Probe, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package securityAdvisoriesHaveCVE
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/secpolicy"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "securityAdvisoriesHaveCVE"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+	var findings []finding.Finding
+	advisories := raw.SecurityPolicyResults.Advisories
+	for i := range advisories {
+		advisory := &advisories[i]
+		var f *finding.Finding
+		var err error
+		if advisory.CVE != "" {
+			f, err = finding.NewPositive(fs, Probe,
+				fmt.Sprintf("advisory %s has CVE %s", advisory.ID, advisory.CVE), secpolicy.AdvisoryLocation(advisory))
+		} else {
+			f, err = finding.NewNegative(fs, Probe,
+				fmt.Sprintf("advisory %s has no CVE", advisory.ID), secpolicy.AdvisoryLocation(advisory))
+		}
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		findings = append(findings, *f)
+	}
+
+	if len(findings) == 0 {
+		f, err := finding.NewNotAvailable(fs, Probe, "no security advisory published", nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		findings = append(findings, *f)
+	}
+	return findings, Probe, nil
+}
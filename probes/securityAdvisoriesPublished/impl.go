@@ -0,0 +1,58 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package securityAdvisoriesPublished
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "securityAdvisoriesPublished"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+	advisories := raw.SecurityPolicyResults.Advisories
+	var f *finding.Finding
+	var err error
+	switch {
+	case advisories == nil:
+		f, err = finding.NewNotAvailable(fs, Probe, "security advisories could not be listed", nil)
+	case len(advisories) == 0:
+		f, err = finding.NewNotAvailable(fs, Probe, "no security advisory published", nil)
+	default:
+		cves := 0
+		for i := range advisories {
+			if advisories[i].CVE != "" {
+				cves++
+			}
+		}
+		f, err = finding.NewPositive(fs, Probe,
+			fmt.Sprintf("%d security advisories published, %d with a CVE", len(advisories), cves), nil)
+	}
+	if err != nil {
+		return nil, Probe, fmt.Errorf("create finding: %w", err)
+	}
+	return []finding.Finding{*f}, Probe, nil
+}
@@ -38,6 +38,10 @@ func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
 	policies := raw.SecurityPolicyResults.PolicyFiles
 	for i := range policies {
 		policy := &policies[i]
+		// security.txt files are analyzed by the securityTxt probes.
+		if policy.IsSecurityTxt() {
+			continue
+		}
 		emails := secpolicy.CountSecInfo(policy.Information, checker.SecurityPolicyInformationTypeEmail, true)
 		urls := secpolicy.CountSecInfo(policy.Information, checker.SecurityPolicyInformationTypeLink, true)
 
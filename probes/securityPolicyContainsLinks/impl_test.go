@@ -273,6 +273,45 @@ func Test_Run(t *testing.T) {
 				finding.OutcomeNegative,
 			},
 		},
+		{
+			name: "security.txt is not analyzed",
+			raw: &checker.RawResults{
+				SecurityPolicyResults: checker.SecurityPolicyData{
+					PolicyFiles: []checker.SecurityPolicyFile{
+						{
+							File: checker.File{
+								Path:     ".well-known/security.txt",
+								Type:     finding.FileTypeText,
+								FileSize: 100,
+							},
+							Information: []checker.SecurityPolicyInformation{
+								{
+									InformationType: checker.SecurityPolicyInformationTypeEmail,
+									InformationValue: checker.SecurityPolicyValueType{
+										Match: "security@example.com",
+									},
+								},
+								{
+									InformationType: checker.SecurityPolicyInformationTypeText,
+									InformationValue: checker.SecurityPolicyValueType{
+										Match: "disclos",
+									},
+								},
+								{
+									InformationType: checker.SecurityPolicyInformationTypeText,
+									InformationValue: checker.SecurityPolicyValueType{
+										Match: "vuln",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			outcomes: []finding.Outcome{
+				finding.OutcomeNegative,
+			},
+		},
 		{
 			name: "nil raw",
 			err:  uerror.ErrNil,
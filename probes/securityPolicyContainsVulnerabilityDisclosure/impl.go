@@ -38,6 +38,10 @@ func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
 	policies := raw.SecurityPolicyResults.PolicyFiles
 	for i := range policies {
 		policy := &policies[i]
+		// security.txt files are analyzed by the securityTxt probes.
+		if policy.IsSecurityTxt() {
+			continue
+		}
 		discvuls := secpolicy.CountSecInfo(policy.Information, checker.SecurityPolicyInformationTypeText, false)
 		if discvuls > 1 {
 			f, err := finding.NewPositive(fs, Probe,
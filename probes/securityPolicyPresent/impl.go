@@ -35,7 +35,12 @@ func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
 	}
 	var files []checker.File
 	for i := range raw.SecurityPolicyResults.PolicyFiles {
-		files = append(files, raw.SecurityPolicyResults.PolicyFiles[i].File)
+		policy := &raw.SecurityPolicyResults.PolicyFiles[i]
+		// security.txt files are analyzed by the securityTxt probes.
+		if policy.IsSecurityTxt() {
+			continue
+		}
+		files = append(files, policy.File)
 	}
 
 	var findings []finding.Finding
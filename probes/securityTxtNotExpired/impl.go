@@ -0,0 +1,81 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package securityTxtNotExpired
+
+import (
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/secpolicy"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const Probe = "securityTxtNotExpired"
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+	var findings []finding.Finding
+	policies := raw.SecurityPolicyResults.PolicyFiles
+	for i := range policies {
+		policy := &policies[i]
+		if !policy.IsSecurityTxt() {
+			continue
+		}
+		outcome, text := checkExpiry(policy, time.Now())
+		f, err := finding.NewWith(fs, Probe, text, policy.File.Location(), outcome)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		findings = append(findings, *f)
+	}
+
+	if len(findings) == 0 {
+		f, err := finding.NewNotAvailable(fs, Probe, "no security.txt file detected", nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		findings = append(findings, *f)
+	}
+	return findings, Probe, nil
+}
+
+func checkExpiry(policy *checker.SecurityPolicyFile, now time.Time) (finding.Outcome, string) {
+	// The RFC requires exactly one Expires field.
+	expires := secpolicy.FindSecInfo(policy.Information, checker.SecurityPolicyInformationTypeExpires, false)
+	if len(expires) == 0 {
+		return finding.OutcomeNotAvailable, "security.txt has no Expires field"
+	}
+	if len(expires) != 1 {
+		return finding.OutcomeNegative, fmt.Sprintf("security.txt has %d Expires fields, expected 1", len(expires))
+	}
+	value := expires[0].InformationValue.Match
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return finding.OutcomeNegative, fmt.Sprintf("security.txt has an invalid Expires field: %s", value)
+	}
+	if !expiry.After(now) {
+		return finding.OutcomeNegative, fmt.Sprintf("security.txt expired on %s", expiry.Format("2006-01-02"))
+	}
+	return finding.OutcomePositive, fmt.Sprintf("security.txt expires on %s", expiry.Format("2006-01-02"))
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package securityTxtNotExpired
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+func securityTxt(expires ...string) checker.SecurityPolicyFile {
+	file := checker.SecurityPolicyFile{
+		File: checker.File{
+			Path: ".well-known/security.txt",
+			Type: finding.FileTypeText,
+		},
+	}
+	for _, e := range expires {
+		file.Information = append(file.Information, checker.SecurityPolicyInformation{
+			InformationType: checker.SecurityPolicyInformationTypeExpires,
+			InformationValue: checker.SecurityPolicyValueType{
+				Match: e,
+			},
+		})
+	}
+	return file
+}
+
+func Test_Run(t *testing.T) {
+	t.Parallel()
+	// nolint:govet
+	tests := []struct {
+		name     string
+		raw      *checker.RawResults
+		outcomes []finding.Outcome
+		err      error
+	}{
+		{
+			name: "not expired",
+			raw: &checker.RawResults{
+				SecurityPolicyResults: checker.SecurityPolicyData{
+					PolicyFiles: []checker.SecurityPolicyFile{
+						securityTxt(time.Now().AddDate(0, 6, 0).Format(time.RFC3339)),
+					},
+				},
+			},
+			outcomes: []finding.Outcome{
+				finding.OutcomePositive,
+			},
+		},
+		{
+			name: "expired",
+			raw: &checker.RawResults{
+				SecurityPolicyResults: checker.SecurityPolicyData{
+					PolicyFiles: []checker.SecurityPolicyFile{
+						securityTxt("2021-12-31T18:37:07.000Z"),
+					},
+				},
+			},
+			outcomes: []finding.Outcome{
+				finding.OutcomeNegative,
+			},
+		},
+		{
+			name: "no security.txt",
+			raw:  &checker.RawResults{},
+			outcomes: []finding.Outcome{
+				finding.OutcomeNotAvailable,
+			},
+		},
+		{
+			name: "nil raw",
+			err:  uerror.ErrNil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			findings, s, err := Run(tt.raw)
+			if !cmp.Equal(tt.err, err, cmpopts.EquateErrors()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(tt.err, err, cmpopts.EquateErrors()))
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(Probe, s); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(len(tt.outcomes), len(findings)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			for i := range tt.outcomes {
+				outcome := &tt.outcomes[i]
+				f := &findings[i]
+				if diff := cmp.Diff(*outcome, f.Outcome); diff != "" {
+					t.Errorf("mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func Test_checkExpiry(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		file    checker.SecurityPolicyFile
+		outcome finding.Outcome
+	}{
+		{
+			name:    "expires in the future",
+			file:    securityTxt("2025-01-01T00:00:00Z"),
+			outcome: finding.OutcomePositive,
+		},
+		{
+			name:    "expires with offset",
+			file:    securityTxt("2024-06-01T02:00:00+03:00"),
+			outcome: finding.OutcomeNegative,
+		},
+		{
+			name:    "expired",
+			file:    securityTxt("2024-05-31T23:59:59.000Z"),
+			outcome: finding.OutcomeNegative,
+		},
+		{
+			name:    "invalid date",
+			file:    securityTxt("Sat, 1 Jun 2024"),
+			outcome: finding.OutcomeNegative,
+		},
+		{
+			name:    "missing",
+			file:    securityTxt(),
+			outcome: finding.OutcomeNotAvailable,
+		},
+		{
+			name:    "duplicated",
+			file:    securityTxt("2025-01-01T00:00:00Z", "2026-01-01T00:00:00Z"),
+			outcome: finding.OutcomeNegative,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			outcome, text := checkExpiry(&tt.file, now)
+			if outcome != tt.outcome {
+				t.Errorf("checkExpiry() = %v (%s), want %v", outcome, text, tt.outcome)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:stylecheck
+package topContributorCommitShareLow
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/probes/internal/utils/uerror"
+)
+
+//go:embed *.yml
+var fs embed.FS
+
+const (
+	Probe = "topContributorCommitShareLow"
+	// maxSharePercent is the maximum share of commits
+	// the most active maintainer may account for.
+	maxSharePercent = 50
+)
+
+func Run(raw *checker.RawResults) ([]finding.Finding, string, error) {
+	if raw == nil {
+		return nil, "", fmt.Errorf("%w: raw", uerror.ErrNil)
+	}
+
+	var total, top int
+	var topLogin string
+	maintainers := raw.BusFactorResults.Maintainers
+	for i := range maintainers {
+		m := &maintainers[i]
+		total += m.NumCommits
+		if m.NumCommits > top {
+			top = m.NumCommits
+			topLogin = m.User.Login
+		}
+	}
+
+	if total == 0 {
+		f, err := finding.NewNotAvailable(fs, Probe,
+			"no commits found in the last year", nil)
+		if err != nil {
+			return nil, Probe, fmt.Errorf("create finding: %w", err)
+		}
+		return []finding.Finding{*f}, Probe, nil
+	}
+
+	share := top * 100 / total
+	text := fmt.Sprintf("most active maintainer %s accounts for %d%% of commits", topLogin, share)
+	outcome := finding.OutcomePositive
+	if share > maxSharePercent {
+		outcome = finding.OutcomeNegative
+	}
+
+	f, err := finding.NewWith(fs, Probe, text, nil, outcome)
+	if err != nil {
+		return nil, Probe, fmt.Errorf("create finding: %w", err)
+	}
+	return []finding.Finding{*f}, Probe, nil
+}
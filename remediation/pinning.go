@@ -0,0 +1,336 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remediation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+var errUnknownRef = errors.New("unknown ref")
+
+// ActionResolver resolves a tag or a branch of an action's repository, e.g., actions/checkout,
+// to a commit SHA.
+type ActionResolver interface {
+	ResolveAction(repo, ref string) (string, error)
+}
+
+// RepoClientFactory returns an initialized RepoClient for a repository, e.g., owner/repo.
+type RepoClientFactory func(repo string) (clients.RepoClient, error)
+
+// RepoClientActionResolver resolves tags and branches using the RepoClient of the action's
+// repository. The refs of each repository are listed once.
+type RepoClientActionResolver struct {
+	NewClient RepoClientFactory
+	repos     map[string]*repoRefs
+	mu        sync.Mutex
+}
+
+// repoRefs maps the tags and branches of a repository to commit SHAs.
+type repoRefs struct {
+	err  error
+	shas map[string]string
+}
+
+// ResolveAction implements ActionResolver.ResolveAction.
+func (r *RepoClientActionResolver) ResolveAction(repo, ref string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.repos == nil {
+		r.repos = make(map[string]*repoRefs)
+	}
+	key := strings.ToLower(repo)
+	refs, ok := r.repos[key]
+	if !ok {
+		refs = r.listRefs(repo)
+		r.repos[key] = refs
+	}
+	if refs.err != nil {
+		return "", refs.err
+	}
+	if sha, ok := refs.shas[ref]; ok {
+		return sha, nil
+	}
+	return "", fmt.Errorf("%w: %s@%s", errUnknownRef, repo, ref)
+}
+
+func (r *RepoClientActionResolver) listRefs(repo string) *repoRefs {
+	client, err := r.NewClient(repo)
+	if err != nil {
+		return &repoRefs{err: err}
+	}
+	defer client.Close()
+
+	branches, err := client.ListBranchHeads()
+	if err != nil {
+		return &repoRefs{err: fmt.Errorf("ListBranchHeads: %w", err)}
+	}
+	tags, err := client.ListTags()
+	if err != nil {
+		return &repoRefs{err: fmt.Errorf("ListTags: %w", err)}
+	}
+	refs := &repoRefs{shas: make(map[string]string, len(branches)+len(tags))}
+	for _, branch := range branches {
+		refs.shas[branch.Name] = branch.SHA
+	}
+	// Like git, prefer tags over branches of the same name.
+	for _, tag := range tags {
+		refs.shas[tag.Name] = tag.SHA
+	}
+	return refs
+}
+
+// TagCache is an ActionResolver backed by a local file that maps `owner/repo@ref`
+// to commit SHAs. Refs missing from the cache are resolved by Fallback, if set,
+// and added to the cache.
+type TagCache struct {
+	Fallback ActionResolver
+	entries  map[string]string
+	path     string
+	mu       sync.Mutex
+}
+
+// LoadTagCache reads the tag cache stored at path. A missing file is an empty cache.
+func LoadTagCache(path string, fallback ActionResolver) (*TagCache, error) {
+	cache := &TagCache{
+		Fallback: fallback,
+		entries:  make(map[string]string),
+		path:     path,
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	if err := json.Unmarshal(content, &cache.entries); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return cache, nil
+}
+
+// ResolveAction implements ActionResolver.ResolveAction.
+func (c *TagCache) ResolveAction(repo, ref string) (string, error) {
+	key := fmt.Sprintf("%s@%s", repo, ref)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if sha, ok := c.entries[key]; ok {
+		return sha, nil
+	}
+	if c.Fallback == nil {
+		return "", fmt.Errorf("%w: %s", errUnknownRef, key)
+	}
+	sha, err := c.Fallback.ResolveAction(repo, ref)
+	if err != nil {
+		return "", err
+	}
+	c.entries[key] = sha
+	return sha, nil
+}
+
+// Save writes the tag cache back to its file.
+func (c *TagCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	content, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: %w", err)
+	}
+	//nolint:gosec // The cache is not sensitive.
+	if err := os.WriteFile(c.path, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+	return nil
+}
+
+// LineEdit replaces a line of a file.
+type LineEdit struct {
+	Old  string
+	New  string
+	Line int // 1-based.
+}
+
+var (
+	usesLine      = regexp.MustCompile(`^(\s*(?:-\s+)?uses:\s*)(["']?)([^"'\s#]+)(["']?)(\s*#.*)?$`)
+	actionRef     = regexp.MustCompile(`^([\w.-]+/[\w.-]+)((?:/[^@]*)?)@(.+)$`)
+	commitSHA     = regexp.MustCompile(`^[a-fA-F\d]{40}$`)
+	fromLine      = regexp.MustCompile(`(?i)^(\s*FROM\s+)((?:--\S+\s+)*)(\S+)(.*)$`)
+	fromStageName = regexp.MustCompile(`(?i)\s+AS\s+(\S+)`)
+)
+
+// PinWorkflow returns the edits pinning the actions and reusable workflows used by
+// a GitHub workflow, or a composite action, to commit SHAs. The replaced tag is kept
+// as a version comment, e.g., `actions/checkout@<sha> # v3`, and Docker actions are
+// pinned to the digest of their image. References that cannot be resolved are skipped,
+// and their errors returned.
+func PinWorkflow(content []byte, actions ActionResolver, images Digester) (edits []LineEdit, skipped []error) {
+	for i, line := range strings.Split(string(content), "\n") {
+		m := usesLine.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		prefix, quote, uses, endQuote := m[1], m[2], m[3], m[4]
+
+		var pinned, comment string
+		switch {
+		case strings.HasPrefix(uses, "./"):
+			continue
+		case strings.HasPrefix(uses, "docker://"):
+			image := strings.TrimPrefix(uses, "docker://")
+			if strings.Contains(image, "@") || images == nil {
+				continue
+			}
+			digest, err := images.Digest(image)
+			if err != nil {
+				skipped = append(skipped, fmt.Errorf("line %d: cannot resolve %s: %w", i+1, image, err))
+				continue
+			}
+			pinned = fmt.Sprintf("docker://%s@%s", image, digest)
+		default:
+			ref := actionRef.FindStringSubmatch(uses)
+			if ref == nil || commitSHA.MatchString(ref[3]) || strings.Contains(ref[3], "${{") {
+				continue
+			}
+			sha, err := actions.ResolveAction(ref[1], ref[3])
+			if err != nil {
+				skipped = append(skipped, fmt.Errorf("line %d: cannot resolve %s: %w", i+1, uses, err))
+				continue
+			}
+			pinned = fmt.Sprintf("%s%s@%s", ref[1], ref[2], sha)
+			comment = " # " + ref[3]
+		}
+		if comment == "" {
+			// Keep existing comments.
+			comment = m[5]
+		}
+
+		edits = append(edits, LineEdit{
+			Line: i + 1,
+			Old:  line,
+			New:  prefix + quote + pinned + endQuote + comment,
+		})
+	}
+	return edits, skipped
+}
+
+// PinDockerfile returns the edits pinning the images of the FROM instructions of
+// a Dockerfile to their digests, e.g., `FROM golang:1.21@sha256:...`. Images that
+// cannot be resolved are skipped, and their errors returned.
+func PinDockerfile(content []byte, images Digester) (edits []LineEdit, skipped []error) {
+	stages := make(map[string]bool)
+	for i, line := range strings.Split(string(content), "\n") {
+		m := fromLine.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		prefix, flags, image, rest := m[1], m[2], m[3], m[4]
+		// Skip pinned images, build stages, images set by ARGs and scratch.
+		skip := strings.Contains(image, "@") || strings.Contains(image, "$") ||
+			stages[strings.ToLower(image)] || strings.EqualFold(image, "scratch")
+		if s := fromStageName.FindStringSubmatch(rest); s != nil {
+			stages[strings.ToLower(s[1])] = true
+		}
+		if skip {
+			continue
+		}
+
+		digest, err := images.Digest(image)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("line %d: cannot resolve %s: %w", i+1, image, err))
+			continue
+		}
+		edits = append(edits, LineEdit{
+			Line: i + 1,
+			Old:  line,
+			New:  fmt.Sprintf("%s%s%s@%s%s", prefix, flags, image, digest, rest),
+		})
+	}
+	return edits, skipped
+}
+
+// ApplyEdits returns the content with the edits applied.
+func ApplyEdits(content []byte, edits []LineEdit) []byte {
+	lines := strings.Split(string(content), "\n")
+	for _, e := range edits {
+		if e.Line > 0 && e.Line <= len(lines) {
+			lines[e.Line-1] = e.New
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// Number of unchanged lines around the changes of a hunk.
+const diffContext = 3
+
+// UnifiedDiff returns the edits of a file as a unified diff.
+func UnifiedDiff(path string, content []byte, edits []LineEdit) string {
+	if len(edits) == 0 {
+		return ""
+	}
+	lines := strings.Split(string(content), "\n")
+	// Don't count the empty line after the final newline.
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	changed := make(map[int]string, len(edits))
+	for _, e := range edits {
+		changed[e.Line] = e.New
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", path, path)
+	for i := 0; i < len(edits); {
+		// Group edits whose contexts overlap into a single hunk.
+		j := i
+		for j+1 < len(edits) && edits[j+1].Line-edits[j].Line <= 2*diffContext {
+			j++
+		}
+		start := maxInt(1, edits[i].Line-diffContext)
+		end := minInt(len(lines), edits[j].Line+diffContext)
+		n := end - start + 1
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", start, n, start, n)
+		for l := start; l <= end; l++ {
+			if repl, ok := changed[l]; ok {
+				fmt.Fprintf(&sb, "-%s\n+%s\n", lines[l-1], repl)
+				continue
+			}
+			fmt.Fprintf(&sb, " %s\n", lines[l-1])
+		}
+		i = j + 1
+	}
+	return sb.String()
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
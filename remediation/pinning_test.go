@@ -0,0 +1,270 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remediation
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+const (
+	checkoutSHA = "8e5e7e5ab8b370d6c329ec480221332ada57f0ab"
+	reusableSHA = "3d58c274f17dffee475a5520cbe67f0a882c4dbb"
+)
+
+var errStubResolver = errors.New("unknown action")
+
+type stubResolver map[string]string
+
+func (s stubResolver) ResolveAction(repo, ref string) (string, error) {
+	sha, ok := s[repo+"@"+ref]
+	if !ok {
+		return "", errStubResolver
+	}
+	return sha, nil
+}
+
+func TestPinWorkflow(t *testing.T) {
+	t.Parallel()
+	content := `on: push
+jobs:
+  call:
+    uses: octo-org/workflows/.github/workflows/build.yml@v1
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v3 # keep me pinned
+      - uses: "actions/checkout@v3"
+      - uses: actions/checkout@` + checkoutSHA + ` # v3
+      - uses: ./.github/actions/setup
+      - uses: docker://foo
+      - name: step
+        uses: ${{ matrix.action }}
+`
+	resolver := stubResolver{
+		"actions/checkout@v3":   checkoutSHA,
+		"octo-org/workflows@v1": reusableSHA,
+	}
+	edits, skipped := PinWorkflow([]byte(content), resolver, stubDigester{})
+	if len(skipped) != 0 {
+		t.Fatalf("unexpected errors: %v", skipped)
+	}
+	want := []LineEdit{
+		{
+			Line: 4,
+			Old:  "    uses: octo-org/workflows/.github/workflows/build.yml@v1",
+			New:  "    uses: octo-org/workflows/.github/workflows/build.yml@" + reusableSHA + " # v1",
+		},
+		{
+			Line: 8,
+			Old:  "      - uses: actions/checkout@v3 # keep me pinned",
+			New:  "      - uses: actions/checkout@" + checkoutSHA + " # v3",
+		},
+		{
+			Line: 9,
+			Old:  `      - uses: "actions/checkout@v3"`,
+			New:  `      - uses: "actions/checkout@` + checkoutSHA + `" # v3`,
+		},
+		{
+			Line: 12,
+			Old:  "      - uses: docker://foo",
+			New:  "      - uses: docker://foo@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		},
+	}
+	if diff := cmp.Diff(want, edits); diff != "" {
+		t.Errorf("unexpected edits (-want +got):\n%s", diff)
+	}
+
+	// Unknown actions are skipped without aborting the file.
+	edits, skipped = PinWorkflow([]byte("    - uses: unknown/action@v1\n    - uses: actions/checkout@v3\n"),
+		resolver, stubDigester{})
+	if len(skipped) != 1 || len(edits) != 1 || edits[0].Line != 2 {
+		t.Errorf("expected the unknown action to be skipped, got edits %v, errors %v", edits, skipped)
+	}
+}
+
+func TestRepoClientActionResolver(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	client := mockrepo.NewMockRepoClient(ctrl)
+	client.EXPECT().ListTags().Return([]clients.Tag{
+		{Name: "v3", SHA: checkoutSHA},
+		{Name: "main", SHA: checkoutSHA},
+	}, nil).Times(1)
+	client.EXPECT().ListBranchHeads().Return([]clients.BranchHead{
+		{Name: "main", SHA: reusableSHA},
+		{Name: "releases/v2", SHA: reusableSHA},
+	}, nil).Times(1)
+	client.EXPECT().Close().Return(nil).Times(1)
+	created := map[string]int{}
+	resolver := &RepoClientActionResolver{
+		NewClient: func(repo string) (clients.RepoClient, error) {
+			created[repo]++
+			if repo == "deleted/action" {
+				return nil, errStubResolver
+			}
+			return client, nil
+		},
+	}
+
+	for _, tt := range []struct {
+		repo, ref, sha string
+		err            bool
+	}{
+		{repo: "actions/checkout", ref: "v3", sha: checkoutSHA},
+		{repo: "actions/checkout", ref: "releases/v2", sha: reusableSHA},
+		// Tags take precedence over branches of the same name.
+		{repo: "actions/checkout", ref: "main", sha: checkoutSHA},
+		{repo: "actions/checkout", ref: "v1", err: true},
+		{repo: "deleted/action", ref: "v1", err: true},
+		{repo: "deleted/action", ref: "v2", err: true},
+	} {
+		sha, err := resolver.ResolveAction(tt.repo, tt.ref)
+		if (err != nil) != tt.err || sha != tt.sha {
+			t.Errorf("ResolveAction(%s, %s) = %q, %v", tt.repo, tt.ref, sha, err)
+		}
+	}
+	if diff := cmp.Diff(map[string]int{"actions/checkout": 1, "deleted/action": 1}, created); diff != "" {
+		t.Errorf("expected one client per repository (-want +got):\n%s", diff)
+	}
+}
+
+func TestPinDockerfile(t *testing.T) {
+	t.Parallel()
+	content := `FROM amazoncorretto:11 AS build
+FROM build AS test
+FROM --platform=linux/amd64 foo
+ARG IMAGE
+FROM ${IMAGE}
+FROM scratch
+FROM baz@sha256:fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9
+FROM not-found
+`
+	edits, skipped := PinDockerfile([]byte(content), stubDigester{})
+	if len(skipped) != 1 {
+		t.Errorf("expected the unknown image to be skipped, got %v", skipped)
+	}
+	want := []LineEdit{
+		{
+			Line: 1,
+			Old:  "FROM amazoncorretto:11 AS build",
+			New:  "FROM amazoncorretto:11@sha256:b1a711069b801a325a30885f08f5067b2b102232379750dda4d25a016afd9a88 AS build",
+		},
+		{
+			Line: 3,
+			Old:  "FROM --platform=linux/amd64 foo",
+			New:  "FROM --platform=linux/amd64 foo@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		},
+	}
+	if diff := cmp.Diff(want, edits); diff != "" {
+		t.Errorf("unexpected edits (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+	content := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+	edits := []LineEdit{
+		{Line: 2, Old: "2", New: "two"},
+		{Line: 5, Old: "5", New: "five"},
+		{Line: 14, Old: "14", New: "fourteen"},
+	}
+	want := `--- a/f
++++ b/f
+@@ -1,8 +1,8 @@
+ 1
+-2
++two
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+@@ -11,5 +11,5 @@
+ 11
+ 12
+ 13
+-14
++fourteen
+ 15
+`
+	if diff := cmp.Diff(want, UnifiedDiff("f", []byte(content), edits)); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+	if got := string(ApplyEdits([]byte(content), edits)); got !=
+		"1\ntwo\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13\nfourteen\n15\n" {
+		t.Errorf("unexpected content: %q", got)
+	}
+}
+
+func TestTagCache(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "tags.json")
+	if err := os.WriteFile(path, []byte(`{"actions/checkout@v3": "`+checkoutSHA+`"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mockrepo.NewMockRepoClient(ctrl)
+	client.EXPECT().ListTags().Return([]clients.Tag{{Name: "v1", SHA: reusableSHA}}, nil).Times(1)
+	client.EXPECT().ListBranchHeads().Return(nil, nil).Times(1)
+	client.EXPECT().Close().Return(nil).Times(1)
+	fallback := &RepoClientActionResolver{
+		NewClient: func(repo string) (clients.RepoClient, error) {
+			return client, nil
+		},
+	}
+
+	cache, err := LoadTagCache(path, fallback)
+	if err != nil {
+		t.Fatalf("LoadTagCache: %v", err)
+	}
+	for _, tt := range []struct {
+		repo, ref, sha string
+		err            bool
+	}{
+		{repo: "actions/checkout", ref: "v3", sha: checkoutSHA},
+		{repo: "octo-org/workflows", ref: "v1", sha: reusableSHA},
+		// Resolved from the cache.
+		{repo: "octo-org/workflows", ref: "v1", sha: reusableSHA},
+		{repo: "octo-org/workflows", ref: "v2", err: true},
+	} {
+		sha, err := cache.ResolveAction(tt.repo, tt.ref)
+		if (err != nil) != tt.err || sha != tt.sha {
+			t.Errorf("ResolveAction(%s, %s) = %q, %v", tt.repo, tt.ref, sha, err)
+		}
+	}
+
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	reloaded, err := LoadTagCache(path, nil)
+	if err != nil {
+		t.Fatalf("LoadTagCache: %v", err)
+	}
+	if sha, err := reloaded.ResolveAction("octo-org/workflows", "v1"); err != nil || sha != reusableSHA {
+		t.Errorf("expected saved entry, got %q, %v", sha, err)
+	}
+}
@@ -32,6 +32,8 @@ var (
 	//nolint
 	workflowMarkdown  = "update your workflow using [https://app.stepsecurity.io](https://app.stepsecurity.io/secureworkflow/%s/%s/%s?enable=%s)"
 	dockerfilePinText = "pin your Docker image by updating %[1]s to %[1]s@%s"
+	containerPinText  = "pin your container image by updating %[1]s to %[1]s@%s"
+	actionPinText     = "pin your action by updating %[1]s@%[2]s to %[1]s@<full-length commit SHA of %[2]s> # %[2]s"
 )
 
 // TODO fix how this info makes it checks/evaluation.
@@ -81,6 +83,22 @@ func (r *RemediationMetadata) createWorkflowRemediation(path, t string) *rule.Re
 	}
 }
 
+// CreateActionPinningRemediation create remediation for pinning actions used outside
+// of workflows, e.g., by composite actions.
+func CreateActionPinningRemediation(dep *checker.Dependency) *rule.Remediation {
+	if dep.Name == nil || *dep.Name == "" || dep.PinnedAt == nil || *dep.PinnedAt == "" {
+		return nil
+	}
+
+	text := fmt.Sprintf(actionPinText, *dep.Name, *dep.PinnedAt)
+	markdown := text
+
+	return &rule.Remediation{
+		Text:     text,
+		Markdown: markdown,
+	}
+}
+
 func dockerImageName(d *checker.Dependency) (name string, ok bool) {
 	if d.Name == nil || *d.Name == "" {
 		return "", false
@@ -102,6 +120,16 @@ func (c CraneDigester) Digest(name string) (string, error) {
 
 // CreateDockerfilePinningRemediation create remediaiton for pinning Dockerfile images.
 func CreateDockerfilePinningRemediation(dep *checker.Dependency, digester Digester) *rule.Remediation {
+	return createImagePinningRemediation(dep, digester, dockerfilePinText)
+}
+
+// CreateContainerImagePinningRemediation create remediation for pinning container images
+// used by workflows and actions, e.g., in `container:`, `services:` or `uses: docker://`.
+func CreateContainerImagePinningRemediation(dep *checker.Dependency, digester Digester) *rule.Remediation {
+	return createImagePinningRemediation(dep, digester, containerPinText)
+}
+
+func createImagePinningRemediation(dep *checker.Dependency, digester Digester, format string) *rule.Remediation {
 	name, ok := dockerImageName(dep)
 	if !ok {
 		return nil
@@ -112,7 +140,7 @@ func CreateDockerfilePinningRemediation(dep *checker.Dependency, digester Digest
 		return nil
 	}
 
-	text := fmt.Sprintf(dockerfilePinText, name, hash)
+	text := fmt.Sprintf(format, name, hash)
 	markdown := text
 
 	return &rule.Remediation{
@@ -141,6 +141,49 @@ func TestCreateDockerfilePinningRemediation(t *testing.T) {
 	}
 }
 
+func TestCreateContainerImagePinningRemediation(t *testing.T) {
+	t.Parallel()
+
+	//nolint:lll
+	tests := []struct {
+		name     string
+		dep      checker.Dependency
+		expected *rule.Remediation
+	}{
+		{
+			name: "image name with tag",
+			dep: checker.Dependency{
+				Name:     asPointer("amazoncorretto"),
+				PinnedAt: asPointer("11"),
+				Type:     checker.DependencyUseTypeDockerfileContainerImage,
+			},
+			expected: &rule.Remediation{
+				Text:     "pin your container image by updating amazoncorretto:11 to amazoncorretto:11@sha256:b1a711069b801a325a30885f08f5067b2b102232379750dda4d25a016afd9a88",
+				Markdown: "pin your container image by updating amazoncorretto:11 to amazoncorretto:11@sha256:b1a711069b801a325a30885f08f5067b2b102232379750dda4d25a016afd9a88",
+			},
+		},
+		{
+			name: "unknown image",
+			dep: checker.Dependency{
+				Name: asPointer("not-found"),
+				Type: checker.DependencyUseTypeDockerfileContainerImage,
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := CreateContainerImagePinningRemediation(&tt.dep, stubDigester{})
+			if !cmp.Equal(got, tt.expected) {
+				t.Errorf(cmp.Diff(got, tt.expected))
+			}
+		})
+	}
+}
+
 func TestCreateWorkflowPinningRemediation(t *testing.T) {
 	t.Parallel()
 
@@ -192,3 +235,50 @@ func TestCreateWorkflowPinningRemediation(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateActionPinningRemediation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dep      checker.Dependency
+		expected *rule.Remediation
+	}{
+		{
+			name:     "no dependency",
+			dep:      checker.Dependency{},
+			expected: nil,
+		},
+		{
+			name: "action without ref",
+			dep: checker.Dependency{
+				Name: asPointer("actions/checkout"),
+				Type: checker.DependencyUseTypeGHAction,
+			},
+			expected: nil,
+		},
+		{
+			name: "action with tag",
+			dep: checker.Dependency{
+				Name:     asPointer("actions/checkout"),
+				PinnedAt: asPointer("v3"),
+				Type:     checker.DependencyUseTypeGHAction,
+			},
+			expected: &rule.Remediation{
+				Text:     "pin your action by updating actions/checkout@v3 to actions/checkout@<full-length commit SHA of v3> # v3",
+				Markdown: "pin your action by updating actions/checkout@v3 to actions/checkout@<full-length commit SHA of v3> # v3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := CreateActionPinningRemediation(&tt.dep)
+			if !cmp.Equal(got, tt.expected) {
+				t.Errorf(cmp.Diff(got, tt.expected))
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/ossf/scorecard/v4/lockfile"
+)
+
+const toolName = "scorecard"
+
+func readCycloneDX(content []byte) (*SBOM, error) {
+	bom := &cdx.BOM{}
+	if err := cdx.NewBOMDecoder(bytes.NewReader(content), cdx.BOMFileFormatJSON).Decode(bom); err != nil {
+		return nil, fmt.Errorf("Decode: %w", err)
+	}
+	s := &SBOM{Format: FormatCycloneDX, bom: bom, Graph: &lockfile.Graph{Edges: make(map[int][]int)}}
+
+	refs := make(map[string]int)
+	var addComponents func(components *[]cdx.Component)
+	addComponents = func(components *[]cdx.Component) {
+		if components == nil {
+			return
+		}
+		for i := range *components {
+			c := &(*components)[i]
+			if d, ok := DependencyFromPURL(c.PackageURL); ok {
+				s.Graph.Dependencies = append(s.Graph.Dependencies, d)
+				s.components = append(s.components, c)
+				if c.BOMRef != "" {
+					refs[c.BOMRef] = len(s.components) - 1
+				}
+			}
+			addComponents(c.Components)
+		}
+	}
+	addComponents(bom.Components)
+
+	rootRef := ""
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		rootRef = bom.Metadata.Component.BOMRef
+	}
+	if bom.Dependencies != nil {
+		for _, d := range *bom.Dependencies {
+			if d.Dependencies == nil {
+				continue
+			}
+			from, ok := refs[d.Ref]
+			for _, ref := range *d.Dependencies {
+				to, found := refs[ref]
+				switch {
+				case !found:
+				case ok:
+					s.Graph.Edges[from] = append(s.Graph.Edges[from], to)
+				case d.Ref == rootRef:
+					s.Graph.Direct = append(s.Graph.Direct, to)
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+func newCycloneDX(name, version string, g *lockfile.Graph) *SBOM {
+	bom := cdx.NewBOM()
+	root := &cdx.Component{
+		BOMRef:  "root",
+		Type:    cdx.ComponentTypeApplication,
+		Name:    name,
+		Version: version,
+	}
+	bom.Metadata = &cdx.Metadata{
+		Timestamp: now().UTC().Format(time.RFC3339),
+		Tools:     &[]cdx.Tool{{Vendor: "OpenSSF", Name: toolName}},
+		Component: root,
+	}
+
+	components := make([]cdx.Component, len(g.Dependencies))
+	refs := make([]string, len(g.Dependencies))
+	for i, d := range g.Dependencies {
+		refs[i] = PURL(d)
+		if refs[i] == "" {
+			refs[i] = fmt.Sprintf("dependency-%d", i)
+		}
+		components[i] = cdx.Component{
+			BOMRef:     refs[i],
+			Type:       cdx.ComponentTypeLibrary,
+			Name:       d.Name,
+			Version:    d.Version,
+			PackageURL: PURL(d),
+		}
+	}
+	// The same package may be locked by several lockfiles.
+	seen := make(map[string]bool)
+	var unique []cdx.Component
+	for i := range components {
+		if !seen[refs[i]] {
+			seen[refs[i]] = true
+			unique = append(unique, components[i])
+		}
+	}
+	bom.Components = &unique
+
+	byRef := make(map[string]*cdx.Component)
+	for i := range unique {
+		byRef[unique[i].BOMRef] = &unique[i]
+	}
+	s := &SBOM{Format: FormatCycloneDX, bom: bom, Graph: g}
+	for i := range g.Dependencies {
+		s.components = append(s.components, byRef[refs[i]])
+	}
+
+	// Components locked by several lockfiles depend on the dependencies locked by all of them.
+	var order []string
+	dependsOn := make(map[string][]string)
+	addDependencies := func(from string, indexes []int) {
+		if _, ok := dependsOn[from]; !ok {
+			order = append(order, from)
+			dependsOn[from] = []string{}
+		}
+		for _, i := range indexes {
+			if !contains(dependsOn[from], refs[i]) {
+				dependsOn[from] = append(dependsOn[from], refs[i])
+			}
+		}
+	}
+	addDependencies(root.BOMRef, g.Direct)
+	for i := range g.Dependencies {
+		addDependencies(refs[i], g.Edges[i])
+	}
+	dependencies := make([]cdx.Dependency, 0, len(order))
+	for _, ref := range order {
+		refs := dependsOn[ref]
+		dependencies = append(dependencies, cdx.Dependency{Ref: ref, Dependencies: &refs})
+	}
+	bom.Dependencies = &dependencies
+	return s
+}
+
+func (s *SBOM) annotateCycloneDXRoot(properties []Property) {
+	if s.bom.Metadata == nil {
+		s.bom.Metadata = &cdx.Metadata{}
+	}
+	if s.bom.Metadata.Component != nil {
+		annotateComponent(s.bom.Metadata.Component, properties)
+		return
+	}
+	s.bom.Metadata.Properties = setProperties(s.bom.Metadata.Properties, properties)
+}
+
+func annotateComponent(c *cdx.Component, properties []Property) {
+	c.Properties = setProperties(c.Properties, properties)
+}
+
+func setProperties(existing *[]cdx.Property, properties []Property) *[]cdx.Property {
+	replaced := make(map[string]bool, len(properties))
+	for _, p := range properties {
+		replaced[p.Name] = true
+	}
+	var out []cdx.Property
+	if existing != nil {
+		for _, p := range *existing {
+			if !replaced[p.Name] {
+				out = append(out, p)
+			}
+		}
+	}
+	for _, p := range properties {
+		out = append(out, cdx.Property{Name: p.Name, Value: p.Value})
+	}
+	return &out
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+
+	"github.com/ossf/scorecard/v4/lockfile"
+)
+
+// purlTypes maps the purl types of the supported ecosystems to their ecosystem.
+var purlTypes = map[string]lockfile.Ecosystem{
+	packageurl.TypeNPM:    lockfile.EcosystemNpm,
+	packageurl.TypePyPi:   lockfile.EcosystemPyPI,
+	packageurl.TypeGolang: lockfile.EcosystemGo,
+	packageurl.TypeMaven:  lockfile.EcosystemMaven,
+	packageurl.TypeCargo:  lockfile.EcosystemCrates,
+	packageurl.TypeNuget:  lockfile.EcosystemNuGet,
+	packageurl.TypeGem:    lockfile.EcosystemRubyGems,
+}
+
+// DependencyFromPURL returns the package identified by a purl, e.g., pkg:npm/%40scope/name@1.0.0.
+// It returns false when the purl is invalid or its ecosystem is not supported.
+func DependencyFromPURL(purl string) (lockfile.Dependency, bool) {
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return lockfile.Dependency{}, false
+	}
+	ecosystem, ok := purlTypes[p.Type]
+	if !ok {
+		return lockfile.Dependency{}, false
+	}
+	name := p.Name
+	if p.Namespace != "" {
+		separator := "/"
+		if ecosystem == lockfile.EcosystemMaven {
+			separator = ":"
+		}
+		name = p.Namespace + separator + p.Name
+	}
+	return lockfile.Dependency{Ecosystem: ecosystem, Name: name, Version: p.Version}, true
+}
+
+// PURL returns the purl of a dependency, or an empty string when its ecosystem has no purl type.
+func PURL(d lockfile.Dependency) string {
+	for purlType, ecosystem := range purlTypes {
+		if ecosystem != d.Ecosystem {
+			continue
+		}
+		namespace, name := "", d.Name
+		separator := "/"
+		if ecosystem == lockfile.EcosystemMaven {
+			separator = ":"
+		}
+		if i := strings.LastIndex(d.Name, separator); i > 0 {
+			namespace, name = d.Name[:i], d.Name[i+1:]
+		}
+		return packageurl.NewPackageURL(purlType, namespace, name, d.Version, nil, "").ToString()
+	}
+	return ""
+}
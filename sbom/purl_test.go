@@ -0,0 +1,82 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/lockfile"
+)
+
+func TestDependencyFromPURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		purl   string
+		want   lockfile.Dependency
+		wantOK bool
+	}{
+		{
+			purl:   "pkg:npm/%40babel/core@7.22.0",
+			want:   lockfile.Dependency{Ecosystem: lockfile.EcosystemNpm, Name: "@babel/core", Version: "7.22.0"},
+			wantOK: true,
+		},
+		{
+			purl:   "pkg:pypi/requests@2.31.0",
+			want:   lockfile.Dependency{Ecosystem: lockfile.EcosystemPyPI, Name: "requests", Version: "2.31.0"},
+			wantOK: true,
+		},
+		{
+			purl:   "pkg:golang/github.com/google/go-cmp@v0.5.9",
+			want:   lockfile.Dependency{Ecosystem: lockfile.EcosystemGo, Name: "github.com/google/go-cmp", Version: "v0.5.9"},
+			wantOK: true,
+		},
+		{
+			purl:   "pkg:maven/org.apache.commons/commons-lang3@3.12.0?type=jar",
+			want:   lockfile.Dependency{Ecosystem: lockfile.EcosystemMaven, Name: "org.apache.commons:commons-lang3", Version: "3.12.0"},
+			wantOK: true,
+		},
+		{
+			purl:   "pkg:gem/rails@7.0.0",
+			want:   lockfile.Dependency{Ecosystem: lockfile.EcosystemRubyGems, Name: "rails", Version: "7.0.0"},
+			wantOK: true,
+		},
+		{
+			purl: "pkg:deb/debian/curl@7.50.3-1",
+		},
+		{
+			purl: "not a purl",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.purl, func(t *testing.T) {
+			t.Parallel()
+			got, ok := DependencyFromPURL(tt.purl)
+			if ok != tt.wantOK {
+				t.Fatalf("DependencyFromPURL() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DependencyFromPURL() mismatch (-want +got): %s", diff)
+			}
+			if ok {
+				if d, _ := DependencyFromPURL(PURL(got)); d != got {
+					t.Errorf("PURL() = %s does not round trip", PURL(got))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sbom reads CycloneDX and SPDX SBOMs, and annotates their components with Scorecard results.
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	spdxjson "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/lockfile"
+)
+
+// Format is the format of an SBOM.
+type Format string
+
+const (
+	// FormatCycloneDX is the CycloneDX JSON format.
+	FormatCycloneDX Format = "cyclonedx"
+	// FormatSPDX is the SPDX JSON format.
+	FormatSPDX Format = "spdx"
+)
+
+// Names of the properties, or SPDX annotations, holding Scorecard results.
+const (
+	// PropertyScore is the aggregate score of the source repository of a component.
+	PropertyScore = "ossf:scorecard:score"
+	// PropertyRepository is the source repository of a component.
+	PropertyRepository = "ossf:scorecard:repository"
+	// PropertyCommit is the commit of the source repository which was scored.
+	PropertyCommit = "ossf:scorecard:commit"
+	// PropertyDate is the date of the Scorecard run.
+	PropertyDate = "ossf:scorecard:date"
+	// PropertyVersion is the version of Scorecard.
+	PropertyVersion = "ossf:scorecard:version"
+	// PropertyWeakestScore is the lowest score of a component and its transitive dependencies.
+	PropertyWeakestScore = "ossf:scorecard:weakest-score"
+	// PropertySupplyChainScore is the roll-up of the scores of the dependencies of the project.
+	PropertySupplyChainScore = "ossf:scorecard:supply-chain-score"
+	// PropertyError is the error which prevented a component from being scored.
+	PropertyError = "ossf:scorecard:error"
+	// PropertyCheckPrefix prefixes the names of the check scores, e.g., ossf:scorecard:check:Code-Review.
+	PropertyCheckPrefix = "ossf:scorecard:check:"
+)
+
+// Property is a Scorecard result attached to a component.
+type Property struct {
+	Name  string
+	Value string
+}
+
+// SBOM is a CycloneDX or SPDX document.
+type SBOM struct {
+	// Graph holds the components whose purl is of a supported ecosystem, and their dependencies.
+	Graph  *lockfile.Graph
+	Format Format
+
+	bom *cdx.BOM
+	doc *spdx.Document
+	// components, or packages, holds the component of each dependency of Graph.
+	components []*cdx.Component
+	packages   []*spdx.Package
+}
+
+// now is replaced in tests.
+var now = time.Now
+
+// Read parses a CycloneDX or SPDX JSON document.
+func Read(pathfn string, content []byte) (*SBOM, error) {
+	var header struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(content, &header); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("%s: only JSON SBOMs are supported: %v", pathfn, err))
+	}
+
+	var s *SBOM
+	var err error
+	switch {
+	case header.BOMFormat == cdx.BOMFormat:
+		s, err = readCycloneDX(content)
+	case header.SPDXVersion != "":
+		s, err = readSPDX(content)
+	default:
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: not a CycloneDX or SPDX document", pathfn))
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: %v", pathfn, err))
+	}
+	for i := range s.Graph.Dependencies {
+		s.Graph.Dependencies[i].Path = pathfn
+	}
+	s.Graph.MakeUnreachableDirect()
+	return s, nil
+}
+
+// New returns an SBOM of a project and its dependencies.
+func New(format Format, name, version string, g *lockfile.Graph) (*SBOM, error) {
+	switch format {
+	case FormatCycloneDX:
+		return newCycloneDX(name, version, g), nil
+	case FormatSPDX:
+		return newSPDX(name, version, g), nil
+	default:
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("unsupported SBOM format: %s", format))
+	}
+}
+
+// Annotate attaches properties to the component of the dependency at index i of the graph.
+// Properties already attached with the same names are replaced.
+func (s *SBOM) Annotate(i int, properties []Property) {
+	if s.bom != nil {
+		annotateComponent(s.components[i], properties)
+		return
+	}
+	annotatePackage(s.packages[i], properties)
+}
+
+// AnnotateRoot attaches properties to the project described by the SBOM, or to the SBOM itself
+// when it does not describe a single project.
+func (s *SBOM) AnnotateRoot(properties []Property) {
+	if s.bom != nil {
+		s.annotateCycloneDXRoot(properties)
+		return
+	}
+	s.annotateSPDXRoot(properties)
+}
+
+// Write writes the SBOM as JSON.
+func (s *SBOM) Write(w io.Writer) error {
+	if s.bom != nil {
+		if err := cdx.NewBOMEncoder(w, cdx.BOMFileFormatJSON).SetPretty(true).Encode(s.bom); err != nil {
+			return fmt.Errorf("Encode: %w", err)
+		}
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := spdxjson.Write(s.doc, &buf, spdxjson.Indent("  ")); err != nil {
+		return fmt.Errorf("spdxjson.Write: %w", err)
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("Write: %w", err)
+	}
+	return nil
+}
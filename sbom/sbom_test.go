@@ -0,0 +1,198 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/lockfile"
+)
+
+const cycloneDXDocument = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "app", "type": "application", "name": "app"}},
+  "components": [
+    {"bom-ref": "a", "type": "library", "name": "a", "version": "1.0.0", "purl": "pkg:npm/a@1.0.0",
+     "properties": [{"name": "ossf:scorecard:score", "value": "1.0"}, {"name": "other", "value": "kept"}]},
+    {"bom-ref": "b", "type": "library", "name": "b", "version": "2.0.0", "purl": "pkg:npm/b@2.0.0"},
+    {"bom-ref": "os", "type": "library", "name": "curl", "purl": "pkg:deb/debian/curl@7.50.3-1"}
+  ],
+  "dependencies": [
+    {"ref": "app", "dependsOn": ["a"]},
+    {"ref": "a", "dependsOn": ["b", "os"]}
+  ]
+}`
+
+const spdxDocument = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "app",
+  "documentNamespace": "https://example.com/app",
+  "creationInfo": {"created": "2023-09-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "app", "downloadLocation": "NOASSERTION"},
+    {"SPDXID": "SPDXRef-a", "name": "a", "versionInfo": "1.0.0", "downloadLocation": "NOASSERTION",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:pypi/a@1.0.0"}]},
+    {"SPDXID": "SPDXRef-b", "name": "b", "versionInfo": "2.0.0", "downloadLocation": "NOASSERTION",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:pypi/b@2.0.0"}]}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-a"},
+    {"spdxElementId": "SPDXRef-b", "relationshipType": "DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-a"}
+  ]
+}`
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		format  Format
+		want    *lockfile.Graph
+		output  []string
+	}{
+		{
+			name:    "CycloneDX",
+			content: cycloneDXDocument,
+			format:  FormatCycloneDX,
+			want: &lockfile.Graph{
+				Dependencies: []lockfile.Dependency{
+					{Ecosystem: lockfile.EcosystemNpm, Name: "a", Version: "1.0.0", Path: "bom.json"},
+					{Ecosystem: lockfile.EcosystemNpm, Name: "b", Version: "2.0.0", Path: "bom.json"},
+				},
+				Direct: []int{0},
+				Edges:  map[int][]int{0: {1}},
+			},
+			output: []string{
+				`"name": "ossf:scorecard:score",
+          "value": "7.5"`,
+				`"name": "other",
+          "value": "kept"`,
+				`"name": "ossf:scorecard:supply-chain-score",
+          "value": "6.0"`,
+			},
+		},
+		{
+			name:    "SPDX",
+			content: spdxDocument,
+			format:  FormatSPDX,
+			want: &lockfile.Graph{
+				Dependencies: []lockfile.Dependency{
+					{Ecosystem: lockfile.EcosystemPyPI, Name: "a", Version: "1.0.0", Path: "bom.json"},
+					{Ecosystem: lockfile.EcosystemPyPI, Name: "b", Version: "2.0.0", Path: "bom.json"},
+				},
+				Direct: []int{0},
+				Edges:  map[int][]int{0: {1}},
+			},
+			output: []string{
+				`"annotator": "Tool: scorecard"`,
+				`"comment": "ossf:scorecard:score=7.5"`,
+				`"comment": "ossf:scorecard:supply-chain-score=6.0"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Read("bom.json", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Read() failed: %v", err)
+			}
+			if s.Format != tt.format {
+				t.Errorf("Format = %s, want %s", s.Format, tt.format)
+			}
+			if diff := cmp.Diff(tt.want, s.Graph); diff != "" {
+				t.Errorf("Graph mismatch (-want +got): %s", diff)
+			}
+
+			s.Annotate(0, []Property{{Name: PropertyScore, Value: "7.5"}})
+			s.AnnotateRoot([]Property{{Name: PropertySupplyChainScore, Value: "6.0"}})
+			var out bytes.Buffer
+			if err := s.Write(&out); err != nil {
+				t.Fatalf("Write() failed: %v", err)
+			}
+			for _, want := range tt.output {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Write() output does not contain %s:\n%s", want, out.String())
+				}
+			}
+			if strings.Contains(out.String(), `"value": "1.0"`) {
+				t.Errorf("Write() output contains the replaced score:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestReadUnsupported(t *testing.T) {
+	t.Parallel()
+	for _, content := range []string{`<bom xmlns="http://cyclonedx.org/schema/bom/1.4"/>`, `{"name": "x"}`} {
+		if _, err := Read("bom", []byte(content)); err == nil {
+			t.Errorf("Read(%s) succeeded, want an error", content)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	g := &lockfile.Graph{
+		Dependencies: []lockfile.Dependency{
+			{Ecosystem: lockfile.EcosystemCrates, Name: "rand", Version: "0.8.5", Path: "Cargo.lock"},
+			{Ecosystem: lockfile.EcosystemCrates, Name: "libc", Version: "0.2.0", Path: "Cargo.lock"},
+			{Ecosystem: lockfile.EcosystemCrates, Name: "libc", Version: "0.2.0", Path: "sub/Cargo.lock"},
+		},
+		Direct: []int{0, 2},
+		Edges:  map[int][]int{0: {1}},
+	}
+	for _, format := range []Format{FormatCycloneDX, FormatSPDX} {
+		s, err := New(format, "project", "", g)
+		if err != nil {
+			t.Fatalf("New(%s) failed: %v", format, err)
+		}
+		s.Annotate(2, []Property{{Name: PropertyScore, Value: "4.0"}})
+		var out bytes.Buffer
+		if err := s.Write(&out); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+
+		// The SBOM is read back to the same graph, with each package once.
+		read, err := Read("bom.json", out.Bytes())
+		if err != nil {
+			t.Fatalf("Read(New(%s)) failed: %v", format, err)
+		}
+		want := &lockfile.Graph{
+			Dependencies: []lockfile.Dependency{
+				{Ecosystem: lockfile.EcosystemCrates, Name: "rand", Version: "0.8.5", Path: "bom.json"},
+				{Ecosystem: lockfile.EcosystemCrates, Name: "libc", Version: "0.2.0", Path: "bom.json"},
+			},
+			Direct: []int{0, 1},
+			Edges:  map[int][]int{0: {1}},
+		}
+		if diff := cmp.Diff(want, read.Graph); diff != "" {
+			t.Errorf("Read(New(%s)) mismatch (-want +got): %s", format, diff)
+		}
+		if !strings.Contains(out.String(), "4.0") {
+			t.Errorf("New(%s) output is not annotated:\n%s", format, out.String())
+		}
+	}
+}
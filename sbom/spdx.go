@@ -0,0 +1,215 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	spdxjson "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+
+	"github.com/ossf/scorecard/v4/lockfile"
+)
+
+const (
+	spdxDocumentID = common.ElementID("DOCUMENT")
+	spdxRootID     = common.ElementID("RootPackage")
+	spdxNoAssert   = "NOASSERTION"
+)
+
+var spdxNamespaceInvalid = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func readSPDX(content []byte) (*SBOM, error) {
+	doc, err := spdxjson.Read(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("spdxjson.Read: %w", err)
+	}
+	s := &SBOM{Format: FormatSPDX, doc: doc, Graph: &lockfile.Graph{Edges: make(map[int][]int)}}
+
+	ids := make(map[common.ElementID]int)
+	for _, p := range doc.Packages {
+		for _, ref := range p.PackageExternalReferences {
+			if ref.RefType != "purl" {
+				continue
+			}
+			if d, ok := DependencyFromPURL(ref.Locator); ok {
+				s.Graph.Dependencies = append(s.Graph.Dependencies, d)
+				s.packages = append(s.packages, p)
+				ids[p.PackageSPDXIdentifier] = len(s.packages) - 1
+				break
+			}
+		}
+	}
+
+	roots := s.describedPackages()
+	for _, r := range doc.Relationships {
+		from, to := r.RefA.ElementRefID, r.RefB.ElementRefID
+		switch {
+		case r.Relationship == common.TypeRelationshipDependsOn:
+		case strings.HasSuffix(r.Relationship, common.TypeRelationshipDependencyOf):
+			from, to = to, from
+		default:
+			continue
+		}
+		j, ok := ids[to]
+		if !ok {
+			continue
+		}
+		if i, ok := ids[from]; ok {
+			s.Graph.Edges[i] = append(s.Graph.Edges[i], j)
+		} else if roots[from] {
+			s.Graph.Direct = append(s.Graph.Direct, j)
+		}
+	}
+	return s, nil
+}
+
+// describedPackages returns the packages the document describes.
+func (s *SBOM) describedPackages() map[common.ElementID]bool {
+	described := make(map[common.ElementID]bool)
+	for _, r := range s.doc.Relationships {
+		if r.Relationship == common.TypeRelationshipDescribe && r.RefA.ElementRefID == s.doc.SPDXIdentifier {
+			described[r.RefB.ElementRefID] = true
+		}
+	}
+	return described
+}
+
+func newSPDX(name, version string, g *lockfile.Graph) *SBOM {
+	created := now().UTC()
+	doc := &spdx.Document{
+		SPDXVersion:    spdx.Version,
+		DataLicense:    spdx.DataLicense,
+		SPDXIdentifier: spdxDocumentID,
+		DocumentName:   name,
+		DocumentNamespace: fmt.Sprintf("https://spdx.org/spdxdocs/%s-%s-%d", toolName,
+			spdxNamespaceInvalid.ReplaceAllString(name, "-"), created.Unix()),
+		CreationInfo: &spdx.CreationInfo{
+			Created:  created.Format(time.RFC3339),
+			Creators: []common.Creator{{Creator: toolName, CreatorType: "Tool"}},
+		},
+	}
+	root := &spdx.Package{
+		PackageName:             name,
+		PackageSPDXIdentifier:   spdxRootID,
+		PackageVersion:          version,
+		PackageDownloadLocation: spdxNoAssert,
+	}
+	doc.Packages = append(doc.Packages, root)
+	doc.Relationships = append(doc.Relationships, &spdx.Relationship{
+		RefA:         common.MakeDocElementID("", string(spdxDocumentID)),
+		RefB:         common.MakeDocElementID("", string(spdxRootID)),
+		Relationship: common.TypeRelationshipDescribe,
+	})
+
+	s := &SBOM{Format: FormatSPDX, doc: doc, Graph: g}
+	ids := make([]common.ElementID, len(g.Dependencies))
+	byPURL := make(map[string]*spdx.Package)
+	for i, d := range g.Dependencies {
+		purl := PURL(d)
+		// The same package may be locked by several lockfiles.
+		if p, ok := byPURL[purl]; ok && purl != "" {
+			ids[i] = p.PackageSPDXIdentifier
+			s.packages = append(s.packages, p)
+			continue
+		}
+		ids[i] = common.ElementID(fmt.Sprintf("Package-%d", i))
+		p := &spdx.Package{
+			PackageName:             d.Name,
+			PackageSPDXIdentifier:   ids[i],
+			PackageVersion:          d.Version,
+			PackageDownloadLocation: spdxNoAssert,
+		}
+		if purl != "" {
+			p.PackageExternalReferences = []*spdx.PackageExternalReference{
+				{Category: common.CategoryPackageManager, RefType: "purl", Locator: purl},
+			}
+			byPURL[purl] = p
+		}
+		doc.Packages = append(doc.Packages, p)
+		s.packages = append(s.packages, p)
+	}
+
+	dependsOn := func(from common.ElementID, to int) {
+		doc.Relationships = append(doc.Relationships, &spdx.Relationship{
+			RefA:         common.MakeDocElementID("", string(from)),
+			RefB:         common.MakeDocElementID("", string(ids[to])),
+			Relationship: common.TypeRelationshipDependsOn,
+		})
+	}
+	for _, i := range g.Direct {
+		dependsOn(spdxRootID, i)
+	}
+	for i := range g.Dependencies {
+		for _, j := range g.Edges[i] {
+			dependsOn(ids[i], j)
+		}
+	}
+	return s
+}
+
+func (s *SBOM) annotateSPDXRoot(properties []Property) {
+	described := s.describedPackages()
+	if len(described) == 1 {
+		for _, p := range s.doc.Packages {
+			if described[p.PackageSPDXIdentifier] {
+				annotatePackage(p, properties)
+				return
+			}
+		}
+	}
+	s.doc.Annotations = setAnnotations(s.doc.Annotations, properties)
+}
+
+func annotatePackage(p *spdx.Package, properties []Property) {
+	annotations := make([]*spdx.Annotation, len(p.Annotations))
+	for i := range p.Annotations {
+		annotations[i] = &p.Annotations[i]
+	}
+	p.Annotations = nil
+	for _, a := range setAnnotations(annotations, properties) {
+		p.Annotations = append(p.Annotations, *a)
+	}
+}
+
+// setAnnotations replaces the annotations of properties, whose comments are `name=value`.
+func setAnnotations(existing []*spdx.Annotation, properties []Property) []*spdx.Annotation {
+	replaced := make(map[string]bool, len(properties))
+	for _, p := range properties {
+		replaced[p.Name] = true
+	}
+	var out []*spdx.Annotation
+	for _, a := range existing {
+		name, _, _ := strings.Cut(a.AnnotationComment, "=")
+		if a.Annotator.Annotator != toolName || !replaced[name] {
+			out = append(out, a)
+		}
+	}
+	date := now().UTC().Format(time.RFC3339)
+	for _, p := range properties {
+		out = append(out, &spdx.Annotation{
+			Annotator:         common.Annotator{Annotator: toolName, AnnotatorType: "Tool"},
+			AnnotationDate:    date,
+			AnnotationType:    "OTHER",
+			AnnotationComment: p.Name + "=" + p.Value,
+		})
+	}
+	return out
+}